@@ -1,7 +1,9 @@
 package adapters
 
 import (
+	"bytes"
 	"context"
+	"fmt"
 	"io"
 
 	"gocreator/internal/interfaces"
@@ -13,26 +15,82 @@ import (
 // OpenAIAdapter wraps the OpenAI client
 type OpenAIAdapter struct {
 	client openai.Client
+	retry  RetryPolicy
 }
 
 // NewOpenAIAdapter creates a new OpenAI adapter
 func NewOpenAIAdapter(client openai.Client) *OpenAIAdapter {
-	return &OpenAIAdapter{client: client}
+	return NewOpenAIAdapterWithRetry(client, DefaultRetryPolicy())
+}
+
+// NewOpenAIAdapterWithRetry creates a new OpenAI adapter with a custom retry policy.
+// The client should be created with option.WithMaxRetries(0) so retries are not applied twice.
+func NewOpenAIAdapterWithRetry(client openai.Client, retry RetryPolicy) *OpenAIAdapter {
+	return &OpenAIAdapter{client: client, retry: retry}
 }
 
 // ChatCompletion sends a chat completion request
 func (a *OpenAIAdapter) ChatCompletion(ctx context.Context, messages []openai.ChatCompletionMessageParamUnion) (string, error) {
-	resp, err := a.client.Chat.Completions.New(
-		ctx,
-		openai.ChatCompletionNewParams{
-			Model:    openai.ChatModelGPT4oMini,
-			Messages: messages,
-		},
-	)
+	return a.ChatCompletionWithModel(ctx, openai.ChatModelGPT4oMini, messages)
+}
+
+// ChatCompletionWithModel sends a chat completion request to a specific model.
+func (a *OpenAIAdapter) ChatCompletionWithModel(ctx context.Context, model string, messages []openai.ChatCompletionMessageParamUnion) (string, error) {
+	if model == "" {
+		model = openai.ChatModelGPT4oMini
+	}
+
+	var content string
+	err := a.retry.Do(ctx, func(ctx context.Context) error {
+		resp, err := a.client.Chat.Completions.New(
+			ctx,
+			openai.ChatCompletionNewParams{
+				Model:    model,
+				Messages: messages,
+			},
+		)
+		if err != nil {
+			return err
+		}
+		if len(resp.Choices) == 0 {
+			return fmt.Errorf("chat completion returned no choices")
+		}
+		content = resp.Choices[0].Message.Content
+		return nil
+	})
 	if err != nil {
 		return "", err
 	}
-	return resp.Choices[0].Message.Content, nil
+	return content, nil
+}
+
+// TranscribeAudio transcribes recorded speech. lang is an optional ISO-639-1 hint for the spoken language.
+func (a *OpenAIAdapter) TranscribeAudio(ctx context.Context, audio []byte, filename, model, lang string) (string, error) {
+	if model == "" {
+		model = openai.AudioModelWhisper1
+	}
+
+	var text string
+	err := a.retry.Do(ctx, func(ctx context.Context) error {
+		params := openai.AudioTranscriptionNewParams{
+			File:  openai.File(bytes.NewReader(audio), filename, ""),
+			Model: model,
+		}
+		if lang != "" {
+			params.Language = param.NewOpt(lang)
+		}
+
+		resp, err := a.client.Audio.Transcriptions.New(ctx, params)
+		if err != nil {
+			return err
+		}
+		text = resp.Text
+		return nil
+	})
+	if err != nil {
+		return "", err
+	}
+	return text, nil
 }
 
 // GenerateSpeech generates speech from text
@@ -62,12 +120,24 @@ func (a *OpenAIAdapter) GenerateSpeechWithOptions(ctx context.Context, text stri
 		params.Speed = param.NewOpt(options.Speed)
 	}
 
-	response, err := a.client.Audio.Speech.New(
-		ctx,
-		params,
-	)
+	// The body is read inside each attempt so truncated downloads are retried and the
+	// per-attempt timeout does not cut off a stream the caller is still reading.
+	var audio []byte
+	err := a.retry.Do(ctx, func(ctx context.Context) error {
+		response, err := a.client.Audio.Speech.New(
+			ctx,
+			params,
+		)
+		if err != nil {
+			return err
+		}
+		defer func() { _ = response.Body.Close() }()
+
+		audio, err = io.ReadAll(response.Body)
+		return err
+	})
 	if err != nil {
 		return nil, err
 	}
-	return response.Body, nil
+	return io.NopCloser(bytes.NewReader(audio)), nil
 }
@@ -0,0 +1,167 @@
+package adapters
+
+import (
+	"context"
+	"errors"
+	"io"
+	"math/rand/v2"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"gocreator/internal/config"
+	"gocreator/internal/interfaces"
+
+	"github.com/openai/openai-go/v3"
+)
+
+// RetryPolicy retries transient API failures with exponential backoff and jitter.
+type RetryPolicy struct {
+	MaxAttempts    int           // total attempts per call; values below 2 disable retries
+	InitialBackoff time.Duration // delay before the first retry
+	MaxBackoff     time.Duration // upper bound for the exponential delay
+	Timeout        time.Duration // per-attempt timeout; zero disables it
+
+	// sleep waits between attempts; tests replace it to avoid real delays.
+	sleep func(ctx context.Context, d time.Duration) error
+}
+
+// NewRetryPolicy creates a retry policy from configuration.
+func NewRetryPolicy(cfg config.RetryConfig) RetryPolicy {
+	return RetryPolicy{
+		MaxAttempts:    cfg.MaxAttempts,
+		InitialBackoff: cfg.InitialBackoffDuration(),
+		MaxBackoff:     cfg.MaxBackoffDuration(),
+		Timeout:        cfg.TimeoutDuration(),
+	}
+}
+
+// DefaultRetryPolicy returns the retry policy used when none is configured.
+func DefaultRetryPolicy() RetryPolicy {
+	return NewRetryPolicy(config.DefaultRetryConfig())
+}
+
+// Do runs fn until it succeeds, fails permanently, or runs out of attempts. Each attempt gets
+// its own timeout-bound context.
+func (p RetryPolicy) Do(ctx context.Context, fn func(ctx context.Context) error) error {
+	attempts := max(1, p.MaxAttempts)
+
+	var err error
+	for attempt := 1; ; attempt++ {
+		interfaces.NotifyAPIAttempt(ctx)
+		err = p.attempt(ctx, fn)
+		if err == nil || attempt >= attempts || ctx.Err() != nil || !IsRetryable(err) {
+			return err
+		}
+
+		if sleepErr := p.wait(ctx, p.backoff(attempt, err)); sleepErr != nil {
+			return err
+		}
+	}
+}
+
+func (p RetryPolicy) attempt(ctx context.Context, fn func(ctx context.Context) error) error {
+	if p.Timeout <= 0 {
+		return fn(ctx)
+	}
+
+	attemptCtx, cancel := context.WithTimeout(ctx, p.Timeout)
+	defer cancel()
+	return fn(attemptCtx)
+}
+
+// backoff returns the delay before retry number attempt, preferring the server's Retry-After.
+// Retry-After is capped at MaxBackoff so a large value cannot stall the run.
+func (p RetryPolicy) backoff(attempt int, err error) time.Duration {
+	if retryAfter, ok := retryAfterDelay(err); ok {
+		if p.MaxBackoff > 0 && retryAfter > p.MaxBackoff {
+			return p.MaxBackoff
+		}
+		return retryAfter
+	}
+
+	if p.InitialBackoff <= 0 {
+		return 0
+	}
+
+	delay := p.InitialBackoff << (attempt - 1)
+	if delay <= 0 || (p.MaxBackoff > 0 && delay > p.MaxBackoff) {
+		delay = p.MaxBackoff
+	}
+
+	// Full jitter between half and the whole delay spreads out concurrent retries.
+	half := delay / 2
+	return half + time.Duration(rand.Int64N(int64(half)+1))
+}
+
+func (p RetryPolicy) wait(ctx context.Context, d time.Duration) error {
+	if p.sleep != nil {
+		return p.sleep(ctx, d)
+	}
+
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// IsRetryable reports whether an API error is transient: rate limits, server errors, timeouts,
+// and connection failures. Other client errors, such as an invalid voice, are permanent.
+func IsRetryable(err error) bool {
+	if err == nil {
+		return false
+	}
+
+	var apiErr *openai.Error
+	if errors.As(err, &apiErr) {
+		switch apiErr.StatusCode {
+		case http.StatusRequestTimeout, http.StatusConflict, http.StatusTooManyRequests:
+			return true
+		default:
+			return apiErr.StatusCode >= http.StatusInternalServerError
+		}
+	}
+
+	if errors.Is(err, context.Canceled) {
+		return false
+	}
+	if errors.Is(err, context.DeadlineExceeded) || errors.Is(err, io.ErrUnexpectedEOF) {
+		return true
+	}
+
+	var netErr net.Error
+	return errors.As(err, &netErr)
+}
+
+// retryAfterDelay reads Retry-After (seconds or HTTP date) or retry-after-ms from an API error.
+func retryAfterDelay(err error) (time.Duration, bool) {
+	var apiErr *openai.Error
+	if !errors.As(err, &apiErr) || apiErr.Response == nil {
+		return 0, false
+	}
+
+	header := apiErr.Response.Header
+	if value := strings.TrimSpace(header.Get("Retry-After-Ms")); value != "" {
+		if ms, parseErr := strconv.ParseFloat(value, 64); parseErr == nil && ms >= 0 {
+			return time.Duration(ms * float64(time.Millisecond)), true
+		}
+	}
+
+	value := strings.TrimSpace(header.Get("Retry-After"))
+	if value == "" {
+		return 0, false
+	}
+	if seconds, parseErr := strconv.ParseFloat(value, 64); parseErr == nil && seconds >= 0 {
+		return time.Duration(seconds * float64(time.Second)), true
+	}
+	if date, parseErr := http.ParseTime(value); parseErr == nil {
+		return max(0, time.Until(date)), true
+	}
+	return 0, false
+}
@@ -0,0 +1,201 @@
+package adapters
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"gocreator/internal/config"
+	"gocreator/internal/interfaces"
+
+	"github.com/openai/openai-go/v3"
+	"github.com/openai/openai-go/v3/option"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const chatCompletionResponse = `{"id":"chatcmpl-1","object":"chat.completion","created":1,"model":"gpt-4o-mini",
+"choices":[{"index":0,"finish_reason":"stop","message":{"role":"assistant","content":"Bonjour"}}]}`
+
+type recordedSleeps struct {
+	delays []time.Duration
+}
+
+func (r *recordedSleeps) sleep(_ context.Context, d time.Duration) error {
+	r.delays = append(r.delays, d)
+	return nil
+}
+
+func newTestAdapter(t *testing.T, handler http.HandlerFunc, policy RetryPolicy) (*OpenAIAdapter, *recordedSleeps) {
+	t.Helper()
+
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+
+	sleeps := &recordedSleeps{}
+	policy.sleep = sleeps.sleep
+	client := openai.NewClient(
+		option.WithBaseURL(server.URL),
+		option.WithAPIKey("test-key"),
+		option.WithMaxRetries(0),
+	)
+	return NewOpenAIAdapterWithRetry(client, policy), sleeps
+}
+
+func testRetryPolicy() RetryPolicy {
+	return RetryPolicy{MaxAttempts: 3, InitialBackoff: time.Second, MaxBackoff: 4 * time.Second, Timeout: 5 * time.Second}
+}
+
+func TestOpenAIAdapter_RetriesRateLimitWithRetryAfter(t *testing.T) {
+	tests := []struct {
+		name       string
+		retryAfter string
+		want       time.Duration
+	}{
+		{name: "server delay", retryAfter: "3", want: 3 * time.Second},
+		{name: "capped at max backoff", retryAfter: "86400", want: 4 * time.Second},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var calls int32
+			adapter, sleeps := newTestAdapter(t, func(w http.ResponseWriter, r *http.Request) {
+				if atomic.AddInt32(&calls, 1) == 1 {
+					w.Header().Set("Retry-After", tt.retryAfter)
+					w.WriteHeader(http.StatusTooManyRequests)
+					_, _ = w.Write([]byte(`{"error":{"message":"slow down","type":"rate_limit"}}`))
+					return
+				}
+				w.Header().Set("Content-Type", "application/json")
+				_, _ = w.Write([]byte(chatCompletionResponse))
+			}, testRetryPolicy())
+
+			content, err := adapter.ChatCompletion(context.Background(), []openai.ChatCompletionMessageParamUnion{openai.UserMessage("Hello")})
+
+			require.NoError(t, err)
+			assert.Equal(t, "Bonjour", content)
+			assert.Equal(t, int32(2), atomic.LoadInt32(&calls))
+			assert.Equal(t, []time.Duration{tt.want}, sleeps.delays)
+		})
+	}
+}
+
+func TestRetryPolicy_NotifiesEveryAttempt(t *testing.T) {
+	policy := testRetryPolicy()
+	policy.sleep = (&recordedSleeps{}).sleep
+
+	attempts := 0
+	ctx := interfaces.WithAPIAttemptHook(context.Background(), func() { attempts++ })
+	calls := 0
+	err := policy.Do(ctx, func(context.Context) error {
+		calls++
+		if calls < 3 {
+			return &openai.Error{StatusCode: http.StatusServiceUnavailable}
+		}
+		return nil
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, 3, attempts)
+}
+
+func TestOpenAIAdapter_DoesNotRetryPermanentErrors(t *testing.T) {
+	var calls int32
+	adapter, sleeps := newTestAdapter(t, func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte(`{"error":{"message":"invalid voice","type":"invalid_request_error"}}`))
+	}, testRetryPolicy())
+
+	_, err := adapter.GenerateSpeechWithOptions(context.Background(), "Hello", interfaces.SpeechOptions{Voice: "robot"})
+
+	require.Error(t, err)
+	assert.False(t, IsRetryable(err))
+	assert.Equal(t, int32(1), atomic.LoadInt32(&calls))
+	assert.Empty(t, sleeps.delays)
+}
+
+func TestOpenAIAdapter_RetriesServerErrorsUntilAttemptsRunOut(t *testing.T) {
+	var calls int32
+	adapter, sleeps := newTestAdapter(t, func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		w.WriteHeader(http.StatusServiceUnavailable)
+		_, _ = w.Write([]byte(`{"error":{"message":"overloaded"}}`))
+	}, testRetryPolicy())
+
+	_, err := adapter.GenerateSpeech(context.Background(), "Hello")
+
+	require.Error(t, err)
+	assert.Equal(t, int32(3), atomic.LoadInt32(&calls))
+	require.Len(t, sleeps.delays, 2)
+	assert.GreaterOrEqual(t, sleeps.delays[0], 500*time.Millisecond)
+	assert.LessOrEqual(t, sleeps.delays[0], time.Second)
+	assert.GreaterOrEqual(t, sleeps.delays[1], time.Second)
+	assert.LessOrEqual(t, sleeps.delays[1], 2*time.Second)
+}
+
+func TestOpenAIAdapter_SpeechRetriesAfterPerCallTimeout(t *testing.T) {
+	var calls int32
+	policy := testRetryPolicy()
+	policy.Timeout = 50 * time.Millisecond
+	adapter, _ := newTestAdapter(t, func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&calls, 1) == 1 {
+			select {
+			case <-r.Context().Done():
+			case <-time.After(200 * time.Millisecond):
+			}
+			return
+		}
+		w.Header().Set("Content-Type", "audio/mpeg")
+		_, _ = w.Write([]byte("ID3-audio"))
+	}, policy)
+
+	body, err := adapter.GenerateSpeech(context.Background(), "Hello")
+	require.NoError(t, err)
+	defer func() { _ = body.Close() }()
+
+	data, err := io.ReadAll(body)
+	require.NoError(t, err)
+	assert.Equal(t, "ID3-audio", string(data))
+	assert.Equal(t, int32(2), atomic.LoadInt32(&calls))
+}
+
+func TestRetryPolicy_StopsWhenContextCancelled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	calls := 0
+	policy := testRetryPolicy()
+	policy.sleep = func(context.Context, time.Duration) error { return nil }
+
+	err := policy.Do(ctx, func(context.Context) error {
+		calls++
+		cancel()
+		return context.DeadlineExceeded
+	})
+
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.Equal(t, 1, calls)
+}
+
+func TestIsRetryable(t *testing.T) {
+	assert.True(t, IsRetryable(&openai.Error{StatusCode: http.StatusTooManyRequests}))
+	assert.True(t, IsRetryable(&openai.Error{StatusCode: http.StatusBadGateway}))
+	assert.False(t, IsRetryable(&openai.Error{StatusCode: http.StatusUnauthorized}))
+	assert.True(t, IsRetryable(io.ErrUnexpectedEOF))
+	assert.False(t, IsRetryable(context.Canceled))
+	assert.False(t, IsRetryable(errors.New("boom")))
+	assert.False(t, IsRetryable(nil))
+}
+
+func TestNewRetryPolicy(t *testing.T) {
+	policy := NewRetryPolicy(config.RetryConfig{MaxAttempts: 5, InitialBackoff: 0.5, MaxBackoff: 10, Timeout: 30})
+
+	assert.Equal(t, 5, policy.MaxAttempts)
+	assert.Equal(t, 500*time.Millisecond, policy.InitialBackoff)
+	assert.Equal(t, 10*time.Second, policy.MaxBackoff)
+	assert.Equal(t, 30*time.Second, policy.Timeout)
+}
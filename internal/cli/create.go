@@ -2,11 +2,14 @@ package cli
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"log/slog"
 	"os"
+	"os/signal"
 	"path/filepath"
 	"strings"
+	"syscall"
 
 	"gocreator/internal/adapters"
 	"gocreator/internal/config"
@@ -16,6 +19,7 @@ import (
 
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/openai/openai-go/v3"
+	"github.com/openai/openai-go/v3/option"
 	"github.com/spf13/afero"
 	"github.com/spf13/cobra"
 )
@@ -26,13 +30,14 @@ func NewCreateCommand() *cobra.Command {
 	var outputLangs string
 	var configFile string
 	var noProgress bool
+	var keepGoing bool
 
 	cmd := &cobra.Command{
 		Use:   "create",
 		Short: "Create videos from slide sidecars",
 		Long:  `Create videos by loading local slide media, inferring per-slide text and audio sidecars, generating translations and TTS when needed, and assembling the result.`,
 		RunE: func(cmd *cobra.Command, args []string) error {
-			return runCreate(inputLang, outputLangs, configFile, noProgress)
+			return runCreate(inputLang, outputLangs, configFile, noProgress, keepGoing)
 		},
 	}
 
@@ -40,11 +45,16 @@ func NewCreateCommand() *cobra.Command {
 	cmd.Flags().StringVarP(&outputLangs, "langs-out", "o", "", "Comma-separated list of output languages (overrides config file)")
 	cmd.Flags().StringVarP(&configFile, "config", "c", "", "Config file path (default: looks for gocreator.yaml in current and parent directories)")
 	cmd.Flags().BoolVar(&noProgress, "no-progress", false, "Disable progress UI")
+	cmd.Flags().BoolVar(&keepGoing, "keep-going", false, "Finish every language possible and report failures at the end instead of stopping")
 
 	return cmd
 }
 
-func runCreate(inputLang, outputLangs, configFile string, noProgress bool) error {
+func runCreate(inputLang, outputLangs, configFile string, noProgress, keepGoing bool) (err error) {
+	// Cancel the pipeline on Ctrl-C or SIGTERM so running ffmpeg processes are killed
+	ctx, stop := interruptContext()
+	defer stop()
+
 	// Get working directory
 	rootDir, err := os.Getwd()
 	if err != nil {
@@ -54,85 +64,69 @@ func runCreate(inputLang, outputLangs, configFile string, noProgress bool) error
 	// Initialize filesystem
 	fs := afero.NewOsFs()
 
-	// Load configuration
-	var cfg *config.Config
-	if configFile != "" {
-		// Use specified config file
-		cfg, err = config.LoadConfig(fs, configFile)
-		if err != nil {
-			return fmt.Errorf("failed to load config file %s: %w", configFile, err)
-		}
-		fmt.Printf("✓ Loaded config from %s\n", configFile)
-	} else {
-		// Try to find config file
-		foundPath, err := config.FindConfigFile(fs)
-		if err != nil {
-			return fmt.Errorf("error searching for config file: %w", err)
-		}
-
-		if foundPath != "" {
-			cfg, err = config.LoadConfig(fs, foundPath)
-			if err != nil {
-				return fmt.Errorf("failed to load config file %s: %w", foundPath, err)
-			}
-			fmt.Printf("✓ Loaded config from %s\n", foundPath)
-		} else {
-			// Use default config
-			cfg = config.DefaultConfig()
-			fmt.Println("ℹ Using default configuration (no config file found)")
-		}
-	}
-
-	// Override config with command-line flags
-	if inputLang != "" {
-		cfg.Input.Lang = inputLang
-	}
-	if outputLangs != "" {
-		cfg.Output.Languages = parseLanguages(outputLangs, cfg.Input.Lang)
-	}
+	// The run report is written however the run ends, including failures before creation starts.
+	// Until the config is loaded it goes to the default output directory.
+	runReport := services.NewRunReport()
+	outputDir := services.ResolveOutputDir(rootDir, config.DefaultConfig().Output.Directory)
+	defer func() {
+		runReport.Finish(err)
+		writeRunReport(fs, filepath.Join(outputDir, "run-report.json"), runReport)
+	}()
 
-	// Ensure input language is in output languages
-	if len(cfg.Output.Languages) == 0 {
-		cfg.Output.Languages = []string{cfg.Input.Lang}
+	// Load configuration
+	cfg, err := loadProjectConfig(fs, configFile, inputLang, outputLangs)
+	if err != nil {
+		return err
 	}
-	cfg.Output.Languages = ensureInputLanguageFirst(cfg.Output.Languages, cfg.Input.Lang)
+	outputDir = services.ResolveOutputDir(rootDir, cfg.Output.Directory)
 
-	if err := cfg.Validate(); err != nil {
-		return fmt.Errorf("invalid configuration: %w", err)
-	}
+	// Bound concurrent ffmpeg processes and API calls across all languages and slides
+	services.SetDefaultScheduler(services.NewScheduler(cfg.Concurrency.ResolveFFmpeg(), cfg.Concurrency.ResolveAPI()))
 
-	// Setup logging
+	// Setup logging; warnings are also collected for the run report
 	slogger := slog.New(slog.NewTextHandler(os.Stderr, nil))
-	logger := &interfaces.SlogLogger{Logger: slogger}
+	logger := runReport.Logger(&interfaces.SlogLogger{Logger: slogger})
 
 	// Initialize progress UI if enabled
 	var prog *tea.Program
 	var progressAdapter *ui.ProgressAdapter
 	if !noProgress {
 		progressModel := ui.NewProgressModel()
-		prog = tea.NewProgram(progressModel)
+		prog = tea.NewProgram(progressModel, tea.WithContext(ctx))
 		progressAdapter = ui.NewProgressAdapter(prog)
 
-		// Run progress UI in background
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithCancel(ctx)
+		defer cancel()
+
+		// Run progress UI in background; Ctrl-C inside the UI cancels the pipeline
 		go func() {
 			if _, err := prog.Run(); err != nil {
-				logger.Error("Progress UI error", "error", err)
+				if errors.Is(err, tea.ErrInterrupted) {
+					cancel()
+					return
+				}
+				if !errors.Is(err, tea.ErrProgramKilled) {
+					logger.Error("Progress UI error", "error", err)
+				}
 			}
 		}()
 	}
 
 	// Initialize OpenAI client
-	openaiClient := openai.NewClient()
-	openaiAdapter := adapters.NewOpenAIAdapter(openaiClient)
+	openaiAdapter := newOpenAIAdapter(cfg.Retry)
 
 	// Create services with dependency injection
 	textService := services.NewTextService(fs, logger)
 
 	// Create translation service with disk cache
-	translationCacheDir := filepath.Join(rootDir, cfg.Cache.Directory, "translations")
-	translationService := services.NewTranslationServiceWithCache(openaiAdapter, logger, fs, translationCacheDir)
+	translationService, err := newTranslationService(fs, rootDir, cfg, openaiAdapter, logger)
+	if err != nil {
+		return err
+	}
 
-	audioService := services.NewAudioService(fs, openaiAdapter, textService, logger)
+	ttsProviders := services.NewTTSProviderRegistry(fs, openaiAdapter, cfg.Voice)
+	audioService := services.NewAudioServiceWithProviders(fs, ttsProviders, textService, logger)
 	videoService := services.NewVideoService(fs, logger)
 	slideService := services.NewSlideService(fs, logger)
 	logger.Info("Using local slides")
@@ -148,6 +142,10 @@ func runCreate(inputLang, outputLangs, configFile string, noProgress bool) error
 		logger,
 	)
 
+	if cfg.Transcription.Enabled {
+		creator.SetTranscriptionService(newTranscriptionService(fs, rootDir, cfg, openaiAdapter, logger), cfg.Transcription.WriteSidecars)
+	}
+
 	// Create video creator configuration with progress callback
 	var progressCallback interfaces.ProgressCallback
 	if progressAdapter != nil {
@@ -177,6 +175,7 @@ func runCreate(inputLang, outputLangs, configFile string, noProgress bool) error
 		Timing:           cfg.Timing,
 		Effects:          cfg.Effects,
 		MultiView:        &cfg.MultiView,
+		Pip:              cfg.Pip,
 		Output:           cfg.Output,
 		Voice:            cfg.Voice,
 		Encoding:         cfg.Encoding,
@@ -186,17 +185,30 @@ func runCreate(inputLang, outputLangs, configFile string, noProgress bool) error
 		Outro:            cfg.Outro,
 		Metadata:         cfg.Metadata,
 		Chapters:         cfg.Chapters,
+		KeepGoing:        keepGoing,
 	}
 
 	// Run video creation
-	ctx := context.Background()
-	if err := creator.Create(ctx, creatorCfg); err != nil {
+	failureReportPath := filepath.Join(outputDir, "failure-report.json")
+	err = creator.Create(services.WithRunReport(ctx, runReport), creatorCfg)
+	if err != nil {
 		if prog != nil {
 			prog.Send(ui.CompleteMsg{})
 			prog.Wait()
 		}
+		if ctx.Err() != nil {
+			return fmt.Errorf("video creation cancelled: %w", ctx.Err())
+		}
+		var partial *services.PartialFailureError
+		if errors.As(err, &partial) {
+			reportFailures(fs, failureReportPath, partial.Report)
+		}
 		return fmt.Errorf("video creation failed: %w", err)
 	}
+	if keepGoing {
+		// Drop the report of an earlier run so it is not mistaken for this one
+		_ = fs.Remove(failureReportPath)
+	}
 
 	// Complete progress
 	if prog != nil {
@@ -210,6 +222,174 @@ func runCreate(inputLang, outputLangs, configFile string, noProgress bool) error
 	return nil
 }
 
+// writeRunReport saves the run report; a failure to write it does not fail the run.
+func writeRunReport(fs afero.Fs, reportPath string, report *services.RunReport) {
+	if err := services.WriteRunReport(fs, reportPath, report); err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: %v\n", err)
+	}
+}
+
+// reportFailures prints the keep-going summary and saves it as JSON.
+func reportFailures(fs afero.Fs, reportPath string, report services.FailureReport) {
+	fmt.Fprint(os.Stderr, report.Summary())
+
+	if err := services.WriteFailureReport(fs, reportPath, report); err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: %v\n", err)
+		return
+	}
+	fmt.Fprintf(os.Stderr, "Failure report written to %s\n", reportPath)
+}
+
+// interruptContext returns a context that is cancelled on the first Ctrl-C or SIGTERM. After that
+// the default handling is restored, so a second Ctrl-C exits immediately.
+func interruptContext() (context.Context, context.CancelFunc) {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	go func() {
+		<-ctx.Done()
+		stop()
+	}()
+	return ctx, stop
+}
+
+// loadProjectConfig loads the configuration file (explicit or discovered), applies the language
+// flags, and validates the result.
+func loadProjectConfig(fs afero.Fs, configFile, inputLang, outputLangs string) (*config.Config, error) {
+	var cfg *config.Config
+	var err error
+
+	if configFile != "" {
+		// Use specified config file
+		cfg, err = config.LoadConfig(fs, configFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load config file %s: %w", configFile, err)
+		}
+		fmt.Printf("✓ Loaded config from %s\n", configFile)
+	} else {
+		// Try to find config file
+		foundPath, err := config.FindConfigFile(fs)
+		if err != nil {
+			return nil, fmt.Errorf("error searching for config file: %w", err)
+		}
+
+		if foundPath != "" {
+			cfg, err = config.LoadConfig(fs, foundPath)
+			if err != nil {
+				return nil, fmt.Errorf("failed to load config file %s: %w", foundPath, err)
+			}
+			fmt.Printf("✓ Loaded config from %s\n", foundPath)
+		} else {
+			// Use default config
+			cfg = config.DefaultConfig()
+			fmt.Println("ℹ Using default configuration (no config file found)")
+		}
+	}
+
+	// Override config with command-line flags
+	if inputLang != "" {
+		cfg.Input.Lang = inputLang
+	}
+	if outputLangs != "" {
+		cfg.Output.Languages = parseLanguages(outputLangs, cfg.Input.Lang)
+	}
+
+	// Ensure input language is in output languages
+	if len(cfg.Output.Languages) == 0 {
+		cfg.Output.Languages = []string{cfg.Input.Lang}
+	}
+	cfg.Output.Languages = ensureInputLanguageFirst(cfg.Output.Languages, cfg.Input.Lang)
+
+	if err := cfg.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid configuration: %w", err)
+	}
+
+	return cfg, nil
+}
+
+// newTranslationService creates the cached translation service configured for the project.
+func newTranslationService(fs afero.Fs, rootDir string, cfg *config.Config, openaiAdapter *adapters.OpenAIAdapter, logger interfaces.Logger) (*services.TranslationService, error) {
+	provider, err := newTranslationProvider(cfg.Translation, cfg.Retry, openaiAdapter)
+	if err != nil {
+		return nil, err
+	}
+
+	cacheDir := filepath.Join(rootDir, cfg.Cache.Directory, "translations")
+	translationService := services.NewTranslationServiceWithProvider(provider, logger, fs, cacheDir)
+	translationService.SetDeckMode(cfg.Translation.DeckMode())
+
+	glossary, err := loadGlossary(fs, rootDir, cfg.Translation)
+	if err != nil {
+		return nil, err
+	}
+	if glossary != nil {
+		translationService.SetGlossary(glossary, cfg.Translation.StrictGlossary())
+	}
+
+	return translationService, nil
+}
+
+// newTranscriptionService creates the cached transcription service for prerecorded narration.
+func newTranscriptionService(fs afero.Fs, rootDir string, cfg *config.Config, openaiAdapter *adapters.OpenAIAdapter, logger interfaces.Logger) *services.TranscriptionService {
+	var provider interfaces.TranscriptionProvider
+	switch cfg.Transcription.ResolveProvider() {
+	case config.TranscriptionProviderCommand:
+		provider = services.NewCommandTranscriptionProvider(fs, nil, cfg.Transcription.Binary, cfg.Transcription.Args)
+	default:
+		provider = services.NewOpenAITranscriptionProvider(fs, openaiAdapter, cfg.Transcription.ResolveModel())
+	}
+
+	cacheDir := filepath.Join(rootDir, cfg.Cache.Directory, "transcriptions")
+	return services.NewTranscriptionService(fs, provider, logger, cacheDir)
+}
+
+// newOpenAIAdapter creates an OpenAI adapter that retries transient failures with the configured policy.
+func newOpenAIAdapter(retry config.RetryConfig, opts ...option.RequestOption) *adapters.OpenAIAdapter {
+	// The adapter owns retries, so the SDK's built-in retries are disabled.
+	opts = append(opts, option.WithMaxRetries(0))
+	return adapters.NewOpenAIAdapterWithRetry(openai.NewClient(opts...), adapters.NewRetryPolicy(retry))
+}
+
+// newTranslationProvider builds the translation backend selected in the configuration.
+func newTranslationProvider(cfg config.TranslationConfig, retry config.RetryConfig, openaiAdapter *adapters.OpenAIAdapter) (interfaces.TranslationProvider, error) {
+	apiKey := ""
+	if cfg.APIKeyEnv != "" {
+		apiKey = os.Getenv(cfg.APIKeyEnv)
+	}
+
+	switch provider := cfg.ResolveProvider(); provider {
+	case config.TranslationProviderOpenAI:
+		return services.NewChatTranslationProvider(provider, openaiAdapter, cfg.ResolveModel()), nil
+	case config.TranslationProviderOpenAICompatible:
+		opts := []option.RequestOption{option.WithBaseURL(cfg.BaseURL)}
+		if apiKey != "" {
+			opts = append(opts, option.WithAPIKey(apiKey))
+		}
+		client := newOpenAIAdapter(retry, opts...)
+		return services.NewChatTranslationProvider(provider, client, cfg.ResolveModel()), nil
+	case config.TranslationProviderLibreTranslate:
+		return services.NewLibreTranslateProvider(cfg.BaseURL, apiKey, nil), nil
+	case config.TranslationProviderManual:
+		return services.NewManualTranslationProvider(), nil
+	default:
+		return nil, fmt.Errorf("unknown translation provider %q", cfg.Provider)
+	}
+}
+
+// loadGlossary loads the translation glossary, falling back to data/glossary.yaml when it exists.
+func loadGlossary(fs afero.Fs, rootDir string, cfg config.TranslationConfig) (*services.Glossary, error) {
+	path := cfg.Glossary
+	if path == "" {
+		path = filepath.Join(rootDir, "data", "glossary.yaml")
+		exists, err := afero.Exists(fs, path)
+		if err != nil || !exists {
+			return nil, err
+		}
+	} else if !filepath.IsAbs(path) {
+		path = filepath.Join(rootDir, path)
+	}
+
+	return services.LoadGlossary(fs, path)
+}
+
 // parseLanguages parses comma-separated languages
 func parseLanguages(outputLangs, inputLang string) []string {
 	langs := strings.Split(outputLangs, ",")
@@ -1,9 +1,16 @@
 package cli
 
 import (
+	"encoding/json"
+	"os"
+	"path/filepath"
 	"testing"
 
+	"gocreator/internal/config"
+
+	"github.com/spf13/afero"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestNewCreateCommand(t *testing.T) {
@@ -69,6 +76,23 @@ func TestCreateCommand_FlagsParsing(t *testing.T) {
 	assert.Equal(t, "true", noProgressFlag.Value.String())
 }
 
+func TestRunCreate_WritesRunReportWhenConfigFails(t *testing.T) {
+	t.Chdir(t.TempDir())
+
+	err := runCreate("", "", "missing.yaml", true, false)
+	require.Error(t, err)
+
+	data, err := os.ReadFile(filepath.Join("data", "out", "run-report.json"))
+	require.NoError(t, err)
+	var report struct {
+		Status string `json:"status"`
+		Error  string `json:"error"`
+	}
+	require.NoError(t, json.Unmarshal(data, &report))
+	assert.Equal(t, "failed", report.Status)
+	assert.Contains(t, report.Error, "missing.yaml")
+}
+
 func TestParseLanguages(t *testing.T) {
 	tests := []struct {
 		name        string
@@ -162,3 +186,20 @@ func TestEnsureInputLanguageFirst(t *testing.T) {
 		})
 	}
 }
+
+func TestLoadGlossary(t *testing.T) {
+	fs := afero.NewMemMapFs()
+	rootDir := filepath.Join(string(filepath.Separator), "project")
+
+	glossary, err := loadGlossary(fs, rootDir, config.TranslationConfig{})
+	require.NoError(t, err)
+	assert.Nil(t, glossary)
+
+	require.NoError(t, afero.WriteFile(fs, filepath.Join(rootDir, "data", "glossary.yaml"), []byte("do_not_translate: [GoCreator]\n"), 0644))
+	glossary, err = loadGlossary(fs, rootDir, config.TranslationConfig{})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"GoCreator"}, glossary.DoNotTranslate)
+
+	_, err = loadGlossary(fs, rootDir, config.TranslationConfig{Glossary: "terms.yaml"})
+	assert.Error(t, err)
+}
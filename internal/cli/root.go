@@ -15,6 +15,7 @@ func NewRootCommand() *cobra.Command {
 	// Add subcommands
 	rootCmd.AddCommand(NewInitCommand())
 	rootCmd.AddCommand(NewCreateCommand())
+	rootCmd.AddCommand(NewTranslateCommand())
 
 	return rootCmd
 }
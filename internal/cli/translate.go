@@ -0,0 +1,143 @@
+package cli
+
+import (
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+
+	"gocreator/internal/interfaces"
+	"gocreator/internal/services"
+
+	"github.com/spf13/afero"
+	"github.com/spf13/cobra"
+)
+
+// NewTranslateCommand creates the translate command
+func NewTranslateCommand() *cobra.Command {
+	var inputLang string
+	var outputLangs string
+	var configFile string
+	var bundlePath string
+
+	cmd := &cobra.Command{
+		Use:   "translate",
+		Short: "Export narration translations for review",
+		Long: `Resolve narration text for every output language without generating audio or video.
+Machine translations are written as basename.<lang>.txt sidecars next to the slides, or, with --bundle,
+collected into a single XLIFF (.xlf), gettext (.po), or CSV (.csv) file for review in a CAT tool.
+Use "gocreator translate import" to turn a reviewed bundle back into sidecars.`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runTranslateExport(inputLang, outputLangs, configFile, bundlePath)
+		},
+	}
+
+	cmd.Flags().StringVarP(&inputLang, "lang", "l", "", "Language of the text input (overrides config file)")
+	cmd.Flags().StringVarP(&outputLangs, "langs-out", "o", "", "Comma-separated list of output languages (overrides config file)")
+	cmd.Flags().StringVarP(&configFile, "config", "c", "", "Config file path (default: looks for gocreator.yaml in current and parent directories)")
+	cmd.Flags().StringVarP(&bundlePath, "bundle", "b", "", "Write a single review bundle (.xlf, .po, or .csv) instead of sidecars")
+
+	cmd.AddCommand(newTranslateImportCommand())
+
+	return cmd
+}
+
+func newTranslateImportCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:   "import <bundle>",
+		Short: "Import a reviewed translation bundle as sidecars",
+		Long:  `Reads a reviewed XLIFF (.xlf), gettext (.po), or CSV (.csv) bundle and writes each entry as a basename.<lang>.txt sidecar in data/slides, replacing existing sidecars.`,
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			rootDir, err := os.Getwd()
+			if err != nil {
+				return fmt.Errorf("failed to get working directory: %w", err)
+			}
+			return runTranslateImport(afero.NewOsFs(), rootDir, args[0])
+		},
+	}
+}
+
+func runTranslateExport(inputLang, outputLangs, configFile, bundlePath string) error {
+	rootDir, err := os.Getwd()
+	if err != nil {
+		return fmt.Errorf("failed to get working directory: %w", err)
+	}
+
+	fs := afero.NewOsFs()
+	cfg, err := loadProjectConfig(fs, configFile, inputLang, outputLangs)
+	if err != nil {
+		return err
+	}
+
+	// Bound concurrent ffmpeg processes and API calls across all languages and slides
+	services.SetDefaultScheduler(services.NewScheduler(cfg.Concurrency.ResolveFFmpeg(), cfg.Concurrency.ResolveAPI()))
+
+	logger := &interfaces.SlogLogger{Logger: slog.New(slog.NewTextHandler(os.Stderr, nil))}
+	openaiAdapter := newOpenAIAdapter(cfg.Retry)
+	translationService, err := newTranslationService(fs, rootDir, cfg, openaiAdapter, logger)
+	if err != nil {
+		return err
+	}
+
+	creator := services.NewVideoCreator(
+		fs,
+		services.NewTextService(fs, logger),
+		translationService,
+		nil,
+		nil,
+		services.NewSlideService(fs, logger),
+		logger,
+	)
+	if cfg.Transcription.Enabled {
+		creator.SetTranscriptionService(newTranscriptionService(fs, rootDir, cfg, openaiAdapter, logger), cfg.Transcription.WriteSidecars)
+	}
+
+	ctx, stop := interruptContext()
+	defer stop()
+
+	entries, err := creator.CollectTranslations(ctx, services.VideoCreatorConfig{
+		RootDir:     rootDir,
+		InputLang:   cfg.Input.Lang,
+		OutputLangs: cfg.Output.Languages,
+	})
+	if err != nil {
+		return fmt.Errorf("translation failed: %w", err)
+	}
+
+	if bundlePath != "" {
+		if err := services.WriteTranslationBundle(fs, bundlePath, cfg.Input.Lang, entries); err != nil {
+			return err
+		}
+		fmt.Printf("✓ Wrote %d translations to %s\n", len(entries), bundlePath)
+		return nil
+	}
+
+	written, _, err := services.WriteTranslationSidecars(fs, slidesDirFor(rootDir), entries, false)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("✓ Wrote %d translation sidecars for review\n", len(written))
+	return nil
+}
+
+func runTranslateImport(fs afero.Fs, rootDir, bundlePath string) error {
+	entries, err := services.ReadTranslationBundle(fs, bundlePath)
+	if err != nil {
+		return err
+	}
+
+	written, skipped, err := services.WriteTranslationSidecars(fs, slidesDirFor(rootDir), entries, true)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("✓ Imported %d translations from %s\n", len(written), bundlePath)
+	for _, entry := range skipped {
+		fmt.Printf("⚠ Skipped %s (%s): the translation is empty\n", entry.Slide, entry.Lang)
+	}
+	return nil
+}
+
+func slidesDirFor(rootDir string) string {
+	return filepath.Join(rootDir, "data", "slides")
+}
@@ -0,0 +1,40 @@
+package cli
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewTranslateCommand(t *testing.T) {
+	cmd := NewTranslateCommand()
+
+	assert.Equal(t, "translate", cmd.Use)
+	assert.NotEmpty(t, cmd.Long)
+	assert.NotNil(t, cmd.Flags().Lookup("bundle"))
+	assert.NotNil(t, cmd.Flags().Lookup("langs-out"))
+
+	var hasImport bool
+	for _, c := range cmd.Commands() {
+		if c.Name() == "import" {
+			hasImport = true
+		}
+	}
+	assert.True(t, hasImport, "import subcommand should be present")
+}
+
+func TestRunTranslateImport(t *testing.T) {
+	fs := afero.NewMemMapFs()
+	rootDir := filepath.Join(string(filepath.Separator), "project")
+	bundlePath := filepath.Join(rootDir, "review.csv")
+	require.NoError(t, afero.WriteFile(fs, bundlePath, []byte("slide,lang,source,target\n01-intro,fr,Hello,Bonjour\n"), 0644))
+
+	require.NoError(t, runTranslateImport(fs, rootDir, bundlePath))
+
+	data, err := afero.ReadFile(fs, filepath.Join(rootDir, "data", "slides", "01-intro.fr.txt"))
+	require.NoError(t, err)
+	assert.Equal(t, "Bonjour\n", string(data))
+}
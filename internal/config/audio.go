@@ -23,6 +23,8 @@ type SoundEffectConfig struct {
 	File   string  `yaml:"file"`
 	Delay  float64 `yaml:"delay,omitempty"`  // seconds
 	Volume float64 `yaml:"volume,omitempty"` // 0.0 to 1.0
+	// SlideSelector holds a slide name or glob given for slide; it is resolved into Slide once slides are loaded
+	SlideSelector string `yaml:"-"`
 }
 
 // DuckingConfig represents audio ducking settings
@@ -0,0 +1,45 @@
+package config
+
+import (
+	"fmt"
+	"runtime"
+)
+
+// ConcurrencyConfig limits how much work runs in parallel across all languages and slides.
+// Zero values are derived from the number of CPUs.
+type ConcurrencyConfig struct {
+	FFmpeg int `yaml:"ffmpeg,omitempty"` // concurrent ffmpeg/ffprobe and other external processes
+	API    int `yaml:"api,omitempty"`    // concurrent translation and TTS API requests
+}
+
+// DefaultConcurrencyConfig returns default concurrency configuration.
+func DefaultConcurrencyConfig() ConcurrencyConfig {
+	return ConcurrencyConfig{}
+}
+
+// ResolveFFmpeg returns the process limit, defaulting to half the CPUs since ffmpeg is multi-threaded.
+func (c ConcurrencyConfig) ResolveFFmpeg() int {
+	if c.FFmpeg > 0 {
+		return c.FFmpeg
+	}
+	return max(1, runtime.NumCPU()/2)
+}
+
+// ResolveAPI returns the API request limit, defaulting to twice the CPUs within [4, 16].
+func (c ConcurrencyConfig) ResolveAPI() int {
+	if c.API > 0 {
+		return c.API
+	}
+	return min(16, max(4, runtime.NumCPU()*2))
+}
+
+// Validate validates concurrency limits.
+func (c ConcurrencyConfig) Validate() error {
+	if c.FFmpeg < 0 {
+		return &ValidationError{Field: "concurrency.ffmpeg", Value: c.FFmpeg, Err: fmt.Errorf("must not be negative")}
+	}
+	if c.API < 0 {
+		return &ValidationError{Field: "concurrency.api", Value: c.API, Err: fmt.Errorf("must not be negative")}
+	}
+	return nil
+}
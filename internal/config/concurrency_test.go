@@ -0,0 +1,31 @@
+package config
+
+import (
+	"runtime"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConcurrencyConfig_Resolve(t *testing.T) {
+	defaults := DefaultConcurrencyConfig()
+	assert.Equal(t, max(1, runtime.NumCPU()/2), defaults.ResolveFFmpeg())
+	assert.GreaterOrEqual(t, defaults.ResolveAPI(), 4)
+	assert.LessOrEqual(t, defaults.ResolveAPI(), 16)
+
+	custom := ConcurrencyConfig{FFmpeg: 3, API: 40}
+	assert.Equal(t, 3, custom.ResolveFFmpeg())
+	assert.Equal(t, 40, custom.ResolveAPI())
+}
+
+func TestConcurrencyConfig_Validate(t *testing.T) {
+	require.NoError(t, DefaultConcurrencyConfig().Validate())
+
+	err := ConcurrencyConfig{API: -1}.Validate()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "concurrency.api")
+
+	cfg := loadConfigFromString(t, "concurrency:\n  ffmpeg: 2\n  api: 8\n")
+	assert.Equal(t, ConcurrencyConfig{FFmpeg: 2, API: 8}, cfg.Concurrency)
+}
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"strings"
 
 	"github.com/goccy/go-yaml"
 	"github.com/spf13/afero"
@@ -11,22 +12,26 @@ import (
 
 // Config represents the application configuration
 type Config struct {
-	Input      InputConfig      `yaml:"input"`
-	Output     OutputConfig     `yaml:"output"`
-	Voice      VoiceConfig      `yaml:"voice,omitempty"`
-	Cache      CacheConfig      `yaml:"cache,omitempty"`
-	Transition TransitionConfig `yaml:"transition,omitempty"`
-	Encoding   EncodingConfig   `yaml:"encoding,omitempty"`
-	Effects    []EffectConfig   `yaml:"effects,omitempty"`
-	Audio      AudioConfig      `yaml:"audio,omitempty"`
-	Subtitles  SubtitlesConfig  `yaml:"subtitles,omitempty"`
-	Intro      IntroConfig      `yaml:"intro,omitempty"`
-	Outro      OutroConfig      `yaml:"outro,omitempty"`
-	Timing     TimingConfig     `yaml:"timing,omitempty"`
-	Pip        PipConfig        `yaml:"pip,omitempty"`
-	Chapters   ChaptersConfig   `yaml:"chapters,omitempty"`
-	Metadata   MetadataConfig   `yaml:"metadata,omitempty"`
-	MultiView  MultiViewConfig  `yaml:"multi_view,omitempty"`
+	Input         InputConfig         `yaml:"input"`
+	Output        OutputConfig        `yaml:"output"`
+	Voice         VoiceConfig         `yaml:"voice,omitempty"`
+	Translation   TranslationConfig   `yaml:"translation,omitempty"`
+	Transcription TranscriptionConfig `yaml:"transcription,omitempty"`
+	Cache         CacheConfig         `yaml:"cache,omitempty"`
+	Concurrency   ConcurrencyConfig   `yaml:"concurrency,omitempty"`
+	Retry         RetryConfig         `yaml:"retry,omitempty"`
+	Transition    TransitionConfig    `yaml:"transition,omitempty"`
+	Encoding      EncodingConfig      `yaml:"encoding,omitempty"`
+	Effects       []EffectConfig      `yaml:"effects,omitempty"`
+	Audio         AudioConfig         `yaml:"audio,omitempty"`
+	Subtitles     SubtitlesConfig     `yaml:"subtitles,omitempty"`
+	Intro         IntroConfig         `yaml:"intro,omitempty"`
+	Outro         OutroConfig         `yaml:"outro,omitempty"`
+	Timing        TimingConfig        `yaml:"timing,omitempty"`
+	Pip           PipConfig           `yaml:"pip,omitempty"`
+	Chapters      ChaptersConfig      `yaml:"chapters,omitempty"`
+	Metadata      MetadataConfig      `yaml:"metadata,omitempty"`
+	MultiView     MultiViewConfig     `yaml:"multi_view,omitempty"`
 }
 
 // InputConfig represents input configuration
@@ -41,6 +46,35 @@ type OutputConfig struct {
 	Format    string         `yaml:"format,omitempty"`  // mp4, webm, etc
 	Quality   string         `yaml:"quality,omitempty"` // low, medium, high, ultra
 	Formats   []FormatConfig `yaml:"formats,omitempty"` // Multi-format export
+
+	RenderStrategy string `yaml:"render_strategy,omitempty"` // per-language (default), shared-picture
+}
+
+const (
+	// RenderStrategyPerLanguage encodes every slide segment separately for each language.
+	RenderStrategyPerLanguage = "per-language"
+	// RenderStrategySharedPicture encodes the silent picture of image slides once and muxes each
+	// language's narration onto a copy of it.
+	RenderStrategySharedPicture = "shared-picture"
+)
+
+// ResolveRenderStrategy returns the normalized render strategy.
+func (c OutputConfig) ResolveRenderStrategy() string {
+	strategy := strings.ToLower(strings.TrimSpace(c.RenderStrategy))
+	if strategy == "" {
+		return RenderStrategyPerLanguage
+	}
+	return strategy
+}
+
+// Validate validates output settings.
+func (c OutputConfig) Validate() error {
+	switch c.ResolveRenderStrategy() {
+	case RenderStrategyPerLanguage, RenderStrategySharedPicture:
+		return nil
+	default:
+		return &ValidationError{Field: "output.render_strategy", Value: c.RenderStrategy}
+	}
 }
 
 // FormatConfig represents a format export configuration
@@ -55,16 +89,23 @@ type FormatConfig struct {
 
 // VoiceConfig represents TTS voice configuration
 type VoiceConfig struct {
-	Model       string                `yaml:"model,omitempty"` // tts-1, tts-1-hd
-	Voice       string                `yaml:"voice,omitempty"` // alloy, echo, fable, onyx, nova, shimmer
-	Speed       float64               `yaml:"speed,omitempty"` // 0.25 to 4.0
+	Provider    string                `yaml:"provider,omitempty"` // openai, piper, espeak, command
+	Model       string                `yaml:"model,omitempty"`    // tts-1, tts-1-hd
+	Voice       string                `yaml:"voice,omitempty"`    // alloy, echo, fable, onyx, nova, shimmer
+	Speed       float64               `yaml:"speed,omitempty"`    // 0.25 to 4.0
+	Piper       LocalTTSConfig        `yaml:"piper,omitempty"`
+	Espeak      LocalTTSConfig        `yaml:"espeak,omitempty"`
+	Command     LocalTTSConfig        `yaml:"command,omitempty"`
+	Chunking    TTSChunkingConfig     `yaml:"chunking,omitempty"`
 	PerLanguage map[string]VoiceSetup `yaml:"per_language,omitempty"`
 }
 
 // VoiceSetup represents voice settings for a specific language
 type VoiceSetup struct {
-	Voice string  `yaml:"voice,omitempty"`
-	Speed float64 `yaml:"speed,omitempty"`
+	Provider      string  `yaml:"provider,omitempty"`
+	Voice         string  `yaml:"voice,omitempty"`
+	Speed         float64 `yaml:"speed,omitempty"`
+	Pronunciation string  `yaml:"pronunciation,omitempty"` // pronunciation dictionary YAML path (default data/pronunciations/<lang>.yaml when present)
 }
 
 // CacheConfig represents cache configuration
@@ -92,9 +133,11 @@ func DefaultConfig() *Config {
 			Quality:   "medium",
 		},
 		Voice: VoiceConfig{
-			Model: "tts-1-hd",
-			Voice: "alloy",
-			Speed: 1.0,
+			Provider: TTSProviderOpenAI,
+			Model:    "tts-1-hd",
+			Voice:    "alloy",
+			Speed:    1.0,
+			Chunking: DefaultTTSChunkingConfig(),
 		},
 		Cache: CacheConfig{
 			Enabled:   true,
@@ -104,10 +147,14 @@ func DefaultConfig() *Config {
 			Type:     "none",
 			Duration: 0.0,
 		},
-		Encoding:  DefaultEncodingConfig(),
-		Audio:     DefaultAudioConfig(),
-		Subtitles: DefaultSubtitlesConfig(),
-		Timing:    DefaultTimingConfig(),
+		Translation:   DefaultTranslationConfig(),
+		Transcription: DefaultTranscriptionConfig(),
+		Concurrency:   DefaultConcurrencyConfig(),
+		Retry:         DefaultRetryConfig(),
+		Encoding:      DefaultEncodingConfig(),
+		Audio:         DefaultAudioConfig(),
+		Subtitles:     DefaultSubtitlesConfig(),
+		Timing:        DefaultTimingConfig(),
 	}
 }
 
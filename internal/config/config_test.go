@@ -202,3 +202,34 @@ func TestFindConfigFile(t *testing.T) {
 		_ = path
 	})
 }
+
+func TestOutputConfig_RenderStrategy(t *testing.T) {
+	cfg := DefaultConfig()
+	assert.Equal(t, RenderStrategyPerLanguage, cfg.Output.ResolveRenderStrategy())
+	assert.NoError(t, cfg.Validate())
+
+	cfg = loadConfigFromString(t, `
+output:
+  languages: [en, fr]
+  render_strategy: Shared-Picture
+`)
+	assert.Equal(t, RenderStrategySharedPicture, cfg.Output.ResolveRenderStrategy())
+	assert.NoError(t, cfg.Validate())
+
+	cfg.Output.RenderStrategy = "per-slide"
+	err := cfg.Validate()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "output.render_strategy")
+}
+
+func loadConfigFromString(t *testing.T, yamlContent string) *Config {
+	t.Helper()
+
+	fs := afero.NewMemMapFs()
+	path := "/test/config.yaml"
+	require.NoError(t, afero.WriteFile(fs, path, []byte(yamlContent), 0644))
+
+	cfg, err := LoadConfig(fs, path)
+	require.NoError(t, err)
+	return cfg
+}
@@ -72,7 +72,7 @@ func (e EffectConfig) ParseSlides(totalSlides int) []int {
 	case []interface{}:
 		slides := make([]int, 0, len(v))
 		for _, item := range v {
-			if num, ok := item.(int); ok {
+			if num, ok := slideNumber(item); ok {
 				if num >= 0 && num < totalSlides {
 					slides = append(slides, num)
 				}
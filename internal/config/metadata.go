@@ -10,6 +10,8 @@ type ChaptersConfig struct {
 type ChapterMarker struct {
 	Slide int    `yaml:"slide"`
 	Title string `yaml:"title"`
+	// SlideSelector holds a slide name or glob given for slide; it is resolved into Slide once slides are loaded
+	SlideSelector string `yaml:"-"`
 }
 
 // MetadataConfig represents video metadata configuration
@@ -32,4 +34,6 @@ type ThumbnailConfig struct {
 	FrameTime   float64 `yaml:"frame_time,omitempty"`
 	CustomFile  string  `yaml:"custom_file,omitempty"`
 	OverlayText string  `yaml:"overlay_text,omitempty"`
+	// SlideSelector holds a slide name or glob given for slide_index; it is resolved into SlideIndex once slides are loaded
+	SlideSelector string `yaml:"-"`
 }
@@ -1,5 +1,8 @@
 package config
 
+// MultiViewSlideSource is a layout source that stands for the slide's own narrated segment.
+const MultiViewSlideSource = "slide"
+
 // MultiViewConfig defines multi-view layout configuration
 type MultiViewConfig struct {
 	Enabled bool           `yaml:"enabled"`
@@ -8,7 +11,7 @@ type MultiViewConfig struct {
 
 // LayoutConfig defines a single layout for specific slides
 type LayoutConfig struct {
-	Type   string      `yaml:"type"` // split-horizontal, split-vertical, pip, grid, focus-gallery, custom
+	Type   string      `yaml:"type"` // split-horizontal, split-vertical, pip, grid, focus-gallery, custom, active-speaker
 	Slides interface{} `yaml:"slides"` // "0-5", [0, 1, 2], "all", or single number
 
 	// Split screen
@@ -38,8 +41,14 @@ type LayoutConfig struct {
 	// Custom
 	CustomVideos []CustomVideoConfig `yaml:"custom_videos,omitempty"`
 
+	// Active speaker
+	ActiveSpeaker ActiveSpeakerConfig `yaml:"active_speaker,omitempty"`
+
 	// Sync
 	Sync SyncConfig `yaml:"sync,omitempty"`
+
+	// Audio
+	Audio LayoutAudioConfig `yaml:"audio,omitempty"`
 }
 
 // VideoSources defines video sources for split screen layouts
@@ -55,21 +64,158 @@ type CustomVideoConfig struct {
 	Source   string         `yaml:"source"`
 	Position [2]int         `yaml:"position"`          // x, y
 	Size     [2]int         `yaml:"size"`              // width, height
-	ZIndex   int            `yaml:"z_index,omitempty"` // stacking order
-	Opacity  float64        `yaml:"opacity,omitempty"` // 0.0 to 1.0
+	ZIndex   int            `yaml:"z_index,omitempty"` // stacking order, higher is drawn on top
+	Opacity  float64        `yaml:"opacity,omitempty"` // 0.0 to 1.0, default 1.0
 	Effects  []EffectConfig `yaml:"effects,omitempty"`
 }
 
+// ResolveOpacity returns the video opacity, defaulting to fully opaque
+func (v CustomVideoConfig) ResolveOpacity() float64 {
+	if v.Opacity <= 0 || v.Opacity > 1 {
+		return 1.0
+	}
+	return v.Opacity
+}
+
+// Active speaker modes
+const (
+	ActiveSpeakerModeCut          = "cut"
+	ActiveSpeakerModeFocusGallery = "focus-gallery"
+)
+
+// ActiveSpeakerConfig defines a layout that follows whoever is speaking
+type ActiveSpeakerConfig struct {
+	Speakers      []string      `yaml:"speakers"`
+	Mode          string        `yaml:"mode,omitempty"`           // cut (default), focus-gallery
+	Hysteresis    float64       `yaml:"hysteresis,omitempty"`     // dB a speaker must be louder than the current one to take over, default 6
+	MinShot       float64       `yaml:"min_shot,omitempty"`       // seconds before the next switch, default 2
+	Threshold     float64       `yaml:"threshold,omitempty"`      // dB RMS below which a speaker counts as silent, default -45
+	Window        float64       `yaml:"window,omitempty"`         // seconds per level measurement, default 0.5
+	DebugTimeline bool          `yaml:"debug_timeline,omitempty"` // write the switch timeline next to the composed segment
+	Shots         []SpeakerShot `yaml:"-"`                        // filled in from audio analysis before rendering
+}
+
+// SpeakerShot is a span of the slide during which one speaker is shown
+type SpeakerShot struct {
+	Start   float64 `json:"start"`
+	End     float64 `json:"end"`
+	Speaker int     `json:"speaker"`
+	Source  string  `json:"source"`
+}
+
+// ResolveMode returns the active speaker mode, defaulting to cut
+func (c ActiveSpeakerConfig) ResolveMode() string {
+	if c.Mode == "" {
+		return ActiveSpeakerModeCut
+	}
+	return c.Mode
+}
+
+// ResolveHysteresis returns the switching margin in dB, defaulting to 6
+func (c ActiveSpeakerConfig) ResolveHysteresis() float64 {
+	if c.Hysteresis <= 0 {
+		return 6
+	}
+	return c.Hysteresis
+}
+
+// ResolveMinShot returns the shortest shot in seconds, defaulting to 2
+func (c ActiveSpeakerConfig) ResolveMinShot() float64 {
+	if c.MinShot <= 0 {
+		return 2
+	}
+	return c.MinShot
+}
+
+// ResolveThreshold returns the speech threshold in dB RMS, defaulting to -45
+func (c ActiveSpeakerConfig) ResolveThreshold() float64 {
+	if c.Threshold >= 0 {
+		return -45
+	}
+	return c.Threshold
+}
+
+// ResolveWindow returns the level measurement window in seconds, defaulting to 0.5
+func (c ActiveSpeakerConfig) ResolveWindow() float64 {
+	if c.Window <= 0 {
+		return 0.5
+	}
+	return c.Window
+}
+
 // BorderConfig defines border styling
 type BorderConfig struct {
 	Width int    `yaml:"width"`
 	Color string `yaml:"color"`
 }
 
-// SyncConfig defines synchronization settings
+// SyncConfig defines synchronization settings. An offset is how many seconds of a source are skipped
+// so that it lines up with the other sources.
 type SyncConfig struct {
-	Enabled bool    `yaml:"enabled"`
-	Offset  float64 `yaml:"offset"` // seconds
+	Enabled   bool      `yaml:"enabled"`
+	Offset    float64   `yaml:"offset"`               // seconds, for every source after the first
+	Offsets   []float64 `yaml:"offsets,omitempty"`    // seconds per source in layout order, overrides offset
+	Auto      bool      `yaml:"auto,omitempty"`       // detect offsets by audio cross-correlation with the first source
+	MaxOffset float64   `yaml:"max_offset,omitempty"` // largest detected offset in seconds, default 10
+	Window    float64   `yaml:"window,omitempty"`     // seconds of audio compared, default 60
+}
+
+// SourceOffset returns the configured offset for the source at index
+func (c SyncConfig) SourceOffset(index int) float64 {
+	if !c.Enabled {
+		return 0
+	}
+	if len(c.Offsets) > 0 {
+		if index < len(c.Offsets) {
+			return c.Offsets[index]
+		}
+		return 0
+	}
+	if index > 0 {
+		return c.Offset
+	}
+	return 0
+}
+
+// ResolveMaxOffset returns the largest offset automatic detection searches, defaulting to 10 seconds
+func (c SyncConfig) ResolveMaxOffset() float64 {
+	if c.MaxOffset <= 0 {
+		return 10
+	}
+	return c.MaxOffset
+}
+
+// ResolveWindow returns how many seconds of audio automatic detection compares, defaulting to 60
+func (c SyncConfig) ResolveWindow() float64 {
+	if c.Window <= 0 {
+		return 60
+	}
+	return c.Window
+}
+
+// LayoutAudioConfig defines how the slide narration and the layout sources are mixed
+type LayoutAudioConfig struct {
+	NarrationVolume float64   `yaml:"narration_volume,omitempty"` // default 1.0
+	SourceVolumes   []float64 `yaml:"source_volumes,omitempty"`   // per source in layout order, default 1.0, 0 mutes
+}
+
+// ResolveNarrationVolume returns the narration gain, defaulting to 1.0
+func (a LayoutAudioConfig) ResolveNarrationVolume() float64 {
+	if a.NarrationVolume <= 0 {
+		return 1.0
+	}
+	return a.NarrationVolume
+}
+
+// SourceVolume returns the gain for the source at index, defaulting to 1.0
+func (a LayoutAudioConfig) SourceVolume(index int) float64 {
+	if index < 0 || index >= len(a.SourceVolumes) {
+		return 1.0
+	}
+	if a.SourceVolumes[index] < 0 {
+		return 0
+	}
+	return a.SourceVolumes[index]
 }
 
 // ParseSlides parses the slides specification into a list of indices
@@ -97,12 +243,20 @@ func (l *LayoutConfig) ParseSlides(totalSlides int) []int {
 	case []interface{}:
 		// Array of slide numbers
 		for _, item := range v {
-			if idx, ok := item.(int); ok {
+			if idx, ok := slideNumber(item); ok {
 				if idx >= 0 && idx < totalSlides {
 					indices = append(indices, idx)
 				}
 			}
 		}
+
+	case []int:
+		// Slides resolved from selectors
+		for _, idx := range v {
+			if idx >= 0 && idx < totalSlides {
+				indices = append(indices, idx)
+			}
+		}
 	}
 
 	return indices
@@ -1,5 +1,7 @@
 package config
 
+import "fmt"
+
 // PipConfig represents picture-in-picture configuration
 type PipConfig struct {
 	Enabled  bool               `yaml:"enabled,omitempty"`
@@ -8,15 +10,15 @@ type PipConfig struct {
 
 // PipOverlayConfig represents a PiP overlay configuration
 type PipOverlayConfig struct {
-	Slides         interface{}        `yaml:"slides"` // "0-3" or []int
-	Video          string             `yaml:"video"`
-	Position       string             `yaml:"position,omitempty"` // top-left, top-right, bottom-left, bottom-right, custom
-	CustomPosition PipPositionConfig  `yaml:"custom_position,omitempty"`
-	Size           string             `yaml:"size,omitempty"` // "20%" or "320x240"
-	Border         PipBorderConfig    `yaml:"border,omitempty"`
-	Opacity        float64            `yaml:"opacity,omitempty"`
-	FadeIn         float64            `yaml:"fade_in,omitempty"`
-	FadeOut        float64            `yaml:"fade_out,omitempty"`
+	Slides         interface{}       `yaml:"slides"` // "0-3" or []int
+	Video          string            `yaml:"video"`
+	Position       string            `yaml:"position,omitempty"` // top-left, top-right, bottom-left, bottom-right, custom
+	CustomPosition PipPositionConfig `yaml:"custom_position,omitempty"`
+	Size           string            `yaml:"size,omitempty"` // "20%" or "320x240"
+	Border         PipBorderConfig   `yaml:"border,omitempty"`
+	Opacity        float64           `yaml:"opacity,omitempty"`
+	FadeIn         float64           `yaml:"fade_in,omitempty"`
+	FadeOut        float64           `yaml:"fade_out,omitempty"`
 }
 
 // PipPositionConfig represents custom PiP position
@@ -31,3 +33,98 @@ type PipBorderConfig struct {
 	Width   int    `yaml:"width,omitempty"`
 	Color   string `yaml:"color,omitempty"`
 }
+
+// Validate validates picture-in-picture settings.
+func (c PipConfig) Validate() error {
+	if !c.Enabled {
+		return nil
+	}
+
+	for i, overlay := range c.Overlays {
+		field := fmt.Sprintf("pip.overlays[%d]", i)
+		if overlay.Video == "" {
+			return &ValidationError{Field: field + ".video", Value: overlay.Video, Err: fmt.Errorf("is required")}
+		}
+		switch overlay.Position {
+		case "", "top-left", "top-right", "bottom-left", "bottom-right":
+		case "custom":
+			if overlay.CustomPosition.X == nil || overlay.CustomPosition.Y == nil {
+				return &ValidationError{Field: field + ".custom_position", Value: overlay.CustomPosition, Err: fmt.Errorf("x and y are required for custom position")}
+			}
+		default:
+			return &ValidationError{Field: field + ".position", Value: overlay.Position, Err: fmt.Errorf("must be top-left, top-right, bottom-left, bottom-right, or custom")}
+		}
+		if overlay.Opacity < 0 || overlay.Opacity > 1 {
+			return &ValidationError{Field: field + ".opacity", Value: overlay.Opacity, Err: fmt.Errorf("must be between 0 and 1")}
+		}
+		if overlay.FadeIn < 0 {
+			return &ValidationError{Field: field + ".fade_in", Value: overlay.FadeIn, Err: fmt.Errorf("must not be negative")}
+		}
+		if overlay.FadeOut < 0 {
+			return &ValidationError{Field: field + ".fade_out", Value: overlay.FadeOut, Err: fmt.Errorf("must not be negative")}
+		}
+		if overlay.Border.Width < 0 {
+			return &ValidationError{Field: field + ".border.width", Value: overlay.Border.Width, Err: fmt.Errorf("must not be negative")}
+		}
+	}
+	return nil
+}
+
+// ParseSlides parses the slides field into a sorted list of slide indices
+func (p PipOverlayConfig) ParseSlides(totalSlides int) []int {
+	var candidates []int
+
+	switch v := p.Slides.(type) {
+	case nil:
+		return nil
+	case string:
+		if v == "all" {
+			candidates = make([]int, totalSlides)
+			for i := range candidates {
+				candidates[i] = i
+			}
+		} else {
+			candidates = parseRange(v, totalSlides)
+		}
+	case []interface{}:
+		for _, item := range v {
+			if idx, ok := slideNumber(item); ok {
+				candidates = append(candidates, idx)
+			}
+		}
+	case []int:
+		candidates = v
+	default:
+		if idx, ok := slideNumber(v); ok {
+			candidates = []int{idx}
+		}
+	}
+
+	selected := make(map[int]bool, len(candidates))
+	for _, idx := range candidates {
+		selected[idx] = true
+	}
+
+	indices := make([]int, 0, len(selected))
+	for idx := 0; idx < totalSlides; idx++ {
+		if selected[idx] {
+			indices = append(indices, idx)
+		}
+	}
+	return indices
+}
+
+// slideNumber converts a decoded YAML number into a slide index. The YAML decoder yields uint64 for
+// plain positive integers.
+func slideNumber(value interface{}) (int, bool) {
+	switch v := value.(type) {
+	case int:
+		return v, true
+	case int64:
+		return int(v), true
+	case uint64:
+		return int(v), true
+	default:
+		return 0, false
+	}
+}
@@ -0,0 +1,54 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPipOverlayConfig_ParseSlides(t *testing.T) {
+	assert.Equal(t, []int{1, 2, 3}, PipOverlayConfig{Slides: "1-3"}.ParseSlides(5))
+	assert.Equal(t, []int{0, 1}, PipOverlayConfig{Slides: "all"}.ParseSlides(2))
+	assert.Equal(t, []int{2}, PipOverlayConfig{Slides: uint64(2)}.ParseSlides(5))
+	assert.Empty(t, PipOverlayConfig{}.ParseSlides(5))
+}
+
+func TestLoadConfig_PipOverlays(t *testing.T) {
+	cfg := loadConfigFromString(t, `pip:
+  enabled: true
+  overlays:
+    - slides: [4, 1, 2, 9]
+      video: media/webcam.mp4
+      position: custom
+      custom_position: {x: 20, y: 40}
+      opacity: 0.9
+`)
+
+	require.Len(t, cfg.Pip.Overlays, 1)
+	overlay := cfg.Pip.Overlays[0]
+	assert.Equal(t, []int{1, 2, 4}, overlay.ParseSlides(5))
+	require.NotNil(t, overlay.CustomPosition.X)
+	assert.Equal(t, 20, *overlay.CustomPosition.X)
+	require.NoError(t, cfg.Validate())
+}
+
+func TestPipConfig_Validate(t *testing.T) {
+	require.NoError(t, PipConfig{Overlays: []PipOverlayConfig{{}}}.Validate())
+
+	err := PipConfig{Enabled: true, Overlays: []PipOverlayConfig{{Slides: "0"}}}.Validate()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "pip.overlays[0].video")
+
+	err = PipConfig{Enabled: true, Overlays: []PipOverlayConfig{{Video: "cam.mp4", Position: "middle"}}}.Validate()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "pip.overlays[0].position")
+
+	err = PipConfig{Enabled: true, Overlays: []PipOverlayConfig{{Video: "cam.mp4", Position: "custom"}}}.Validate()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "custom_position")
+
+	err = PipConfig{Enabled: true, Overlays: []PipOverlayConfig{{Video: "cam.mp4", Opacity: 1.5}}}.Validate()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "pip.overlays[0].opacity")
+}
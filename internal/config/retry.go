@@ -0,0 +1,63 @@
+package config
+
+import (
+	"fmt"
+	"time"
+)
+
+// RetryConfig configures retries for remote API calls (translation and TTS).
+type RetryConfig struct {
+	MaxAttempts    int     `yaml:"max_attempts,omitempty"`    // total attempts per call, including the first
+	InitialBackoff float64 `yaml:"initial_backoff,omitempty"` // seconds before the first retry
+	MaxBackoff     float64 `yaml:"max_backoff,omitempty"`     // upper bound for exponential backoff in seconds
+	Timeout        float64 `yaml:"timeout,omitempty"`         // per-attempt timeout in seconds (0 disables)
+}
+
+// DefaultRetryConfig returns default retry configuration.
+func DefaultRetryConfig() RetryConfig {
+	return RetryConfig{
+		MaxAttempts:    4,
+		InitialBackoff: 1,
+		MaxBackoff:     30,
+		Timeout:        120,
+	}
+}
+
+// InitialBackoffDuration returns the initial backoff as a duration.
+func (c RetryConfig) InitialBackoffDuration() time.Duration {
+	return secondsToDuration(c.InitialBackoff)
+}
+
+// MaxBackoffDuration returns the maximum backoff as a duration.
+func (c RetryConfig) MaxBackoffDuration() time.Duration {
+	return secondsToDuration(c.MaxBackoff)
+}
+
+// TimeoutDuration returns the per-attempt timeout as a duration.
+func (c RetryConfig) TimeoutDuration() time.Duration {
+	return secondsToDuration(c.Timeout)
+}
+
+// Validate validates retry settings.
+func (c RetryConfig) Validate() error {
+	if c.MaxAttempts < 0 {
+		return &ValidationError{Field: "retry.max_attempts", Value: c.MaxAttempts, Err: fmt.Errorf("must not be negative")}
+	}
+	if c.InitialBackoff < 0 {
+		return &ValidationError{Field: "retry.initial_backoff", Value: c.InitialBackoff, Err: fmt.Errorf("must not be negative")}
+	}
+	if c.MaxBackoff < 0 {
+		return &ValidationError{Field: "retry.max_backoff", Value: c.MaxBackoff, Err: fmt.Errorf("must not be negative")}
+	}
+	if c.MaxBackoff > 0 && c.MaxBackoff < c.InitialBackoff {
+		return &ValidationError{Field: "retry.max_backoff", Value: c.MaxBackoff, Err: fmt.Errorf("must be at least retry.initial_backoff")}
+	}
+	if c.Timeout < 0 {
+		return &ValidationError{Field: "retry.timeout", Value: c.Timeout, Err: fmt.Errorf("must not be negative")}
+	}
+	return nil
+}
+
+func secondsToDuration(seconds float64) time.Duration {
+	return time.Duration(seconds * float64(time.Second))
+}
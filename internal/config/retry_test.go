@@ -0,0 +1,36 @@
+package config
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRetryConfig_Durations(t *testing.T) {
+	cfg := RetryConfig{InitialBackoff: 0.25, MaxBackoff: 8, Timeout: 90}
+
+	assert.Equal(t, 250*time.Millisecond, cfg.InitialBackoffDuration())
+	assert.Equal(t, 8*time.Second, cfg.MaxBackoffDuration())
+	assert.Equal(t, 90*time.Second, cfg.TimeoutDuration())
+}
+
+func TestRetryConfig_Validate(t *testing.T) {
+	require.NoError(t, DefaultRetryConfig().Validate())
+
+	err := RetryConfig{InitialBackoff: 5, MaxBackoff: 1}.Validate()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "retry.max_backoff")
+
+	err = RetryConfig{MaxAttempts: -1}.Validate()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "retry.max_attempts")
+}
+
+func TestLoadConfig_RetryKeepsDefaultsForOmittedFields(t *testing.T) {
+	cfg := loadConfigFromString(t, "retry:\n  max_attempts: 6\n")
+
+	assert.Equal(t, 6, cfg.Retry.MaxAttempts)
+	assert.Equal(t, DefaultRetryConfig().Timeout, cfg.Retry.Timeout)
+}
@@ -0,0 +1,230 @@
+package config
+
+import (
+	"fmt"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/goccy/go-yaml"
+)
+
+// slideNameRangeSeparator separates the first and last slide of a range by name, as in "02-intro..04-demo".
+const slideNameRangeSeparator = ".."
+
+// SelectSlides resolves a slide selector against the loaded slide paths and returns the sorted slide
+// indices it selects. A selector is "all", a zero-based index, an index range ("0-5"), a slide
+// basename with or without extension, a glob ("02-handout-page-*"), a name range ("02-intro..04-demo"),
+// or a list of those. Every selector must match at least one slide.
+func SelectSlides(selector interface{}, slides []string) ([]int, error) {
+	var terms []interface{}
+	switch v := selector.(type) {
+	case []interface{}:
+		terms = v
+	case []int:
+		for _, item := range v {
+			terms = append(terms, item)
+		}
+	case []string:
+		for _, item := range v {
+			terms = append(terms, item)
+		}
+	default:
+		terms = []interface{}{v}
+	}
+	if len(terms) == 0 {
+		return nil, fmt.Errorf("slide selector is empty")
+	}
+
+	selected := make(map[int]bool)
+	for _, term := range terms {
+		indices, err := selectSlideTerm(term, slides)
+		if err != nil {
+			return nil, err
+		}
+		for _, index := range indices {
+			selected[index] = true
+		}
+	}
+
+	indices := make([]int, 0, len(selected))
+	for index := range selected {
+		indices = append(indices, index)
+	}
+	sort.Ints(indices)
+	return indices, nil
+}
+
+// SelectSlide resolves a selector that must pick exactly one slide.
+func SelectSlide(selector interface{}, slides []string) (int, error) {
+	indices, err := SelectSlides(selector, slides)
+	if err != nil {
+		return 0, err
+	}
+	if len(indices) != 1 {
+		return 0, fmt.Errorf("slide selector %v matches %d slides, expected one", selector, len(indices))
+	}
+	return indices[0], nil
+}
+
+func selectSlideTerm(term interface{}, slides []string) ([]int, error) {
+	if index, ok := slideNumber(term); ok {
+		if index < 0 || index >= len(slides) {
+			return nil, fmt.Errorf("slide index %d is out of range (%d slides)", index, len(slides))
+		}
+		return []int{index}, nil
+	}
+
+	text, ok := term.(string)
+	if !ok {
+		return nil, fmt.Errorf("unsupported slide selector %v", term)
+	}
+	text = strings.TrimSpace(text)
+
+	if text == "all" {
+		indices := make([]int, len(slides))
+		for i := range indices {
+			indices[i] = i
+		}
+		return indices, nil
+	}
+
+	if index, err := strconv.Atoi(text); err == nil {
+		return selectSlideTerm(index, slides)
+	}
+
+	// Index ranges are clipped to the slide count
+	if from, to, ok := parseIndexRange(text); ok {
+		to = min(to, len(slides)-1)
+		if from > to {
+			return nil, fmt.Errorf("slide range %q matches no slide (%d slides)", text, len(slides))
+		}
+		indices := make([]int, 0, to-from+1)
+		for i := from; i <= to; i++ {
+			indices = append(indices, i)
+		}
+		return indices, nil
+	}
+
+	if first, last, ok := strings.Cut(text, slideNameRangeSeparator); ok {
+		start, err := matchSlideNames(strings.TrimSpace(first), slides)
+		if err != nil {
+			return nil, fmt.Errorf("slide range %q: %w", text, err)
+		}
+		end, err := matchSlideNames(strings.TrimSpace(last), slides)
+		if err != nil {
+			return nil, fmt.Errorf("slide range %q: %w", text, err)
+		}
+		from, to := start[0], end[len(end)-1]
+		if to < from {
+			return nil, fmt.Errorf("slide range %q ends before it starts", text)
+		}
+		indices := make([]int, 0, to-from+1)
+		for i := from; i <= to; i++ {
+			indices = append(indices, i)
+		}
+		return indices, nil
+	}
+
+	return matchSlideNames(text, slides)
+}
+
+// parseIndexRange parses an index range such as "0-5".
+func parseIndexRange(text string) (int, int, bool) {
+	first, last, ok := strings.Cut(text, "-")
+	if !ok || first == "" || last == "" {
+		return 0, 0, false
+	}
+	from, to := parseInt(first), parseInt(last)
+	return from, to, from >= 0 && to >= 0
+}
+
+// matchSlideNames returns the slides whose basename, with or without extension, equals or matches pattern.
+func matchSlideNames(pattern string, slides []string) ([]int, error) {
+	if _, err := filepath.Match(pattern, ""); err != nil {
+		return nil, fmt.Errorf("invalid slide pattern %q: %w", pattern, err)
+	}
+
+	var indices []int
+	for index, slide := range slides {
+		fileName := filepath.Base(slide)
+		stem := strings.TrimSuffix(fileName, filepath.Ext(fileName))
+		for _, name := range []string{stem, fileName} {
+			if matched, _ := filepath.Match(pattern, name); matched {
+				indices = append(indices, index)
+				break
+			}
+		}
+	}
+	if len(indices) == 0 {
+		return nil, fmt.Errorf("slide selector %q matches no slide", pattern)
+	}
+	return indices, nil
+}
+
+// decodeSlideReference decodes a mapping into out, a plain alias of the calling type, when its key
+// field may hold a slide name or glob instead of an index. It returns that selector and decodes the
+// rest of the mapping with the key removed, so the index field keeps its zero value until the
+// selector is resolved against the loaded slides.
+func decodeSlideReference(unmarshal func(interface{}) error, key string, out interface{}) (string, error) {
+	var fields map[string]interface{}
+	if err := unmarshal(&fields); err != nil {
+		return "", err
+	}
+
+	selector := ""
+	if value, ok := fields[key].(string); ok {
+		if index, err := strconv.Atoi(strings.TrimSpace(value)); err == nil {
+			fields[key] = index
+		} else {
+			selector = strings.TrimSpace(value)
+			delete(fields, key)
+		}
+	}
+
+	data, err := yaml.Marshal(fields)
+	if err != nil {
+		return "", err
+	}
+	return selector, yaml.Unmarshal(data, out)
+}
+
+// UnmarshalYAML accepts a slide name or glob as well as an index for slide.
+func (m *ChapterMarker) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	type plain ChapterMarker
+	var decoded plain
+	selector, err := decodeSlideReference(unmarshal, "slide", &decoded)
+	if err != nil {
+		return err
+	}
+	*m = ChapterMarker(decoded)
+	m.SlideSelector = selector
+	return nil
+}
+
+// UnmarshalYAML accepts a slide name or glob as well as an index for slide.
+func (c *SoundEffectConfig) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	type plain SoundEffectConfig
+	var decoded plain
+	selector, err := decodeSlideReference(unmarshal, "slide", &decoded)
+	if err != nil {
+		return err
+	}
+	*c = SoundEffectConfig(decoded)
+	c.SlideSelector = selector
+	return nil
+}
+
+// UnmarshalYAML accepts a slide name or glob as well as an index for slide_index.
+func (c *ThumbnailConfig) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	type plain ThumbnailConfig
+	var decoded plain
+	selector, err := decodeSlideReference(unmarshal, "slide_index", &decoded)
+	if err != nil {
+		return err
+	}
+	*c = ThumbnailConfig(decoded)
+	c.SlideSelector = selector
+	return nil
+}
@@ -0,0 +1,91 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSelectSlides(t *testing.T) {
+	slides := []string{
+		"/data/slides/01-title.png",
+		"/data/slides/02-intro.png",
+		"/data/cache/pdf/03-handout-page-0001.png",
+		"/data/cache/pdf/03-handout-page-0002.png",
+		"/data/slides/04-demo.mp4",
+	}
+
+	tests := []struct {
+		name     string
+		selector interface{}
+		want     []int
+	}{
+		{name: "all", selector: "all", want: []int{0, 1, 2, 3, 4}},
+		{name: "index", selector: uint64(1), want: []int{1}},
+		{name: "numeric string", selector: "4", want: []int{4}},
+		{name: "index range clipped", selector: "3-9", want: []int{3, 4}},
+		{name: "basename", selector: "02-intro", want: []int{1}},
+		{name: "file name", selector: "04-demo.mp4", want: []int{4}},
+		{name: "glob", selector: "03-handout-page-*", want: []int{2, 3}},
+		{name: "name range", selector: "02-intro..03-handout-page-*", want: []int{1, 2, 3}},
+		{name: "list", selector: []interface{}{"04-demo", uint64(0), "01-title"}, want: []int{0, 4}},
+		{name: "resolved indices", selector: []int{3, 1}, want: []int{1, 3}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := SelectSlides(tt.selector, slides)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+
+	for _, selector := range []interface{}{"05-outro", "99", "7-9", "04-demo..01-title", []interface{}{"01-title", "missing-*"}} {
+		_, err := SelectSlides(selector, slides)
+		assert.Error(t, err, "%v", selector)
+	}
+
+	index, err := SelectSlide("02-intro", slides)
+	require.NoError(t, err)
+	assert.Equal(t, 1, index)
+
+	_, err = SelectSlide("03-handout-page-*", slides)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "matches 2 slides")
+}
+
+func TestLoadConfig_SlideSelectorsByName(t *testing.T) {
+	cfg := loadConfigFromString(t, `audio:
+  sound_effects:
+    - slide: 03-handout-page-0002
+      file: click.wav
+      delay: 0.5
+    - slide: 2
+      file: whoosh.wav
+chapters:
+  enabled: true
+  markers:
+    - slide: "02-intro"
+      title: Introduction
+metadata:
+  thumbnail:
+    enabled: true
+    source: slide
+    slide_index: 04-demo
+effects:
+  - type: vignette
+    slides: [01-title, "03-handout-page-*"]
+`)
+
+	require.Len(t, cfg.Audio.SoundEffects, 2)
+	assert.Equal(t, SoundEffectConfig{File: "click.wav", Delay: 0.5, SlideSelector: "03-handout-page-0002"}, cfg.Audio.SoundEffects[0])
+	assert.Equal(t, SoundEffectConfig{Slide: 2, File: "whoosh.wav"}, cfg.Audio.SoundEffects[1])
+	assert.Equal(t, []ChapterMarker{{Title: "Introduction", SlideSelector: "02-intro"}}, cfg.Chapters.Markers)
+	assert.True(t, cfg.Metadata.Thumbnail.Enabled)
+	assert.Equal(t, "04-demo", cfg.Metadata.Thumbnail.SlideSelector)
+
+	indices, err := SelectSlides(cfg.Effects[0].Slides, []string{"01-title.png", "02-intro.png", "03-handout-page-0001.png"})
+	require.NoError(t, err)
+	assert.Equal(t, []int{0, 2}, indices)
+}
@@ -1,13 +1,40 @@
 package config
 
+import (
+	"fmt"
+	"strings"
+)
+
+const (
+	// SubtitleAlignmentAuto uses TTS word timestamps when the engine reports them, then the aligner command when one is configured.
+	SubtitleAlignmentAuto = "auto"
+	// SubtitleAlignmentTTS only uses word timestamps reported by the TTS engine.
+	SubtitleAlignmentTTS = "tts"
+	// SubtitleAlignmentCommand aligns narration with a local aligner or whisper-compatible command.
+	SubtitleAlignmentCommand = "command"
+	// SubtitleAlignmentNone shows each slide's text for its whole narration.
+	SubtitleAlignmentNone = "none"
+)
+
+const (
+	// SubtitleModeLine shows each cue as plain lines.
+	SubtitleModeLine = "line"
+	// SubtitleModeKaraoke highlights each word of a cue as it is spoken.
+	SubtitleModeKaraoke = "karaoke"
+	// SubtitleModeWordPop shows one word at a time, popping in as it is spoken.
+	SubtitleModeWordPop = "word-pop"
+)
+
 // SubtitlesConfig represents subtitle configuration
 type SubtitlesConfig struct {
 	Enabled   bool                `yaml:"enabled,omitempty"`
 	Generate  bool                `yaml:"generate,omitempty"`
 	Languages interface{}         `yaml:"languages,omitempty"` // "all" or []string
 	BurnIn    bool                `yaml:"burn_in,omitempty"`
+	Mode      string              `yaml:"mode,omitempty"` // line (default), karaoke, word-pop
 	Style     SubtitleStyleConfig `yaml:"style,omitempty"`
 	Timing    SubtitleTimingConfig `yaml:"timing,omitempty"`
+	Alignment SubtitleAlignmentConfig `yaml:"alignment,omitempty"`
 }
 
 // SubtitleStyleConfig represents subtitle styling
@@ -17,6 +44,7 @@ type SubtitleStyleConfig struct {
 	Bold                bool    `yaml:"bold,omitempty"`
 	Italic              bool    `yaml:"italic,omitempty"`
 	Color               string  `yaml:"color,omitempty"`
+	HighlightColor      string  `yaml:"highlight_color,omitempty"` // spoken words in karaoke mode
 	OutlineColor        string  `yaml:"outline_color,omitempty"`
 	OutlineWidth        int     `yaml:"outline_width,omitempty"`
 	ShadowColor         string  `yaml:"shadow_color,omitempty"`
@@ -38,6 +66,52 @@ type SubtitleTimingConfig struct {
 	MaxDuration     float64 `yaml:"max_duration,omitempty"` // seconds
 }
 
+// SubtitleAlignmentConfig selects where the word timings that subtitle cues follow come from.
+type SubtitleAlignmentConfig struct {
+	Provider string   `yaml:"provider,omitempty"` // auto (default), tts, command, none
+	Binary   string   `yaml:"binary,omitempty"`   // aligner executable for the command provider
+	Args     []string `yaml:"args,omitempty"`     // placeholders: {audio}, {text}, {text_file}, {output}, {lang}
+}
+
+// ResolveProvider returns the normalized alignment provider.
+func (c SubtitleAlignmentConfig) ResolveProvider() string {
+	provider := strings.ToLower(strings.TrimSpace(c.Provider))
+	if provider == "" {
+		return SubtitleAlignmentAuto
+	}
+	return provider
+}
+
+// ResolveMode returns the normalized caption mode.
+func (c SubtitlesConfig) ResolveMode() string {
+	mode := strings.ToLower(strings.TrimSpace(c.Mode))
+	if mode == "" {
+		return SubtitleModeLine
+	}
+	return mode
+}
+
+// Validate validates the caption mode and subtitle alignment.
+func (c SubtitlesConfig) Validate() error {
+	switch c.ResolveMode() {
+	case SubtitleModeLine, SubtitleModeKaraoke, SubtitleModeWordPop:
+	default:
+		return &ValidationError{Field: "subtitles.mode", Value: c.Mode}
+	}
+
+	switch provider := c.Alignment.ResolveProvider(); provider {
+	case SubtitleAlignmentAuto, SubtitleAlignmentTTS, SubtitleAlignmentNone:
+		return nil
+	case SubtitleAlignmentCommand:
+		if strings.TrimSpace(c.Alignment.Binary) == "" {
+			return &ValidationError{Field: "subtitles.alignment.provider", Value: provider, Err: fmt.Errorf("subtitles.alignment.binary is required")}
+		}
+		return nil
+	default:
+		return &ValidationError{Field: "subtitles.alignment.provider", Value: c.Alignment.Provider}
+	}
+}
+
 // DefaultSubtitlesConfig returns default subtitle configuration
 func DefaultSubtitlesConfig() SubtitlesConfig {
 	return SubtitlesConfig{
@@ -51,6 +125,7 @@ func DefaultSubtitlesConfig() SubtitlesConfig {
 			Bold:              false,
 			Italic:            false,
 			Color:             "white",
+			HighlightColor:    "yellow",
 			OutlineColor:      "black",
 			OutlineWidth:      2,
 			ShadowColor:       "black",
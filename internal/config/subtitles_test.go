@@ -4,6 +4,7 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestDefaultSubtitlesConfig(t *testing.T) {
@@ -131,6 +132,38 @@ func FuzzSubtitleFontSize(f *testing.F) {
 	})
 }
 
+func TestSubtitlesConfig_ValidateAlignment(t *testing.T) {
+	assert.NoError(t, DefaultSubtitlesConfig().Validate())
+	assert.Equal(t, SubtitleAlignmentAuto, DefaultSubtitlesConfig().Alignment.ResolveProvider())
+
+	cfg := DefaultSubtitlesConfig()
+	cfg.Alignment = SubtitleAlignmentConfig{Provider: "Command"}
+	err := cfg.Validate()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "subtitles.alignment.binary")
+
+	cfg.Alignment.Binary = "whisper-align"
+	assert.NoError(t, cfg.Validate())
+
+	cfg.Alignment = SubtitleAlignmentConfig{Provider: "gentle"}
+	assert.Error(t, cfg.Validate())
+}
+
+func TestSubtitlesConfig_ValidateMode(t *testing.T) {
+	cfg := DefaultSubtitlesConfig()
+	assert.Equal(t, SubtitleModeLine, cfg.ResolveMode())
+
+	for _, mode := range []string{"line", "Karaoke", "word-pop"} {
+		cfg.Mode = mode
+		assert.NoError(t, cfg.Validate(), mode)
+	}
+
+	cfg.Mode = "typewriter"
+	err := cfg.Validate()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "subtitles.mode")
+}
+
 func FuzzSubtitleTiming(f *testing.F) {
 	// Add seed corpus
 	f.Add(1.0, 7.0)
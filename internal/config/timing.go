@@ -1,5 +1,11 @@
 package config
 
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
 const (
 	// MediaAlignmentVideo keeps video slides aligned to their clip duration.
 	MediaAlignmentVideo = "video"
@@ -21,6 +27,9 @@ type SlideTimingConfig struct {
 	Slide    int     `yaml:"slide"`
 	Speed    float64 `yaml:"speed,omitempty"`    // Speed multiplier (1.0 = normal)
 	Duration float64 `yaml:"duration,omitempty"` // Explicit duration override
+	Silent   bool    `yaml:"silent,omitempty"`   // Render without narration, even when sidecars exist
+	// PauseAfter holds an image slide for extra seconds after its narration ends
+	PauseAfter float64 `yaml:"pause_after,omitempty"`
 }
 
 // DefaultTimingConfig returns default timing configuration.
@@ -29,3 +38,117 @@ func DefaultTimingConfig() TimingConfig {
 		MediaAlignment: MediaAlignmentVideo,
 	}
 }
+
+// DefaultImageSeconds returns the configured duration for image slides without narration. It reports
+// false for "auto" or when no duration is set.
+func (c TimingConfig) DefaultImageSeconds() (float64, bool) {
+	switch v := c.DefaultImageDuration.(type) {
+	case float64:
+		return v, v > 0
+	case int:
+		return float64(v), v > 0
+	case int64:
+		return float64(v), v > 0
+	case uint64:
+		return float64(v), v > 0
+	case string:
+		seconds, err := strconv.ParseFloat(strings.TrimSpace(v), 64)
+		return seconds, err == nil && seconds > 0
+	default:
+		return 0, false
+	}
+}
+
+// ForSlide returns the per-slide timing of a slide, or a zero value when it has none.
+func (c TimingConfig) ForSlide(index int) SlideTimingConfig {
+	for _, slide := range c.PerSlide {
+		if slide.Slide == index {
+			return slide
+		}
+	}
+	return SlideTimingConfig{Slide: index}
+}
+
+// SilentDuration returns how long a slide without narration is shown, and false when the slide
+// has no configured duration and therefore needs narration.
+func (c TimingConfig) SilentDuration(index int) (float64, bool) {
+	if duration := c.ForSlide(index).Duration; duration > 0 {
+		return duration, true
+	}
+	return c.DefaultImageSeconds()
+}
+
+// WithSlide returns a copy of c with slide merged into the per-slide settings. Non-zero fields of
+// slide override an existing entry for the same index.
+func (c TimingConfig) WithSlide(slide SlideTimingConfig) TimingConfig {
+	perSlide := make([]SlideTimingConfig, 0, len(c.PerSlide)+1)
+	merged := false
+	for _, existing := range c.PerSlide {
+		if existing.Slide == slide.Slide && !merged {
+			if slide.Speed > 0 {
+				existing.Speed = slide.Speed
+			}
+			if slide.Duration > 0 {
+				existing.Duration = slide.Duration
+			}
+			if slide.PauseAfter > 0 {
+				existing.PauseAfter = slide.PauseAfter
+			}
+			existing.Silent = existing.Silent || slide.Silent
+			merged = true
+		}
+		perSlide = append(perSlide, existing)
+	}
+	if !merged {
+		perSlide = append(perSlide, slide)
+	}
+	c.PerSlide = perSlide
+	return c
+}
+
+// AdjustsSlides reports whether any setting beyond media alignment changes slide timing.
+func (c TimingConfig) AdjustsSlides() bool {
+	_, hasDefault := c.DefaultImageSeconds()
+	return len(c.PerSlide) > 0 || hasDefault || c.MinSlideDuration > 0 || c.MaxSlideDuration > 0
+}
+
+// Validate validates timing settings.
+func (c TimingConfig) Validate() error {
+	switch v := c.DefaultImageDuration.(type) {
+	case nil:
+	case string:
+		if strings.TrimSpace(v) != "auto" {
+			if _, ok := c.DefaultImageSeconds(); !ok {
+				return &ValidationError{Field: "timing.default_image_duration", Value: v, Err: fmt.Errorf("must be \"auto\" or a positive number of seconds")}
+			}
+		}
+	default:
+		if _, ok := c.DefaultImageSeconds(); !ok {
+			return &ValidationError{Field: "timing.default_image_duration", Value: v, Err: fmt.Errorf("must be \"auto\" or a positive number of seconds")}
+		}
+	}
+	if c.MinSlideDuration < 0 {
+		return &ValidationError{Field: "timing.min_slide_duration", Value: c.MinSlideDuration, Err: fmt.Errorf("must not be negative")}
+	}
+	if c.MaxSlideDuration < 0 {
+		return &ValidationError{Field: "timing.max_slide_duration", Value: c.MaxSlideDuration, Err: fmt.Errorf("must not be negative")}
+	}
+	if c.MaxSlideDuration > 0 && c.MaxSlideDuration < c.MinSlideDuration {
+		return &ValidationError{Field: "timing.max_slide_duration", Value: c.MaxSlideDuration, Err: fmt.Errorf("must be at least timing.min_slide_duration")}
+	}
+	for i, slide := range c.PerSlide {
+		if slide.Slide < 0 {
+			return &ValidationError{Field: fmt.Sprintf("timing.per_slide[%d].slide", i), Value: slide.Slide, Err: fmt.Errorf("must not be negative")}
+		}
+		if slide.Speed < 0 {
+			return &ValidationError{Field: fmt.Sprintf("timing.per_slide[%d].speed", i), Value: slide.Speed, Err: fmt.Errorf("must not be negative")}
+		}
+		if slide.Duration < 0 {
+			return &ValidationError{Field: fmt.Sprintf("timing.per_slide[%d].duration", i), Value: slide.Duration, Err: fmt.Errorf("must not be negative")}
+		}
+		if slide.PauseAfter < 0 {
+			return &ValidationError{Field: fmt.Sprintf("timing.per_slide[%d].pause_after", i), Value: slide.PauseAfter, Err: fmt.Errorf("must not be negative")}
+		}
+	}
+	return nil
+}
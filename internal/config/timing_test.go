@@ -0,0 +1,62 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoadConfig_TimingSettings(t *testing.T) {
+	cfg := loadConfigFromString(t, `timing:
+  default_image_duration: 4
+  min_slide_duration: 3
+  max_slide_duration: 45.5
+  per_slide:
+    - slide: 2
+      speed: 1.1
+    - slide: 5
+      duration: 8
+`)
+
+	seconds, ok := cfg.Timing.DefaultImageSeconds()
+	require.True(t, ok)
+	assert.Equal(t, 4.0, seconds)
+	assert.Equal(t, MediaAlignmentVideo, cfg.Timing.MediaAlignment)
+	assert.Equal(t, 1.1, cfg.Timing.ForSlide(2).Speed)
+
+	duration, ok := cfg.Timing.SilentDuration(5)
+	require.True(t, ok)
+	assert.Equal(t, 8.0, duration)
+	assert.True(t, cfg.Timing.AdjustsSlides())
+	require.NoError(t, cfg.Validate())
+}
+
+func TestTimingConfig_DefaultImageDurationAuto(t *testing.T) {
+	timing := TimingConfig{DefaultImageDuration: "auto"}
+
+	_, ok := timing.DefaultImageSeconds()
+	assert.False(t, ok)
+	_, ok = timing.SilentDuration(0)
+	assert.False(t, ok)
+	assert.False(t, timing.AdjustsSlides())
+	require.NoError(t, timing.Validate())
+}
+
+func TestTimingConfig_Validate(t *testing.T) {
+	err := TimingConfig{DefaultImageDuration: "later"}.Validate()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "timing.default_image_duration")
+
+	err = TimingConfig{MinSlideDuration: 10, MaxSlideDuration: 5}.Validate()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "timing.max_slide_duration")
+
+	err = TimingConfig{PerSlide: []SlideTimingConfig{{Slide: 1, Speed: -1}}}.Validate()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "timing.per_slide[0].speed")
+
+	err = TimingConfig{PerSlide: []SlideTimingConfig{{Slide: 1, PauseAfter: -1}}}.Validate()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "timing.per_slide[0].pause_after")
+}
@@ -0,0 +1,67 @@
+package config
+
+import (
+	"fmt"
+	"strings"
+)
+
+const (
+	// TranscriptionProviderOpenAI transcribes with the OpenAI audio transcription API.
+	TranscriptionProviderOpenAI = "openai"
+	// TranscriptionProviderCommand transcribes with a local whisper-compatible command.
+	TranscriptionProviderCommand = "command"
+
+	// DefaultTranscriptionModel is the OpenAI transcription model used when none is configured.
+	DefaultTranscriptionModel = "whisper-1"
+)
+
+// TranscriptionConfig configures how prerecorded narration is turned into text for subtitles and
+// translation.
+type TranscriptionConfig struct {
+	Enabled       bool     `yaml:"enabled,omitempty"`
+	Provider      string   `yaml:"provider,omitempty"`       // openai (default), command
+	Model         string   `yaml:"model,omitempty"`          // transcription model for openai
+	Binary        string   `yaml:"binary,omitempty"`         // transcriber executable for command
+	Args          []string `yaml:"args,omitempty"`           // placeholders: {audio}, {lang}, {output}
+	WriteSidecars bool     `yaml:"write_sidecars,omitempty"` // save transcripts as basename.<lang>.txt sidecars
+}
+
+// DefaultTranscriptionConfig returns default transcription configuration.
+func DefaultTranscriptionConfig() TranscriptionConfig {
+	return TranscriptionConfig{
+		Provider: TranscriptionProviderOpenAI,
+		Model:    DefaultTranscriptionModel,
+	}
+}
+
+// ResolveProvider returns the normalized transcription provider name.
+func (c TranscriptionConfig) ResolveProvider() string {
+	provider := strings.ToLower(strings.TrimSpace(c.Provider))
+	if provider == "" {
+		return TranscriptionProviderOpenAI
+	}
+	return provider
+}
+
+// ResolveModel returns the configured transcription model or the default one.
+func (c TranscriptionConfig) ResolveModel() string {
+	if model := strings.TrimSpace(c.Model); model != "" {
+		return model
+	}
+	return DefaultTranscriptionModel
+}
+
+// Validate validates transcription provider settings.
+func (c TranscriptionConfig) Validate() error {
+	switch provider := c.ResolveProvider(); provider {
+	case TranscriptionProviderOpenAI:
+		return nil
+	case TranscriptionProviderCommand:
+		if strings.TrimSpace(c.Binary) == "" {
+			return &ValidationError{Field: "transcription.binary", Value: c.Binary, Err: fmt.Errorf("required for provider %s", provider)}
+		}
+		return nil
+	default:
+		return &ValidationError{Field: "transcription.provider", Value: c.Provider}
+	}
+}
@@ -0,0 +1,48 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTranscriptionConfig_Validate(t *testing.T) {
+	cfg := DefaultConfig().Transcription
+	assert.False(t, cfg.Enabled)
+	assert.Equal(t, TranscriptionProviderOpenAI, cfg.ResolveProvider())
+	assert.Equal(t, DefaultTranscriptionModel, cfg.ResolveModel())
+	assert.NoError(t, cfg.Validate())
+
+	cfg.Provider = "Command"
+	err := cfg.Validate()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "transcription.binary")
+
+	cfg.Binary = "whisper-cli"
+	assert.NoError(t, cfg.Validate())
+
+	cfg.Provider = "vosk"
+	assert.Error(t, cfg.Validate())
+}
+
+func TestLoadConfig_Transcription(t *testing.T) {
+	cfg := loadConfigFromString(t, `
+input:
+  lang: en
+output:
+  languages: [en, fr]
+transcription:
+  enabled: true
+  provider: command
+  binary: whisper-cli
+  args: ["{audio}", "--language", "{lang}", "--output-txt", "{output}"]
+  write_sidecars: true
+`)
+
+	assert.True(t, cfg.Transcription.Enabled)
+	assert.True(t, cfg.Transcription.WriteSidecars)
+	assert.Equal(t, TranscriptionProviderCommand, cfg.Transcription.ResolveProvider())
+	assert.Equal(t, DefaultTranscriptionModel, cfg.Transcription.Model)
+	assert.NoError(t, cfg.Validate())
+}
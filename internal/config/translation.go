@@ -0,0 +1,107 @@
+package config
+
+import (
+	"fmt"
+	"strings"
+)
+
+const (
+	// TranslationProviderOpenAI translates with the OpenAI chat API.
+	TranslationProviderOpenAI = "openai"
+	// TranslationProviderOpenAICompatible translates with any server exposing the OpenAI chat API.
+	TranslationProviderOpenAICompatible = "openai-compatible"
+	// TranslationProviderLibreTranslate translates with a LibreTranslate-style HTTP API.
+	TranslationProviderLibreTranslate = "libretranslate"
+	// TranslationProviderManual never translates and requires per-language text sidecars.
+	TranslationProviderManual = "manual"
+
+	// DefaultTranslationModel is the chat model used when none is configured.
+	DefaultTranslationModel = "gpt-4o-mini"
+
+	// TranslationModeSlide translates each slide narration in its own request.
+	TranslationModeSlide = "slide"
+	// TranslationModeDeck translates all slide narrations of a language in one request.
+	TranslationModeDeck = "deck"
+
+	// GlossaryCheckWarn logs translations that drop protected glossary terms.
+	GlossaryCheckWarn = "warn"
+	// GlossaryCheckError fails translations that drop protected glossary terms.
+	GlossaryCheckError = "error"
+)
+
+// TranslationConfig represents machine translation configuration
+type TranslationConfig struct {
+	Provider  string `yaml:"provider,omitempty"`    // openai, openai-compatible, libretranslate, manual
+	Model     string `yaml:"model,omitempty"`       // chat model for openai and openai-compatible
+	BaseURL   string `yaml:"base_url,omitempty"`    // endpoint for openai-compatible and libretranslate
+	APIKeyEnv string `yaml:"api_key_env,omitempty"` // environment variable holding the API key
+	Mode      string `yaml:"mode,omitempty"`        // slide (one request per slide) or deck (one request per language)
+
+	Glossary      string `yaml:"glossary,omitempty"`       // glossary YAML path (default data/glossary.yaml when present)
+	GlossaryCheck string `yaml:"glossary_check,omitempty"` // warn or error when a protected term is missing
+}
+
+// DefaultTranslationConfig returns default translation configuration.
+func DefaultTranslationConfig() TranslationConfig {
+	return TranslationConfig{
+		Provider:      TranslationProviderOpenAI,
+		Model:         DefaultTranslationModel,
+		Mode:          TranslationModeSlide,
+		GlossaryCheck: GlossaryCheckWarn,
+	}
+}
+
+// ResolveProvider returns the normalized translation provider name.
+func (c TranslationConfig) ResolveProvider() string {
+	provider := strings.ToLower(strings.TrimSpace(c.Provider))
+	if provider == "" {
+		return TranslationProviderOpenAI
+	}
+	return provider
+}
+
+// ResolveModel returns the configured chat model or the default one.
+func (c TranslationConfig) ResolveModel() string {
+	if model := strings.TrimSpace(c.Model); model != "" {
+		return model
+	}
+	return DefaultTranslationModel
+}
+
+// DeckMode reports whether slide narrations are translated together as one deck.
+func (c TranslationConfig) DeckMode() bool {
+	return strings.EqualFold(strings.TrimSpace(c.Mode), TranslationModeDeck)
+}
+
+// StrictGlossary reports whether missing glossary terms should fail the translation.
+func (c TranslationConfig) StrictGlossary() bool {
+	return strings.EqualFold(strings.TrimSpace(c.GlossaryCheck), GlossaryCheckError)
+}
+
+// Validate validates translation provider settings.
+func (c TranslationConfig) Validate() error {
+	switch strings.ToLower(strings.TrimSpace(c.Mode)) {
+	case "", TranslationModeSlide, TranslationModeDeck:
+	default:
+		return &ValidationError{Field: "translation.mode", Value: c.Mode}
+	}
+
+	switch strings.ToLower(strings.TrimSpace(c.GlossaryCheck)) {
+	case "", GlossaryCheckWarn, GlossaryCheckError:
+	default:
+		return &ValidationError{Field: "translation.glossary_check", Value: c.GlossaryCheck}
+	}
+
+	provider := c.ResolveProvider()
+	switch provider {
+	case TranslationProviderOpenAI, TranslationProviderManual:
+		return nil
+	case TranslationProviderOpenAICompatible, TranslationProviderLibreTranslate:
+		if strings.TrimSpace(c.BaseURL) == "" {
+			return &ValidationError{Field: "translation.base_url", Value: c.BaseURL, Err: fmt.Errorf("required for provider %s", provider)}
+		}
+		return nil
+	default:
+		return &ValidationError{Field: "translation.provider", Value: c.Provider}
+	}
+}
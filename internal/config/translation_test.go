@@ -0,0 +1,85 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTranslationConfig_Resolve(t *testing.T) {
+	assert.Equal(t, TranslationProviderOpenAI, TranslationConfig{}.ResolveProvider())
+	assert.Equal(t, TranslationProviderLibreTranslate, TranslationConfig{Provider: " LibreTranslate "}.ResolveProvider())
+	assert.Equal(t, DefaultTranslationModel, TranslationConfig{}.ResolveModel())
+	assert.Equal(t, "llama3.1", TranslationConfig{Model: "llama3.1"}.ResolveModel())
+}
+
+func TestTranslationConfig_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		cfg     TranslationConfig
+		wantErr string
+	}{
+		{name: "default", cfg: DefaultTranslationConfig()},
+		{name: "manual", cfg: TranslationConfig{Provider: TranslationProviderManual}},
+		{
+			name:    "openai-compatible without base url",
+			cfg:     TranslationConfig{Provider: TranslationProviderOpenAICompatible},
+			wantErr: "translation.base_url",
+		},
+		{
+			name: "libretranslate with base url",
+			cfg:  TranslationConfig{Provider: TranslationProviderLibreTranslate, BaseURL: "http://localhost:5000"},
+		},
+		{
+			name:    "unknown provider",
+			cfg:     TranslationConfig{Provider: "deepl"},
+			wantErr: "translation.provider",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.cfg.Validate()
+			if tt.wantErr == "" {
+				require.NoError(t, err)
+				return
+			}
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.wantErr)
+		})
+	}
+}
+
+func TestLoadConfig_TranslationProvider(t *testing.T) {
+	cfg := loadConfigFromString(t, `translation:
+  provider: openai-compatible
+  model: llama3.1
+  base_url: http://localhost:11434/v1
+  api_key_env: OLLAMA_API_KEY
+`)
+
+	assert.Equal(t, TranslationProviderOpenAICompatible, cfg.Translation.Provider)
+	assert.Equal(t, "llama3.1", cfg.Translation.Model)
+	assert.Equal(t, "http://localhost:11434/v1", cfg.Translation.BaseURL)
+	assert.Equal(t, "OLLAMA_API_KEY", cfg.Translation.APIKeyEnv)
+	require.NoError(t, cfg.Validate())
+}
+
+func TestTranslationConfig_GlossaryCheck(t *testing.T) {
+	assert.False(t, DefaultTranslationConfig().StrictGlossary())
+	assert.True(t, TranslationConfig{GlossaryCheck: "Error"}.StrictGlossary())
+
+	err := TranslationConfig{GlossaryCheck: "ignore"}.Validate()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "translation.glossary_check")
+}
+
+func TestTranslationConfig_Mode(t *testing.T) {
+	assert.False(t, DefaultTranslationConfig().DeckMode())
+	assert.True(t, TranslationConfig{Mode: TranslationModeDeck}.DeckMode())
+
+	err := TranslationConfig{Mode: "paragraph"}.Validate()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "translation.mode")
+}
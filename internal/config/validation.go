@@ -18,11 +18,56 @@ func (e *ValidationError) Error() string {
 
 // Validate validates the entire configuration
 func (c *Config) Validate() error {
+	// Validate output settings
+	if err := c.Output.Validate(); err != nil {
+		return err
+	}
+
 	// Validate encoding
 	if err := c.Encoding.Validate(); err != nil {
 		return err
 	}
 
+	// Validate voice providers
+	if err := c.Voice.Validate(); err != nil {
+		return err
+	}
+
+	// Validate translation provider
+	if err := c.Translation.Validate(); err != nil {
+		return err
+	}
+
+	// Validate transcription provider
+	if err := c.Transcription.Validate(); err != nil {
+		return err
+	}
+
+	// Validate concurrency limits
+	if err := c.Concurrency.Validate(); err != nil {
+		return err
+	}
+
+	// Validate retry policy
+	if err := c.Retry.Validate(); err != nil {
+		return err
+	}
+
+	// Validate slide timing
+	if err := c.Timing.Validate(); err != nil {
+		return err
+	}
+
+	// Validate subtitle alignment
+	if err := c.Subtitles.Validate(); err != nil {
+		return err
+	}
+
+	// Validate picture-in-picture overlays
+	if err := c.Pip.Validate(); err != nil {
+		return err
+	}
+
 	// Add more validation as needed
 	return nil
 }
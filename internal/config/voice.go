@@ -0,0 +1,110 @@
+package config
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+const (
+	// TTSProviderOpenAI synthesizes narration with the OpenAI speech API.
+	TTSProviderOpenAI = "openai"
+	// TTSProviderPiper synthesizes narration with a local Piper binary.
+	TTSProviderPiper = "piper"
+	// TTSProviderEspeak synthesizes narration with a local espeak-ng binary.
+	TTSProviderEspeak = "espeak"
+	// TTSProviderCommand synthesizes narration with a user-defined command.
+	TTSProviderCommand = "command"
+)
+
+// LocalTTSConfig configures a TTS engine that runs as a local command.
+type LocalTTSConfig struct {
+	Binary string   `yaml:"binary,omitempty"` // executable name or path
+	Voice  string   `yaml:"voice,omitempty"`  // engine voice name or model path
+	Args   []string `yaml:"args,omitempty"`   // placeholders: {text}, {text_file}, {output}, {timestamps}, {voice}, {speed}, {wpm}, {length_scale}; without {text} or {text_file} the text goes to stdin
+	Format string   `yaml:"format,omitempty"` // audio container written by the engine (default wav)
+	SSML   bool     `yaml:"ssml,omitempty"`   // engine reads SSML, so narration markup is sent as SSML
+}
+
+// DefaultTTSMaxChars keeps each speech request under the 4096-character input limit of the OpenAI speech API.
+const DefaultTTSMaxChars = 4000
+
+// TTSChunkingConfig controls how long narration is split into several speech requests and joined again.
+type TTSChunkingConfig struct {
+	MaxChars      int     `yaml:"max_chars,omitempty"`      // longest text per speech request (default 4000)
+	Gap           float64 `yaml:"gap,omitempty"`            // seconds of silence between chunks
+	MatchLoudness bool    `yaml:"match_loudness,omitempty"` // normalize each chunk's loudness before joining
+}
+
+// DefaultTTSChunkingConfig returns default narration chunking configuration.
+func DefaultTTSChunkingConfig() TTSChunkingConfig {
+	return TTSChunkingConfig{
+		MaxChars:      DefaultTTSMaxChars,
+		MatchLoudness: true,
+	}
+}
+
+// ResolveMaxChars returns the chunk length limit, defaulting to DefaultTTSMaxChars.
+func (c TTSChunkingConfig) ResolveMaxChars() int {
+	if c.MaxChars > 0 {
+		return c.MaxChars
+	}
+	return DefaultTTSMaxChars
+}
+
+// ResolveProvider returns the normalized TTS provider for a language.
+func (c VoiceConfig) ResolveProvider(lang string) string {
+	provider := c.Provider
+	if override, ok := c.PerLanguage[lang]; ok && strings.TrimSpace(override.Provider) != "" {
+		provider = override.Provider
+	}
+
+	provider = strings.ToLower(strings.TrimSpace(provider))
+	if provider == "" {
+		return TTSProviderOpenAI
+	}
+	return provider
+}
+
+// Validate validates TTS provider selection.
+func (c VoiceConfig) Validate() error {
+	if err := c.validateProvider("voice.provider", c.Provider); err != nil {
+		return err
+	}
+
+	langs := make([]string, 0, len(c.PerLanguage))
+	for lang := range c.PerLanguage {
+		langs = append(langs, lang)
+	}
+	sort.Strings(langs)
+
+	for _, lang := range langs {
+		field := fmt.Sprintf("voice.per_language.%s.provider", lang)
+		if err := c.validateProvider(field, c.PerLanguage[lang].Provider); err != nil {
+			return err
+		}
+	}
+
+	if c.Chunking.MaxChars < 0 {
+		return &ValidationError{Field: "voice.chunking.max_chars", Value: c.Chunking.MaxChars, Err: fmt.Errorf("must not be negative")}
+	}
+	if c.Chunking.Gap < 0 {
+		return &ValidationError{Field: "voice.chunking.gap", Value: c.Chunking.Gap, Err: fmt.Errorf("must not be negative")}
+	}
+
+	return nil
+}
+
+func (c VoiceConfig) validateProvider(field, provider string) error {
+	switch strings.ToLower(strings.TrimSpace(provider)) {
+	case "", TTSProviderOpenAI, TTSProviderPiper, TTSProviderEspeak:
+		return nil
+	case TTSProviderCommand:
+		if strings.TrimSpace(c.Command.Binary) == "" {
+			return &ValidationError{Field: field, Value: provider, Err: fmt.Errorf("voice.command.binary is required")}
+		}
+		return nil
+	default:
+		return &ValidationError{Field: field, Value: provider}
+	}
+}
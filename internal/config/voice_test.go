@@ -0,0 +1,110 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestVoiceConfig_ResolveProvider(t *testing.T) {
+	cfg := VoiceConfig{
+		Provider: "Piper",
+		PerLanguage: map[string]VoiceSetup{
+			"fr": {Provider: TTSProviderEspeak},
+			"es": {Voice: "nova"},
+		},
+	}
+
+	assert.Equal(t, TTSProviderPiper, cfg.ResolveProvider("en"))
+	assert.Equal(t, TTSProviderEspeak, cfg.ResolveProvider("fr"))
+	assert.Equal(t, TTSProviderPiper, cfg.ResolveProvider("es"))
+	assert.Equal(t, TTSProviderOpenAI, VoiceConfig{}.ResolveProvider("en"))
+}
+
+func TestVoiceConfig_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		cfg     VoiceConfig
+		wantErr string
+	}{
+		{
+			name: "default provider",
+			cfg:  DefaultConfig().Voice,
+		},
+		{
+			name:    "unknown provider",
+			cfg:     VoiceConfig{Provider: "polly"},
+			wantErr: "voice.provider",
+		},
+		{
+			name:    "command without binary",
+			cfg:     VoiceConfig{Provider: TTSProviderCommand},
+			wantErr: "voice.command.binary",
+		},
+		{
+			name: "command with binary",
+			cfg: VoiceConfig{
+				Provider: TTSProviderCommand,
+				Command:  LocalTTSConfig{Binary: "my-tts"},
+			},
+		},
+		{
+			name: "unknown per-language provider",
+			cfg: VoiceConfig{
+				PerLanguage: map[string]VoiceSetup{"fr": {Provider: "coqui"}},
+			},
+			wantErr: "voice.per_language.fr.provider",
+		},
+		{
+			name:    "negative chunk gap",
+			cfg:     VoiceConfig{Chunking: TTSChunkingConfig{Gap: -0.2}},
+			wantErr: "voice.chunking.gap",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.cfg.Validate()
+			if tt.wantErr == "" {
+				require.NoError(t, err)
+				return
+			}
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.wantErr)
+		})
+	}
+}
+
+func TestLoadConfig_VoiceProviders(t *testing.T) {
+	cfg := loadConfigFromString(t, `voice:
+  provider: piper
+  piper:
+    binary: /opt/piper/piper
+    voice: models/en_US-lessac-medium.onnx
+  per_language:
+    fr:
+      provider: espeak
+      voice: fr-fr
+`)
+
+	assert.Equal(t, TTSProviderPiper, cfg.Voice.Provider)
+	assert.Equal(t, "/opt/piper/piper", cfg.Voice.Piper.Binary)
+	assert.Equal(t, "models/en_US-lessac-medium.onnx", cfg.Voice.Piper.Voice)
+	assert.Equal(t, TTSProviderEspeak, cfg.Voice.PerLanguage["fr"].Provider)
+	assert.Equal(t, "fr-fr", cfg.Voice.PerLanguage["fr"].Voice)
+	require.NoError(t, cfg.Validate())
+}
+
+func TestLoadConfig_VoiceChunking(t *testing.T) {
+	cfg := loadConfigFromString(t, `voice:
+  chunking:
+    max_chars: 1200
+    gap: 0.25
+`)
+
+	assert.Equal(t, 1200, cfg.Voice.Chunking.ResolveMaxChars())
+	assert.Equal(t, 0.25, cfg.Voice.Chunking.Gap)
+	assert.True(t, cfg.Voice.Chunking.MatchLoudness)
+	assert.Equal(t, DefaultTTSMaxChars, TTSChunkingConfig{}.ResolveMaxChars())
+}
@@ -0,0 +1,19 @@
+package interfaces
+
+import "context"
+
+type apiAttemptHookKey struct{}
+
+// WithAPIAttemptHook returns a context whose API clients call hook before every request attempt,
+// retries included.
+func WithAPIAttemptHook(ctx context.Context, hook func()) context.Context {
+	return context.WithValue(ctx, apiAttemptHookKey{}, hook)
+}
+
+// NotifyAPIAttempt calls the attempt hook attached to ctx, if any. API clients call it before
+// sending each request.
+func NotifyAPIAttempt(ctx context.Context) {
+	if hook, ok := ctx.Value(apiAttemptHookKey{}).(func()); ok {
+		hook()
+	}
+}
@@ -80,6 +80,11 @@ type CommandExecutor interface {
 	Run(ctx context.Context, name string, args ...string) (CommandResult, error)
 }
 
+// StdinCommandExecutor optionally runs external commands with data written to their standard input.
+type StdinCommandExecutor interface {
+	RunWithStdin(ctx context.Context, stdin []byte, name string, args ...string) (CommandResult, error)
+}
+
 // SpeechOptions describes optional TTS settings for synthesized narration.
 type SpeechOptions struct {
 	Model string
@@ -92,6 +97,54 @@ type SpeechSynthesisClient interface {
 	GenerateSpeechWithOptions(ctx context.Context, text string, options SpeechOptions) (io.ReadCloser, error)
 }
 
+// TTSProvider synthesizes narration audio with a specific speech engine.
+type TTSProvider interface {
+	Name() string
+	Synthesize(ctx context.Context, text string, options SpeechOptions) (io.ReadCloser, error)
+}
+
+// SSMLProvider is optionally implemented by TTS providers whose engine can read SSML input.
+type SSMLProvider interface {
+	SupportsSSML() bool
+}
+
+// WordTimestamp is a spoken word and when it is said, in seconds from the start of the audio.
+type WordTimestamp struct {
+	Word  string  `json:"word"`
+	Start float64 `json:"start"`
+	End   float64 `json:"end"`
+}
+
+// TimestampedTTSProvider is optionally implemented by TTS providers that can report when each word
+// is spoken. Engines that do not report timings for a request return nil timestamps.
+type TimestampedTTSProvider interface {
+	SynthesizeWithTimestamps(ctx context.Context, text string, options SpeechOptions) (io.ReadCloser, []WordTimestamp, error)
+}
+
+// TranscriptionProvider turns recorded speech into text with a specific speech recognition engine.
+type TranscriptionProvider interface {
+	Name() string
+	Model() string
+	Transcribe(ctx context.Context, audioPath, lang string) (string, error)
+}
+
+// TranscriptionClient optionally supports transcribing audio through the OpenAI API.
+type TranscriptionClient interface {
+	TranscribeAudio(ctx context.Context, audio []byte, filename, model, lang string) (string, error)
+}
+
+// ChatCompletionClient optionally supports per-request chat models.
+type ChatCompletionClient interface {
+	ChatCompletionWithModel(ctx context.Context, model string, messages []openai.ChatCompletionMessageParamUnion) (string, error)
+}
+
+// TranslationProvider translates text with a specific translation backend.
+type TranslationProvider interface {
+	Name() string
+	Model() string
+	Translate(ctx context.Context, text, targetLang string) (string, error)
+}
+
 // OpenAIClient wraps OpenAI client operations
 type OpenAIClient interface {
 	ChatCompletion(ctx context.Context, messages []openai.ChatCompletionMessageParamUnion) (string, error)
@@ -0,0 +1,288 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"strings"
+	"unicode"
+
+	"gocreator/internal/config"
+	"gocreator/internal/interfaces"
+
+	"github.com/spf13/afero"
+)
+
+// defaultAlignerArgs are used when subtitles.alignment.args is empty.
+var defaultAlignerArgs = []string{"{audio}", "{text_file}", "{output}"}
+
+// AlignmentService finds when each word of a narration is spoken, so subtitle cues can follow the
+// speech instead of spanning a whole slide.
+type AlignmentService struct {
+	fs              afero.Fs
+	logger          interfaces.Logger
+	commandExecutor interfaces.CommandExecutor
+}
+
+// NewAlignmentService creates a new alignment service
+func NewAlignmentService(fs afero.Fs, logger interfaces.Logger) *AlignmentService {
+	return NewAlignmentServiceWithExecutor(fs, logger, nil)
+}
+
+// NewAlignmentServiceWithExecutor creates a new alignment service with an injected command executor.
+func NewAlignmentServiceWithExecutor(fs afero.Fs, logger interfaces.Logger, executor interfaces.CommandExecutor) *AlignmentService {
+	if executor == nil {
+		executor = newCommandExecutor()
+	}
+
+	return &AlignmentService{
+		fs:              fs,
+		logger:          logger,
+		commandExecutor: executor,
+	}
+}
+
+// Align returns the word timings of the narration in audioPath, or nil when the configured
+// alignment has no source for them. Word timestamps reported by the TTS engine are used first;
+// otherwise the aligner command runs and its result is cached under cacheDir by audio hash. In
+// auto mode an aligner failure is logged and yields nil.
+func (s *AlignmentService) Align(ctx context.Context, audioPath, text, lang string, cfg config.SubtitleAlignmentConfig, cacheDir string) ([]interfaces.WordTimestamp, error) {
+	provider := cfg.ResolveProvider()
+	if provider == config.SubtitleAlignmentNone || audioPath == "" || strings.TrimSpace(text) == "" {
+		return nil, nil
+	}
+
+	if provider != config.SubtitleAlignmentCommand {
+		words, err := s.readTTSTimestamps(audioPath)
+		if err != nil || words != nil {
+			return words, err
+		}
+	}
+	if provider == config.SubtitleAlignmentTTS || (provider == config.SubtitleAlignmentAuto && strings.TrimSpace(cfg.Binary) == "") {
+		return nil, nil
+	}
+
+	words, err := s.alignWithCommand(ctx, audioPath, text, lang, cfg, cacheDir)
+	if err != nil && provider == config.SubtitleAlignmentAuto {
+		s.logger.Warn("Subtitle alignment failed, showing slide text for the whole narration", "audio", audioPath, "error", err)
+		return nil, nil
+	}
+	return words, err
+}
+
+// readTTSTimestamps reads the word timestamps the TTS engine reported for generated narration.
+func (s *AlignmentService) readTTSTimestamps(audioPath string) ([]interfaces.WordTimestamp, error) {
+	path := wordTimestampsPath(audioPath)
+	exists, err := afero.Exists(s.fs, path)
+	if err != nil || !exists {
+		return nil, err
+	}
+
+	data, err := afero.ReadFile(s.fs, path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read word timestamps: %w", err)
+	}
+	return parseWordTimestamps(data)
+}
+
+func (s *AlignmentService) alignWithCommand(ctx context.Context, audioPath, text, lang string, cfg config.SubtitleAlignmentConfig, cacheDir string) ([]interfaces.WordTimestamp, error) {
+	audio, err := afero.ReadFile(s.fs, audioPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read narration for alignment: %w", err)
+	}
+
+	payload := fmt.Sprintf("%x|%s|%s|%s|%s", sha256.Sum256(audio), cfg.Binary, strings.Join(cfg.Args, "\x00"), lang, text)
+	cachePath := ""
+	if cacheDir != "" {
+		cachePath = filepath.Join(cacheDir, "alignment", fmt.Sprintf("%x.json", sha256.Sum256([]byte(payload))))
+		if data, err := afero.ReadFile(s.fs, cachePath); err == nil {
+			if words, err := parseWordTimestamps(data); err == nil {
+				recordCacheLookup(ctx, CacheStageAlignment, true)
+				return words, nil
+			}
+		}
+		recordCacheLookup(ctx, CacheStageAlignment, false)
+	}
+
+	words, err := s.runAligner(ctx, audioPath, text, lang, cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	if cachePath != "" {
+		if err := s.fs.MkdirAll(filepath.Dir(cachePath), 0755); err != nil {
+			return nil, fmt.Errorf("failed to create alignment cache directory: %w", err)
+		}
+		if err := writeWordTimestamps(s.fs, cachePath, words); err != nil {
+			return nil, err
+		}
+	}
+	return words, nil
+}
+
+// runAligner runs the aligner command. It reads the result from {output} when the arguments use
+// it, or from stdout otherwise.
+func (s *AlignmentService) runAligner(ctx context.Context, audioPath, text, lang string, cfg config.SubtitleAlignmentConfig) ([]interfaces.WordTimestamp, error) {
+	workDir, err := afero.TempDir(s.fs, "", "gocreator-align-")
+	if err != nil {
+		return nil, fmt.Errorf("failed to create alignment work directory: %w", err)
+	}
+	defer func() { _ = s.fs.RemoveAll(workDir) }()
+
+	textPath := filepath.Join(workDir, "narration.txt")
+	outputPath := filepath.Join(workDir, "alignment.json")
+	if err := afero.WriteFile(s.fs, textPath, []byte(text), 0644); err != nil {
+		return nil, fmt.Errorf("failed to write alignment text: %w", err)
+	}
+
+	templates := cfg.Args
+	if len(templates) == 0 {
+		templates = defaultAlignerArgs
+	}
+	replacer := strings.NewReplacer(
+		"{audio}", audioPath,
+		"{text}", text,
+		"{text_file}", textPath,
+		"{output}", outputPath,
+		"{lang}", lang,
+	)
+	usesOutput := false
+	args := make([]string, len(templates))
+	for i, arg := range templates {
+		usesOutput = usesOutput || strings.Contains(arg, "{output}")
+		args[i] = replacer.Replace(arg)
+	}
+
+	s.logger.Debug("Aligning narration", "command", formatCommand(cfg.Binary, args...))
+
+	result, err := s.commandExecutor.Run(ctx, cfg.Binary, args...)
+	if err != nil {
+		return nil, fmt.Errorf("aligner error: %w, stderr: %s", err, string(result.Stderr))
+	}
+
+	data := result.Stdout
+	if usesOutput {
+		if data, err = afero.ReadFile(s.fs, outputPath); err != nil {
+			return nil, fmt.Errorf("aligner did not write %s: %w", outputPath, err)
+		}
+	}
+	return parseWordTimestamps(data)
+}
+
+// wordTimestampsPath returns where the word timestamps of a narration file are kept.
+func wordTimestampsPath(audioPath string) string {
+	return audioPath + ".words.json"
+}
+
+func writeWordTimestamps(fs afero.Fs, path string, words []interfaces.WordTimestamp) error {
+	data, err := json.Marshal(words)
+	if err != nil {
+		return fmt.Errorf("failed to encode word timestamps: %w", err)
+	}
+	if err := afero.WriteFile(fs, path, data, 0644); err != nil {
+		return fmt.Errorf("failed to write word timestamps: %w", err)
+	}
+	return nil
+}
+
+// parseWordTimestamps reads word timings from a JSON array of {word, start, end} objects, an object
+// with such a words array, or whisper-style output with words nested in segments.
+func parseWordTimestamps(data []byte) ([]interfaces.WordTimestamp, error) {
+	var words []interfaces.WordTimestamp
+	if trimmed := bytes.TrimSpace(data); len(trimmed) > 0 && trimmed[0] == '[' {
+		if err := json.Unmarshal(trimmed, &words); err != nil {
+			return nil, fmt.Errorf("failed to parse word timestamps: %w", err)
+		}
+	} else {
+		var document struct {
+			Words    []interfaces.WordTimestamp `json:"words"`
+			Segments []struct {
+				Words []interfaces.WordTimestamp `json:"words"`
+			} `json:"segments"`
+		}
+		if err := json.Unmarshal(trimmed, &document); err != nil {
+			return nil, fmt.Errorf("failed to parse word timestamps: %w", err)
+		}
+		words = document.Words
+		for _, segment := range document.Segments {
+			words = append(words, segment.Words...)
+		}
+	}
+
+	parsed := make([]interfaces.WordTimestamp, 0, len(words))
+	for _, word := range words {
+		word.Word = strings.TrimSpace(word.Word)
+		if word.Word != "" && word.End >= word.Start {
+			parsed = append(parsed, word)
+		}
+	}
+	if len(parsed) == 0 {
+		return nil, fmt.Errorf("no word timestamps found")
+	}
+	return parsed, nil
+}
+
+// alignDisplayWords times the words of the displayed text from the aligned words. Words are matched
+// in order after normalizing case and punctuation; displayed words without a match, such as a term
+// spoken through a substitution, are spread over the gap between their matched neighbours.
+func alignDisplayWords(display string, aligned []interfaces.WordTimestamp) []interfaces.WordTimestamp {
+	fields := strings.Fields(display)
+	if len(fields) == 0 || len(aligned) == 0 {
+		return nil
+	}
+
+	const lookahead = 4
+	words := make([]interfaces.WordTimestamp, len(fields))
+	matched := make([]bool, len(fields))
+	next := 0
+	for i, field := range fields {
+		words[i].Word = field
+		key := normalizeAlignmentWord(field)
+		for k := next; key != "" && k < min(len(aligned), next+lookahead); k++ {
+			if normalizeAlignmentWord(aligned[k].Word) == key {
+				words[i].Start, words[i].End = aligned[k].Start, aligned[k].End
+				matched[i] = true
+				next = k + 1
+				break
+			}
+		}
+	}
+
+	for i := 0; i < len(words); {
+		if matched[i] {
+			i++
+			continue
+		}
+		j := i
+		for j < len(words) && !matched[j] {
+			j++
+		}
+		from := aligned[0].Start
+		if i > 0 {
+			from = words[i-1].End
+		}
+		to := aligned[len(aligned)-1].End
+		if j < len(words) {
+			to = words[j].Start
+		}
+		step := max(0, to-from) / float64(j-i)
+		for k := i; k < j; k++ {
+			words[k].Start = from + float64(k-i)*step
+			words[k].End = words[k].Start + step
+		}
+		i = j
+	}
+	return words
+}
+
+func normalizeAlignmentWord(word string) string {
+	return strings.Map(func(r rune) rune {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			return unicode.ToLower(r)
+		}
+		return -1
+	}, word)
+}
@@ -0,0 +1,157 @@
+package services
+
+import (
+	"context"
+	"testing"
+
+	"gocreator/internal/config"
+	"gocreator/internal/interfaces"
+
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseWordTimestamps(t *testing.T) {
+	want := []interfaces.WordTimestamp{{Word: "Hello", Start: 0, End: 0.4}, {Word: "world", Start: 0.5, End: 0.9}}
+
+	words, err := parseWordTimestamps([]byte(`[{"word":"Hello","start":0,"end":0.4},{"word":"world","start":0.5,"end":0.9}]`))
+	require.NoError(t, err)
+	assert.Equal(t, want, words)
+
+	words, err = parseWordTimestamps([]byte(`{"words":[{"word":"Hello","start":0,"end":0.4},{"word":"world","start":0.5,"end":0.9}]}`))
+	require.NoError(t, err)
+	assert.Equal(t, want, words)
+
+	words, err = parseWordTimestamps([]byte(`{"text":" Hello world","segments":[{"words":[{"word":" Hello","start":0,"end":0.4}]},{"words":[{"word":" world","start":0.5,"end":0.9}]}]}`))
+	require.NoError(t, err)
+	assert.Equal(t, want, words)
+
+	_, err = parseWordTimestamps([]byte(`{"segments":[]}`))
+	require.Error(t, err)
+}
+
+func TestAlignDisplayWords(t *testing.T) {
+	aligned := []interfaces.WordTimestamp{
+		{Word: "Open", Start: 0.0, End: 0.3},
+		{Word: "the", Start: 0.3, End: 0.4},
+		{Word: "jiff", Start: 0.4, End: 0.8},
+		{Word: "file.", Start: 0.9, End: 1.2},
+	}
+
+	words := alignDisplayWords("Open the GIF file!", aligned)
+	require.Len(t, words, 4)
+	assert.Equal(t, interfaces.WordTimestamp{Word: "Open", Start: 0.0, End: 0.3}, words[0])
+	assert.Equal(t, "GIF", words[2].Word)
+	assert.InDelta(t, 0.4, words[2].Start, 1e-9)
+	assert.InDelta(t, 0.9, words[2].End, 1e-9)
+	assert.Equal(t, interfaces.WordTimestamp{Word: "file!", Start: 0.9, End: 1.2}, words[3])
+
+	assert.Nil(t, alignDisplayWords("Anything", nil))
+}
+
+func TestAlignmentService_Align_UsesTTSTimestamps(t *testing.T) {
+	fs := afero.NewMemMapFs()
+	audioPath := testPath("cache", "en", "audio", "0.mp3")
+	require.NoError(t, writeTestFile(fs, audioPath, "audio"))
+	require.NoError(t, writeTestFile(fs, wordTimestampsPath(audioPath), `[{"word":"Hi","start":0.1,"end":0.3}]`))
+	service := NewAlignmentServiceWithExecutor(fs, &mockLogger{}, newFakeCommandExecutor())
+
+	words, err := service.Align(context.Background(), audioPath, "Hi", "en", config.SubtitleAlignmentConfig{}, testPath("cache", "en"))
+	require.NoError(t, err)
+	assert.Equal(t, []interfaces.WordTimestamp{{Word: "Hi", Start: 0.1, End: 0.3}}, words)
+
+	// Without timestamps or an aligner there is nothing to align with
+	words, err = service.Align(context.Background(), audioPath, "Hi", "en", config.SubtitleAlignmentConfig{Provider: config.SubtitleAlignmentNone}, "")
+	require.NoError(t, err)
+	assert.Nil(t, words)
+}
+
+func TestAlignmentService_Align_CachesCommandResultByAudioHash(t *testing.T) {
+	fs := afero.NewMemMapFs()
+	audioPath := testPath("data", "slides", "01-intro.mp3")
+	cacheDir := testPath("cache", "en")
+	require.NoError(t, writeTestFile(fs, audioPath, "recorded audio"))
+	cfg := config.SubtitleAlignmentConfig{
+		Provider: config.SubtitleAlignmentCommand,
+		Binary:   "whisper-align",
+		Args:     []string{"--audio", "{audio}", "--language", "{lang}", "--text", "{text_file}"},
+	}
+	alignerOutput := newCommandResult(`{"segments":[{"words":[{"word":" Welcome","start":0.2,"end":0.7}]}]}`, "")
+	executor := newFakeCommandExecutor(expectedCommand{
+		Name:     "whisper-align",
+		Contains: []string{"--audio " + audioPath, "--language fr"},
+		Result:   alignerOutput,
+	})
+	service := NewAlignmentServiceWithExecutor(fs, &mockLogger{}, executor)
+
+	words, err := service.Align(context.Background(), audioPath, "Welcome", "fr", cfg, cacheDir)
+	require.NoError(t, err)
+	executor.AssertDone(t)
+	assert.Equal(t, []interfaces.WordTimestamp{{Word: "Welcome", Start: 0.2, End: 0.7}}, words)
+
+	// The same audio is served from the cache
+	service = NewAlignmentServiceWithExecutor(fs, &mockLogger{}, newFakeCommandExecutor())
+	cached, err := service.Align(context.Background(), audioPath, "Welcome", "fr", cfg, cacheDir)
+	require.NoError(t, err)
+	assert.Equal(t, words, cached)
+
+	// New audio is aligned again
+	require.NoError(t, writeTestFile(fs, audioPath, "re-recorded audio"))
+	executor = newFakeCommandExecutor(expectedCommand{Name: "whisper-align", Result: alignerOutput})
+	service = NewAlignmentServiceWithExecutor(fs, &mockLogger{}, executor)
+	_, err = service.Align(context.Background(), audioPath, "Welcome", "fr", cfg, cacheDir)
+	require.NoError(t, err)
+	executor.AssertDone(t)
+}
+
+func TestSubtitleService_CreateSegmentsFromWords(t *testing.T) {
+	service := NewSubtitleService(afero.NewMemMapFs(), &mockLogger{})
+	words := []interfaces.WordTimestamp{
+		{Word: "Hi.", Start: 0.0, End: 0.3},
+		{Word: "This", Start: 0.5, End: 0.7},
+		{Word: "sentence", Start: 0.7, End: 1.1},
+		{Word: "is", Start: 1.1, End: 1.2},
+		{Word: "long", Start: 1.2, End: 1.5},
+		{Word: "enough.", Start: 1.5, End: 2.0},
+	}
+
+	segments := service.CreateSegmentsFromWords(words, config.SubtitleTimingConfig{
+		MaxCharsPerLine: 10,
+		MaxLines:        2,
+		MinDuration:     1.0,
+	}, 3.0)
+
+	require.Len(t, segments, 3)
+	assert.Equal(t, words[1:4], segments[1].Words)
+	for i := range segments {
+		segments[i].Words = nil
+	}
+	// Short cues are held for the minimum duration, but never past the next cue
+	assert.Equal(t, SubtitleSegment{Index: 1, StartTime: 0.0, EndTime: 0.5, Text: "Hi."}, segments[0])
+	assert.Equal(t, SubtitleSegment{Index: 2, StartTime: 0.5, EndTime: 1.2, Text: "This sentence is"}, segments[1])
+	assert.Equal(t, SubtitleSegment{Index: 3, StartTime: 1.2, EndTime: 2.2, Text: "long enough."}, segments[2])
+}
+
+func TestPostProcessService_generateSubtitles_FollowsWordTimestamps(t *testing.T) {
+	fs := afero.NewMemMapFs()
+	audioPath := testPath("cache", "en", "audio", "0.mp3")
+	require.NoError(t, writeTestFile(fs, audioPath, "audio"))
+	require.NoError(t, writeTestFile(fs, wordTimestampsPath(audioPath),
+		`[{"word":"First","start":0,"end":0.5},{"word":"sentence.","start":0.5,"end":1.0},{"word":"Second","start":2.0,"end":2.5},{"word":"one.","start":2.5,"end":3.0}]`))
+	service := NewPostProcessServiceWithExecutor(fs, &mockLogger{}, newFakeCommandExecutor())
+
+	paths, _, err := service.generateSubtitles(context.Background(), PostProcessRequest{
+		OutputDir:  testPath("out"),
+		BaseName:   "output-en",
+		Lang:       "en",
+		Texts:      []string{"First sentence. Second one."},
+		AudioPaths: []string{audioPath},
+		Subtitles:  config.SubtitlesConfig{Enabled: true},
+	}, []slideTiming{{speed: 2, narration: 1.5}}, []float64{4}, 0)
+	require.NoError(t, err)
+
+	srt, err := afero.ReadFile(fs, paths[0])
+	require.NoError(t, err)
+	assert.Equal(t, "1\n00:00:04,000 --> 00:00:04,500\nFirst sentence.\n\n2\n00:00:05,000 --> 00:00:05,500\nSecond one.\n\n", string(srt))
+}
@@ -6,8 +6,9 @@ import (
 	"fmt"
 	"io"
 	"path/filepath"
-	"sync"
+	"strings"
 
+	"gocreator/internal/config"
 	"gocreator/internal/interfaces"
 
 	"github.com/spf13/afero"
@@ -15,23 +16,58 @@ import (
 
 // AudioService handles audio generation
 type AudioService struct {
-	fs          afero.Fs
-	client      interfaces.OpenAIClient
-	textService *TextService
-	logger      interfaces.Logger
-	speech      interfaces.SpeechOptions
+	fs              afero.Fs
+	providers       *TTSProviderRegistry
+	provider        interfaces.TTSProvider
+	textService     *TextService
+	logger          interfaces.Logger
+	commandExecutor interfaces.CommandExecutor
+	speech          interfaces.SpeechOptions
+	pronunciations  PronunciationDictionary
+	chunking        config.TTSChunkingConfig
 }
 
 // NewAudioService creates a new audio service
 func NewAudioService(fs afero.Fs, client interfaces.OpenAIClient, textService *TextService, logger interfaces.Logger) *AudioService {
+	return NewAudioServiceWithProviders(fs, NewTTSProviderRegistry(fs, client, config.VoiceConfig{}), textService, logger)
+}
+
+// NewAudioServiceWithProviders creates a new audio service backed by a TTS provider registry.
+// The OpenAI provider is selected by default when it is registered.
+func NewAudioServiceWithProviders(fs afero.Fs, providers *TTSProviderRegistry, textService *TextService, logger interfaces.Logger) *AudioService {
+	return NewAudioServiceWithExecutor(fs, providers, textService, logger, nil)
+}
+
+// NewAudioServiceWithExecutor creates a new audio service with an injected command executor, which
+// joins speech chunks around pauses.
+func NewAudioServiceWithExecutor(fs afero.Fs, providers *TTSProviderRegistry, textService *TextService, logger interfaces.Logger, executor interfaces.CommandExecutor) *AudioService {
+	if executor == nil {
+		executor = newCommandExecutor()
+	}
+	provider, _ := providers.Get(config.TTSProviderOpenAI)
+
 	return &AudioService{
-		fs:          fs,
-		client:      client,
-		textService: textService,
-		logger:      logger,
+		fs:              fs,
+		providers:       providers,
+		provider:        provider,
+		textService:     textService,
+		logger:          logger,
+		commandExecutor: executor,
 	}
 }
 
+// WithProvider returns a shallow copy that synthesizes speech with the named provider.
+func (s *AudioService) WithProvider(name string) (*AudioService, error) {
+	provider, err := s.providers.Get(name)
+	if err != nil {
+		return nil, err
+	}
+
+	clone := *s
+	clone.provider = provider
+	return &clone, nil
+}
+
 // WithSpeechOptions returns a shallow copy configured for a specific speech profile.
 func (s *AudioService) WithSpeechOptions(options interfaces.SpeechOptions) *AudioService {
 	clone := *s
@@ -39,44 +75,52 @@ func (s *AudioService) WithSpeechOptions(options interfaces.SpeechOptions) *Audi
 	return &clone
 }
 
+// WithPronunciations returns a shallow copy that applies a pronunciation dictionary to narration.
+func (s *AudioService) WithPronunciations(dictionary PronunciationDictionary) *AudioService {
+	clone := *s
+	clone.pronunciations = dictionary
+	return &clone
+}
+
+// WithChunking returns a shallow copy that splits and joins long narration as configured.
+func (s *AudioService) WithChunking(chunking config.TTSChunkingConfig) *AudioService {
+	clone := *s
+	clone.chunking = chunking
+	return &clone
+}
+
 // Generate generates audio from text
 func (s *AudioService) Generate(ctx context.Context, text, outputPath string) error {
+	text = s.pronunciations.apply(text)
+
 	// Check cache
 	cached, err := s.checkCache(ctx, text, outputPath)
 	if err != nil {
 		return fmt.Errorf("failed to check cache: %w", err)
 	}
+	recordCacheLookup(ctx, CacheStageAudio, cached)
 	if cached {
 		s.logger.Info("Using cached audio", "path", outputPath)
 		return nil
 	}
 
-	// Generate audio
-	body, err := s.generateSpeech(ctx, text)
-	if err != nil {
-		return fmt.Errorf("failed to generate speech: %w", err)
-	}
-	defer func() { _ = body.Close() }()
-
 	// Ensure directory exists
 	dir := filepath.Dir(outputPath)
 	if err := s.fs.MkdirAll(dir, 0755); err != nil {
 		return fmt.Errorf("failed to create directory: %w", err)
 	}
 
-	// Write to file
-	file, err := s.fs.Create(outputPath)
-	if err != nil {
-		return fmt.Errorf("failed to create audio file: %w", err)
-	}
-	defer func() { _ = file.Close() }()
-
-	if _, err := io.Copy(file, body); err != nil {
-		return fmt.Errorf("failed to write audio: %w", err)
+	// Generate audio
+	discardPartialOutput(s.fs, outputPath)
+	_ = s.fs.Remove(wordTimestampsPath(outputPath))
+	if err := s.synthesize(ctx, text, outputPath); err != nil {
+		discardPartialOutput(s.fs, outputPath)
+		_ = s.fs.Remove(wordTimestampsPath(outputPath))
+		return err
 	}
 
 	// Save hash for cache validation
-	hash := s.computeSpeechHash(text)
+	hash := s.narrationHash(text)
 	hashPath := outputPath + ".hash"
 	if err := afero.WriteFile(s.fs, hashPath, []byte(hash), 0644); err != nil {
 		return fmt.Errorf("failed to write hash file: %w", err)
@@ -85,6 +129,222 @@ func (s *AudioService) Generate(ctx context.Context, text, outputPath string) er
 	return nil
 }
 
+// synthesize renders narration into outputPath. Narration that fits one speech request is written
+// directly; otherwise its chunks are synthesized in parallel and joined.
+func (s *AudioService) synthesize(ctx context.Context, text, outputPath string) error {
+	plan := s.planNarration(text)
+	if len(plan) == 1 && plan[0].text != "" {
+		return s.synthesizeTo(ctx, plan[0].text, outputPath)
+	}
+	return s.synthesizeChunks(ctx, plan, outputPath)
+}
+
+// planNarration splits text into speech requests and pauses. Markup goes to the engine as SSML
+// when it reads SSML; otherwise pauses become generated silence. Speech longer than the chunking
+// limit is split at sentence boundaries.
+func (s *AudioService) planNarration(text string) []narrationChunk {
+	maxChars := s.chunking.ResolveMaxChars()
+	if !hasNarrationMarkup(text) {
+		return speechChunks(splitNarrationText(text, maxChars))
+	}
+	if provider, ok := s.provider.(interfaces.SSMLProvider); ok && provider.SupportsSSML() {
+		parts := splitNarrationText(text, maxChars)
+		for i, part := range parts {
+			parts[i] = narrationSSML(part)
+		}
+		return speechChunks(parts)
+	}
+
+	var plan []narrationChunk
+	for _, chunk := range narrationChunks(text) {
+		if chunk.text == "" {
+			plan = append(plan, chunk)
+			continue
+		}
+		plan = append(plan, speechChunks(splitNarrationText(chunk.text, maxChars))...)
+	}
+	return plan
+}
+
+func speechChunks(parts []string) []narrationChunk {
+	chunks := make([]narrationChunk, len(parts))
+	for i, part := range parts {
+		chunks[i] = narrationChunk{text: part}
+	}
+	return chunks
+}
+
+// synthesizeTo writes the speech for text to outputPath, with the word timestamps the engine
+// reported next to it.
+func (s *AudioService) synthesizeTo(ctx context.Context, text, outputPath string) error {
+	body, words, err := s.generateSpeech(ctx, text)
+	if err != nil {
+		return fmt.Errorf("failed to generate speech: %w", err)
+	}
+	defer func() { _ = body.Close() }()
+
+	file, err := s.fs.Create(outputPath)
+	if err != nil {
+		return fmt.Errorf("failed to create audio file: %w", err)
+	}
+	_, err = io.Copy(file, body)
+	if closeErr := file.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return fmt.Errorf("failed to write audio: %w", err)
+	}
+
+	if words != nil {
+		return writeWordTimestamps(s.fs, wordTimestampsPath(outputPath), words)
+	}
+	return nil
+}
+
+// synthesizeChunks synthesizes the speech chunks of a plan in parallel and joins them with the
+// plan's pauses and the configured gap. Each chunk is cached by its own speech hash in a chunks
+// directory next to the output, so editing one sentence only re-synthesizes that chunk. A chunk
+// only counts as cached once its hash file is written after synthesis completes.
+func (s *AudioService) synthesizeChunks(ctx context.Context, plan []narrationChunk, outputPath string) error {
+	chunkDir := filepath.Join(filepath.Dir(outputPath), "chunks")
+	if err := s.fs.MkdirAll(chunkDir, 0755); err != nil {
+		return fmt.Errorf("failed to create chunk directory: %w", err)
+	}
+
+	chunkPaths := make([]string, len(plan))
+	errs := runBounded(ctx, len(plan), DefaultScheduler().APILimit(), func(idx int) error {
+		if plan[idx].text == "" {
+			return nil
+		}
+		hash := s.computeSpeechHash(plan[idx].text)
+		chunkPath := filepath.Join(chunkDir, hash+".mp3")
+		chunkPaths[idx] = chunkPath
+		if outputComplete(s.fs, chunkPath, hash) {
+			return nil
+		}
+		discardPartialOutput(s.fs, chunkPath)
+		if err := s.synthesizeTo(ctx, plan[idx].text, chunkPath); err != nil {
+			discardPartialOutput(s.fs, chunkPath)
+			_ = s.fs.Remove(wordTimestampsPath(chunkPath))
+			return err
+		}
+		return markOutputComplete(s.fs, chunkPath, hash)
+	})
+	for i, err := range errs {
+		if err != nil {
+			return fmt.Errorf("narration chunk %d: %w", i, err)
+		}
+	}
+
+	args := []string{"-y"}
+	var labels []string
+	var inputs []joinedInput
+	var filter strings.Builder
+	addSilence := func(seconds float64) {
+		input := len(labels)
+		inputs = append(inputs, joinedInput{silence: seconds})
+		args = append(args, "-f", "lavfi", "-t", fmt.Sprintf("%.3f", seconds), "-i", "anullsrc=r=44100:cl=stereo")
+		fmt.Fprintf(&filter, "[%d:a]aresample=44100,aformat=channel_layouts=stereo[a%d];", input, input)
+		labels = append(labels, fmt.Sprintf("[a%d]", input))
+	}
+	for i, chunk := range plan {
+		if chunk.text == "" {
+			addSilence(chunk.pause)
+			continue
+		}
+		if i > 0 && plan[i-1].text != "" && s.chunking.Gap > 0 {
+			addSilence(s.chunking.Gap)
+		}
+
+		input := len(labels)
+		inputs = append(inputs, joinedInput{path: chunkPaths[i]})
+		args = append(args, "-i", chunkPaths[i])
+		fmt.Fprintf(&filter, "[%d:a]", input)
+		if s.chunking.MatchLoudness {
+			filter.WriteString("loudnorm=I=-16:TP=-1.5:LRA=11,")
+		}
+		fmt.Fprintf(&filter, "aresample=44100,aformat=channel_layouts=stereo[a%d];", input)
+		labels = append(labels, fmt.Sprintf("[a%d]", input))
+	}
+	fmt.Fprintf(&filter, "%sconcat=n=%d:v=0:a=1[out]", strings.Join(labels, ""), len(labels))
+	args = append(args, "-filter_complex", filter.String(), "-map", "[out]", "-c:a", "mp3", "-b:a", "192k", outputPath)
+
+	s.logger.Debug("Joining narration chunks", "chunks", len(labels), "command", formatCommand("ffmpeg", args...))
+
+	result, err := s.commandExecutor.Run(ctx, "ffmpeg", args...)
+	if err != nil {
+		return fmt.Errorf("ffmpeg error joining narration: %w, stderr: %s", err, string(result.Stderr))
+	}
+	return s.joinWordTimestamps(ctx, inputs, outputPath)
+}
+
+// joinedInput is one input of joined narration: a speech chunk file or generated silence.
+type joinedInput struct {
+	path    string
+	silence float64
+}
+
+// joinWordTimestamps offsets the word timestamps of joined chunks into one list for the output.
+// It writes nothing unless the engine reported timestamps for every chunk.
+func (s *AudioService) joinWordTimestamps(ctx context.Context, inputs []joinedInput, outputPath string) error {
+	var chunkWords [][]interfaces.WordTimestamp
+	for _, input := range inputs {
+		if input.path == "" {
+			continue
+		}
+		data, err := afero.ReadFile(s.fs, wordTimestampsPath(input.path))
+		if err != nil {
+			return nil
+		}
+		words, err := parseWordTimestamps(data)
+		if err != nil {
+			return nil
+		}
+		chunkWords = append(chunkWords, words)
+	}
+	if len(chunkWords) == 0 {
+		return nil
+	}
+
+	var joined []interfaces.WordTimestamp
+	offset := 0.0
+	for _, input := range inputs {
+		if input.path == "" {
+			offset += input.silence
+			continue
+		}
+		for _, word := range chunkWords[0] {
+			joined = append(joined, interfaces.WordTimestamp{Word: word.Word, Start: offset + word.Start, End: offset + word.End})
+		}
+		chunkWords = chunkWords[1:]
+
+		duration, err := s.getDuration(ctx, input.path)
+		if err != nil {
+			return err
+		}
+		offset += duration
+	}
+	return writeWordTimestamps(s.fs, wordTimestampsPath(outputPath), joined)
+}
+
+func (s *AudioService) getDuration(ctx context.Context, mediaPath string) (float64, error) {
+	result, err := s.commandExecutor.Run(ctx, "ffprobe",
+		"-v", "error",
+		"-show_entries", "format=duration",
+		"-of", "default=noprint_wrappers=1:nokey=1",
+		mediaPath,
+	)
+	if err != nil {
+		return 0, fmt.Errorf("ffprobe duration check failed: %w", err)
+	}
+
+	var duration float64
+	if _, err := fmt.Sscanf(strings.TrimSpace(string(result.Stdout)), "%f", &duration); err != nil {
+		return 0, fmt.Errorf("failed to parse duration: %w", err)
+	}
+	return duration, nil
+}
+
 // GenerateBatch generates audio for multiple texts in parallel
 func (s *AudioService) GenerateBatch(ctx context.Context, texts []string, outputDir string) ([]string, error) {
 	if err := s.fs.MkdirAll(outputDir, 0755); err != nil {
@@ -94,7 +354,7 @@ func (s *AudioService) GenerateBatch(ctx context.Context, texts []string, output
 	// Compute hashes and load cached hashes
 	hashes := make([]string, len(texts))
 	for i, text := range texts {
-		hashes[i] = s.computeSpeechHash(text)
+		hashes[i] = s.narrationHash(s.pronunciations.apply(text))
 	}
 
 	hashFile := filepath.Join(outputDir, "hashes")
@@ -103,40 +363,24 @@ func (s *AudioService) GenerateBatch(ctx context.Context, texts []string, output
 		return nil, fmt.Errorf("failed to load cached hashes: %w", err)
 	}
 
-	// Save current hashes
-	if err := s.textService.SaveHashes(ctx, hashFile, hashes); err != nil {
-		return nil, fmt.Errorf("failed to save hashes: %w", err)
-	}
-
 	// Generate audio files
 	audioPaths := make([]string, len(texts))
-	errors := make([]error, len(texts))
-	var wg sync.WaitGroup
-
-	for i, text := range texts {
-		wg.Add(1)
-		go func(idx int, txt, hash string) {
-			defer wg.Done()
-
-			audioPath := filepath.Join(outputDir, fmt.Sprintf("%d.mp3", idx))
-			audioPaths[idx] = audioPath
-
-			// Check if cached
-			if idx < len(cachedHashes) && cachedHashes[idx] == hash {
-				exists, err := afero.Exists(s.fs, audioPath)
-				if err == nil && exists {
-					return
-				}
-			}
-
-			// Generate new audio
-			if err := s.Generate(ctx, txt, audioPath); err != nil {
-				errors[idx] = err
+	errors := runBounded(ctx, len(texts), DefaultScheduler().APILimit(), func(idx int) error {
+		audioPath := filepath.Join(outputDir, fmt.Sprintf("%d.mp3", idx))
+		audioPaths[idx] = audioPath
+
+		// Check if cached
+		if idx < len(cachedHashes) && cachedHashes[idx] == hashes[idx] {
+			exists, err := afero.Exists(s.fs, audioPath)
+			if err == nil && exists {
+				recordCacheLookup(ctx, CacheStageAudio, true)
+				return nil
 			}
-		}(i, text, hashes[i])
-	}
+		}
 
-	wg.Wait()
+		// Generate new audio
+		return s.Generate(ctx, texts[idx], audioPath)
+	})
 
 	// Check for errors
 	for i, err := range errors {
@@ -145,6 +389,11 @@ func (s *AudioService) GenerateBatch(ctx context.Context, texts []string, output
 		}
 	}
 
+	// Save current hashes only once every file is written, so an interrupted batch is regenerated
+	if err := s.textService.SaveHashes(ctx, hashFile, hashes); err != nil {
+		return nil, fmt.Errorf("failed to save hashes: %w", err)
+	}
+
 	return audioPaths, nil
 }
 
@@ -174,24 +423,51 @@ func (s *AudioService) checkCache(ctx context.Context, text, outputPath string)
 	}
 
 	// Compute current hash
-	currentHash := s.computeSpeechHash(text)
+	currentHash := s.narrationHash(text)
 	return string(data) == currentHash, nil
 }
 
-func (s *AudioService) generateSpeech(ctx context.Context, text string) (io.ReadCloser, error) {
-	if !isZeroSpeechOptions(s.speech) {
-		if client, ok := s.client.(interfaces.SpeechSynthesisClient); ok {
-			return client.GenerateSpeechWithOptions(ctx, text, s.speech)
-		}
+func (s *AudioService) generateSpeech(ctx context.Context, text string) (io.ReadCloser, []interfaces.WordTimestamp, error) {
+	if s.provider == nil {
+		return nil, nil, fmt.Errorf("no TTS provider configured")
+	}
+	if provider, ok := s.provider.(interfaces.TimestampedTTSProvider); ok {
+		return provider.SynthesizeWithTimestamps(ctx, text, s.speech)
 	}
-	return s.client.GenerateSpeech(ctx, text)
+	body, err := s.provider.Synthesize(ctx, text, s.speech)
+	return body, nil, err
+}
+
+func (s *AudioService) providerName() string {
+	if s.provider == nil {
+		return config.TTSProviderOpenAI
+	}
+	return s.provider.Name()
 }
 
 func isZeroSpeechOptions(options interfaces.SpeechOptions) bool {
 	return options.Model == "" && options.Voice == "" && options.Speed == 0
 }
 
+// narrationHash keys a narration file. Narration joined from several chunks also depends on how
+// the chunks are joined; narration from a single request keeps the plain speech hash.
+func (s *AudioService) narrationHash(text string) string {
+	plan := s.planNarration(text)
+	if len(plan) == 1 && plan[0].text != "" {
+		return s.computeSpeechHash(text)
+	}
+	return s.computeSpeechHash(fmt.Sprintf("%s|joined:%d:%.3f:%t", text, s.chunking.ResolveMaxChars(), s.chunking.Gap, s.chunking.MatchLoudness))
+}
+
+// computeSpeechHash keys cached narration by engine, voice settings, and text.
+// OpenAI keeps its historical payload so existing caches stay valid; other
+// providers are namespaced so switching engines re-synthesizes the audio.
 func (s *AudioService) computeSpeechHash(text string) string {
+	if provider := s.providerName(); provider != config.TTSProviderOpenAI {
+		payload := fmt.Sprintf("%s|%s|%s|%.3f|%s", provider, s.speech.Model, s.speech.Voice, s.speech.Speed, text)
+		return fmt.Sprintf("%x", sha256.Sum256([]byte(payload)))
+	}
+
 	if isZeroSpeechOptions(s.speech) {
 		return fmt.Sprintf("%x", sha256.Sum256([]byte(text)))
 	}
@@ -43,7 +43,7 @@ func (s *AudioMixer) MixBackgroundMusic(ctx context.Context, videoPath, musicPat
 	}
 
 	// Get video duration
-	duration, err := s.getVideoDuration(videoPath)
+	duration, err := s.getVideoDuration(ctx, videoPath)
 	if err != nil {
 		return fmt.Errorf("failed to get video duration: %w", err)
 	}
@@ -160,7 +160,7 @@ func (s *AudioMixer) ApplyDucking(ctx context.Context, videoPath, musicPath, out
 		return nil
 	}
 
-	duration, err := s.getVideoDuration(videoPath)
+	duration, err := s.getVideoDuration(ctx, videoPath)
 	if err != nil {
 		return fmt.Errorf("failed to get video duration: %w", err)
 	}
@@ -193,8 +193,8 @@ func (s *AudioMixer) ApplyDucking(ctx context.Context, videoPath, musicPath, out
 	return nil
 }
 
-func (s *AudioMixer) getVideoDuration(videoPath string) (float64, error) {
-	result, err := s.commandExecutor.Run(context.Background(), "ffprobe",
+func (s *AudioMixer) getVideoDuration(ctx context.Context, videoPath string) (float64, error) {
+	result, err := s.commandExecutor.Run(ctx, "ffprobe",
 		"-v", "error",
 		"-show_entries", "format=duration",
 		"-of", "default=noprint_wrappers=1:nokey=1",
@@ -6,6 +6,7 @@ import (
 	"io"
 	"strings"
 	"testing"
+	"testing/iotest"
 
 	"gocreator/internal/mocks"
 
@@ -119,6 +120,38 @@ func TestAudioService_Generate_WithCache(t *testing.T) {
 	mockClient.AssertExpectations(t)
 }
 
+func TestAudioService_Generate_DiscardsPartialAudio(t *testing.T) {
+	fs := afero.NewMemMapFs()
+	mockClient := new(mocks.MockOpenAIClient)
+	logger := &mockLogger{}
+	service := NewAudioService(fs, mockClient, NewTextService(fs, logger), logger)
+
+	text := "Hello world"
+	outputPath := testPath("output", "audio.mp3")
+
+	interrupted := io.NopCloser(io.MultiReader(strings.NewReader("partial"), iotest.ErrReader(context.Canceled)))
+	mockClient.On("GenerateSpeech", mock.Anything, text).Return(interrupted, nil).Once()
+
+	err := service.Generate(context.Background(), text, outputPath)
+	require.ErrorIs(t, err, context.Canceled)
+
+	exists, err := afero.Exists(fs, outputPath)
+	require.NoError(t, err)
+	assert.False(t, exists)
+	exists, err = afero.Exists(fs, outputPath+".hash")
+	require.NoError(t, err)
+	assert.False(t, exists)
+
+	// The next run must synthesize again instead of treating the partial file as cached
+	mockClient.On("GenerateSpeech", mock.Anything, text).Return(newMockReadCloser("audio data"), nil).Once()
+	require.NoError(t, service.Generate(context.Background(), text, outputPath))
+
+	content, err := afero.ReadFile(fs, outputPath)
+	require.NoError(t, err)
+	assert.Equal(t, "audio data", string(content))
+	mockClient.AssertExpectations(t)
+}
+
 func TestAudioService_GenerateBatch(t *testing.T) {
 	tests := []struct {
 		name          string
@@ -6,6 +6,7 @@ import (
 	"os/exec"
 	"strconv"
 	"strings"
+	"time"
 
 	"gocreator/internal/interfaces"
 )
@@ -16,14 +17,30 @@ func newCommandExecutor() interfaces.CommandExecutor {
 	return realCommandExecutor{}
 }
 
-func (realCommandExecutor) Run(ctx context.Context, name string, args ...string) (interfaces.CommandResult, error) {
+func (e realCommandExecutor) Run(ctx context.Context, name string, args ...string) (interfaces.CommandResult, error) {
+	return e.RunWithStdin(ctx, nil, name, args...)
+}
+
+// RunWithStdin runs the command with stdin written to its standard input.
+func (realCommandExecutor) RunWithStdin(ctx context.Context, stdin []byte, name string, args ...string) (interfaces.CommandResult, error) {
+	release, err := DefaultScheduler().AcquireFFmpeg(ctx)
+	if err != nil {
+		return interfaces.CommandResult{}, err
+	}
+	defer release()
+
 	cmd := exec.CommandContext(ctx, name, args...)
+	if stdin != nil {
+		cmd.Stdin = bytes.NewReader(stdin)
+	}
 	var stdout bytes.Buffer
 	var stderr bytes.Buffer
 	cmd.Stdout = &stdout
 	cmd.Stderr = &stderr
 
-	err := cmd.Run()
+	started := time.Now()
+	err = cmd.Run()
+	recordCommand(ctx, name, time.Since(started))
 	return interfaces.CommandResult{
 		Stdout: stdout.Bytes(),
 		Stderr: stderr.Bytes(),
@@ -13,13 +13,15 @@ import (
 )
 
 type recordedCommand struct {
-	Name string
-	Args []string
+	Name  string
+	Args  []string
+	Stdin string
 }
 
 type expectedCommand struct {
 	Name     string
 	Contains []string
+	Stdin    string
 	Result   interfaces.CommandResult
 	Err      error
 	Run      func(name string, args []string)
@@ -46,12 +48,16 @@ func newCommandResult(stdout, stderr string) interfaces.CommandResult {
 	}
 }
 
-func (f *fakeCommandExecutor) Run(_ context.Context, name string, args ...string) (interfaces.CommandResult, error) {
+func (f *fakeCommandExecutor) Run(ctx context.Context, name string, args ...string) (interfaces.CommandResult, error) {
+	return f.RunWithStdin(ctx, nil, name, args...)
+}
+
+func (f *fakeCommandExecutor) RunWithStdin(_ context.Context, stdin []byte, name string, args ...string) (interfaces.CommandResult, error) {
 	f.mu.Lock()
 	defer f.mu.Unlock()
 
 	copiedArgs := append([]string(nil), args...)
-	f.calls = append(f.calls, recordedCommand{Name: name, Args: copiedArgs})
+	f.calls = append(f.calls, recordedCommand{Name: name, Args: copiedArgs, Stdin: string(stdin)})
 
 	if len(f.expectations) == 0 {
 		return interfaces.CommandResult{}, fmt.Errorf("unexpected command: %s", formatCommand(name, args...))
@@ -71,6 +77,10 @@ func (f *fakeCommandExecutor) Run(_ context.Context, name string, args ...string
 		}
 	}
 
+	if expectation.Stdin != "" && expectation.Stdin != string(stdin) {
+		return interfaces.CommandResult{}, fmt.Errorf("command %q expected stdin %q, got %q", name, expectation.Stdin, string(stdin))
+	}
+
 	if expectation.Run != nil {
 		expectation.Run(name, copiedArgs)
 	}
@@ -92,8 +102,9 @@ func (f *fakeCommandExecutor) Calls() []recordedCommand {
 	cloned := make([]recordedCommand, len(f.calls))
 	for i, call := range f.calls {
 		cloned[i] = recordedCommand{
-			Name: call.Name,
-			Args: append([]string(nil), call.Args...),
+			Name:  call.Name,
+			Args:  append([]string(nil), call.Args...),
+			Stdin: call.Stdin,
 		}
 	}
 
@@ -6,7 +6,7 @@ import (
 	"path/filepath"
 	"reflect"
 	"strings"
-	"sync"
+	"time"
 
 	"gocreator/internal/config"
 	"gocreator/internal/interfaces"
@@ -20,10 +20,12 @@ type VideoCreatorConfig struct {
 	InputLang        string
 	OutputLangs      []string
 	ProgressCallback interfaces.ProgressCallback
-	Transition       TransitionConfig        // Transition configuration for slide transitions
-	Timing           config.TimingConfig     // Timing and alignment configuration
-	Effects          []config.EffectConfig   // Per-slide visual effects
-	MultiView        *config.MultiViewConfig // Multi-view configuration for split-screen layouts
+	Transition       TransitionConfig         // Transition configuration for slide transitions
+	SlideTransitions map[int]TransitionConfig // Transitions into specific slides, overriding Transition
+	Timing           config.TimingConfig      // Timing and alignment configuration
+	Effects          []config.EffectConfig    // Per-slide visual effects
+	MultiView        *config.MultiViewConfig  // Multi-view configuration for split-screen layouts
+	Pip              config.PipConfig         // Picture-in-picture overlays on slide ranges
 	Output           config.OutputConfig
 	Voice            config.VoiceConfig
 	Encoding         config.EncodingConfig
@@ -33,6 +35,7 @@ type VideoCreatorConfig struct {
 	Outro            config.OutroConfig
 	Metadata         config.MetadataConfig
 	Chapters         config.ChaptersConfig
+	KeepGoing        bool // finish every language it can and return a *PartialFailureError listing the failures
 }
 
 // VideoCreator orchestrates the video creation process
@@ -45,6 +48,9 @@ type VideoCreator struct {
 	slideService       interfaces.SlideLoader
 	logger             interfaces.Logger
 	postProcessService *PostProcessService
+
+	transcriptionService *TranscriptionService // transcribes prerecorded narration when set
+	writeTranscripts     bool                  // save transcripts as basename.<lang>.txt sidecars
 }
 
 // NewVideoCreator creates a new video creator
@@ -87,6 +93,14 @@ func NewVideoCreatorWithPostProcessor(
 	}
 }
 
+// SetTranscriptionService enables transcription of prerecorded narration that has no text sidecar,
+// so it gets subtitles and can be translated. With writeSidecars, each transcript is also saved as
+// a basename.<lang>.txt sidecar next to the slide.
+func (vc *VideoCreator) SetTranscriptionService(service *TranscriptionService, writeSidecars bool) {
+	vc.transcriptionService = service
+	vc.writeTranscripts = writeSidecars
+}
+
 // Create creates videos for all specified languages
 func (vc *VideoCreator) Create(ctx context.Context, cfg VideoCreatorConfig) error {
 	dataDir := filepath.Join(cfg.RootDir, "data")
@@ -97,7 +111,7 @@ func (vc *VideoCreator) Create(ctx context.Context, cfg VideoCreatorConfig) erro
 		progress = &interfaces.NoOpProgressCallback{}
 	}
 
-	// Configure video service with transitions and multi-view if available
+	// Configure video service with transitions if available; slide-specific settings follow once slides are loaded
 	if videoService, ok := vc.videoService.(*VideoService); ok {
 		if err := videoService.SetMediaAlignment(cfg.Timing.MediaAlignment); err != nil {
 			return fmt.Errorf("invalid media alignment: %w", err)
@@ -113,17 +127,6 @@ func (vc *VideoCreator) Create(ctx context.Context, cfg VideoCreatorConfig) erro
 				vc.logger.Debug("Transitions are disabled", "type", cfg.Transition.Type)
 			}
 		}
-
-		videoService.SetEffects(cfg.Effects)
-		if len(cfg.Effects) > 0 {
-			vc.logger.Info("Effects enabled", "count", len(cfg.Effects))
-		}
-
-		// Configure multi-view if enabled
-		if cfg.MultiView != nil && cfg.MultiView.Enabled {
-			videoService.SetMultiView(cfg.MultiView)
-			vc.logger.Info("Multi-view enabled", "layouts", len(cfg.MultiView.Layouts))
-		}
 	}
 
 	var slides []string
@@ -149,23 +152,76 @@ func (vc *VideoCreator) Create(ctx context.Context, cfg VideoCreatorConfig) erro
 		return fmt.Errorf("no slides found in %s", slidesDir)
 	}
 
-	progress.OnStageComplete("Loading", true, fmt.Sprintf("Loaded %d slides", len(slides)))
+	// Slide names and globs in the config resolve to indices into the loaded slides
+	cfg, err = resolveSlideSelectors(cfg, slides)
+	if err != nil {
+		progress.OnStageComplete("Loading", false, fmt.Sprintf("Failed: %v", err))
+		return err
+	}
+
+	// Silent markers and sidecar front-matter become per-slide settings shared by every language
+	cfg.Timing, err = vc.resolveSilentSlides(slidesDir, slides, cfg.Timing)
+	if err != nil {
+		progress.OnStageComplete("Loading", false, fmt.Sprintf("Failed: %v", err))
+		return err
+	}
+	cfg, err = vc.applySidecarFrontMatter(slidesDir, slides, cfg)
+	if err != nil {
+		progress.OnStageComplete("Loading", false, fmt.Sprintf("Failed: %v", err))
+		return err
+	}
+	if videoService, ok := vc.videoService.(*VideoService); ok {
+		videoService.SetTiming(cfg.Timing)
+		videoService.SetSlideTransitions(cfg.SlideTransitions)
+		videoService.SetEffects(cfg.Effects)
+		videoService.SetEncoding(cfg.Encoding)
+		if cfg.Output.ResolveRenderStrategy() == config.RenderStrategySharedPicture {
+			// Languages share one encoded picture per image slide and only mux their narration
+			videoService.SetSharedPictureCache(filepath.Join(dataDir, "cache", "pictures"))
+			vc.logger.Info("Shared picture rendering enabled")
+		}
+		if len(cfg.Effects) > 0 {
+			vc.logger.Info("Effects enabled", "count", len(cfg.Effects))
+		}
 
-	// Process each language in parallel
-	var wg sync.WaitGroup
-	errors := make([]error, len(cfg.OutputLangs))
+		// Configure multi-view if enabled
+		if cfg.MultiView != nil && cfg.MultiView.Enabled {
+			videoService.SetMultiView(cfg.MultiView)
+			vc.logger.Info("Multi-view enabled", "layouts", len(cfg.MultiView.Layouts))
+		}
 
-	for i, lang := range cfg.OutputLangs {
-		wg.Add(1)
-		go func(idx int, l string) {
-			defer wg.Done()
-			if err := vc.processLanguage(ctx, cfg, l, slides, slidesDir, dataDir, progress); err != nil {
-				errors[idx] = fmt.Errorf("failed to process language %s: %w", l, err)
-			}
-		}(i, lang)
+		// Configure picture-in-picture overlays if enabled
+		if cfg.Pip.Enabled {
+			pip := ResolvePipVideos(cfg.RootDir, cfg.Pip)
+			videoService.SetPip(&pip)
+			vc.logger.Info("PiP overlays enabled", "overlays", len(pip.Overlays))
+		}
 	}
 
-	wg.Wait()
+	progress.OnStageComplete("Loading", true, fmt.Sprintf("Loaded %d slides", len(slides)))
+
+	// Process languages in parallel, bounded by the shared scheduler
+	languageReports := make([]*LanguageReport, len(cfg.OutputLangs))
+	if report := runReportFrom(ctx); report != nil {
+		for idx, lang := range cfg.OutputLangs {
+			languageReports[idx] = report.Language(lang)
+		}
+	}
+
+	errors := runBounded(ctx, len(cfg.OutputLangs), DefaultScheduler().FFmpegLimit(), func(idx int) error {
+		lang := cfg.OutputLangs[idx]
+		started := time.Now()
+		err := vc.processLanguage(withLanguageReport(ctx, languageReports[idx]), cfg, lang, slides, slidesDir, dataDir, progress)
+		languageReports[idx].finish(err, time.Since(started))
+		if err != nil {
+			return fmt.Errorf("failed to process language %s: %w", lang, err)
+		}
+		return nil
+	})
+
+	if cfg.KeepGoing {
+		return keepGoingResult(ctx, cfg.OutputLangs, errors)
+	}
 
 	// Check for any errors
 	for _, err := range errors {
@@ -177,6 +233,27 @@ func (vc *VideoCreator) Create(ctx context.Context, cfg VideoCreatorConfig) erro
 	return nil
 }
 
+// keepGoingResult turns per-language errors into a failure report. Cancellation is still returned as is.
+func keepGoingResult(ctx context.Context, langs []string, errs []error) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	report := FailureReport{Succeeded: []string{}, Failed: []LanguageFailure{}}
+	for idx, err := range errs {
+		if err == nil {
+			report.Succeeded = append(report.Succeeded, langs[idx])
+			continue
+		}
+		report.Failed = append(report.Failed, newLanguageFailure(langs[idx], err))
+	}
+
+	if len(report.Failed) == 0 {
+		return nil
+	}
+	return &PartialFailureError{Report: report}
+}
+
 func (vc *VideoCreator) processLanguage(
 	ctx context.Context,
 	cfg VideoCreatorConfig,
@@ -191,7 +268,7 @@ func (vc *VideoCreator) processLanguage(
 
 	cacheDir := filepath.Join(dataDir, "cache", lang)
 	audioDir := filepath.Join(cacheDir, "audio")
-	outputDir := resolveOutputDir(cfg.RootDir, cfg.Output.Directory)
+	outputDir := ResolveOutputDir(cfg.RootDir, cfg.Output.Directory)
 	outputBaseName := fmt.Sprintf("output-%s", lang)
 	primaryOutputPath := filepath.Join(outputDir, outputBaseName+"."+primaryOutputExtension(cfg.Output))
 	outputTargetPath := primaryOutputPath
@@ -202,15 +279,15 @@ func (vc *VideoCreator) processLanguage(
 	// Translation stage
 	progress.OnItemStart("Translation", lang)
 	progress.OnItemProgress("Translation", lang, 40, "Resolving slide sidecars...")
-	texts, translatedCount, err := vc.resolveTextsForLanguage(ctx, cfg.InputLang, lang, slidesDir, slides)
+	texts, translatedIndexes, err := vc.resolveTextsForLanguage(ctx, cfg.InputLang, lang, slidesDir, slides, cfg.Timing)
 	if err != nil {
 		progress.OnItemComplete("Translation", lang, false, fmt.Sprintf("Error: %v", err))
-		return fmt.Errorf("failed to resolve texts: %w", err)
+		return &stageError{stage: StageTranslation, err: fmt.Errorf("failed to resolve texts: %w", err)}
 	}
 
 	switch {
-	case translatedCount > 0:
-		progress.OnItemComplete("Translation", lang, true, fmt.Sprintf("Translated %d slide texts", translatedCount))
+	case len(translatedIndexes) > 0:
+		progress.OnItemComplete("Translation", lang, true, fmt.Sprintf("Translated %d slide texts", len(translatedIndexes)))
 	default:
 		progress.OnItemComplete("Translation", lang, true, "Using local sidecars")
 	}
@@ -220,13 +297,27 @@ func (vc *VideoCreator) processLanguage(
 	progress.OnItemProgress("Audio Generation", lang, 30, "Resolving narration...")
 	audioGenerator := vc.audioService
 	if service, ok := vc.audioService.(*AudioService); ok {
-		audioGenerator = service.WithSpeechOptions(resolveSpeechOptions(cfg.Voice, lang))
+		providerName := cfg.Voice.ResolveProvider(lang)
+		providerService, err := service.WithProvider(providerName)
+		if err != nil {
+			progress.OnItemComplete("Audio Generation", lang, false, fmt.Sprintf("Error: %v", err))
+			return &stageError{stage: StageAudio, err: fmt.Errorf("failed to select TTS provider: %w", err)}
+		}
+		logger.Debug("Using TTS provider", "provider", providerName)
+		pronunciations, err := vc.loadPronunciations(cfg, lang)
+		if err != nil {
+			progress.OnItemComplete("Audio Generation", lang, false, fmt.Sprintf("Error: %v", err))
+			return &stageError{stage: StageAudio, err: err}
+		}
+		audioGenerator = providerService.WithSpeechOptions(resolveSpeechOptions(cfg.Voice, lang)).
+			WithPronunciations(pronunciations).
+			WithChunking(cfg.Voice.Chunking)
 	}
 
-	audioPaths, prerecordedCount, generatedCount, err := vc.resolveAudioForLanguage(ctx, audioGenerator, cfg.InputLang, lang, slidesDir, slides, texts, audioDir)
+	audioPaths, prerecordedCount, generatedCount, err := vc.resolveAudioForLanguage(ctx, audioGenerator, cfg.InputLang, lang, slidesDir, slides, texts, audioDir, cfg.Timing)
 	if err != nil {
 		progress.OnItemComplete("Audio Generation", lang, false, fmt.Sprintf("Error: %v", err))
-		return fmt.Errorf("audio generation failed: %w", err)
+		return &stageError{stage: StageAudio, err: fmt.Errorf("audio generation failed: %w", err)}
 	}
 	progress.OnItemComplete("Audio Generation", lang, true, fmt.Sprintf("Using %d prerecorded and %d generated tracks", prerecordedCount, generatedCount))
 
@@ -237,36 +328,58 @@ func (vc *VideoCreator) processLanguage(
 
 	if err := vc.videoService.GenerateFromSlides(ctx, slides, audioPaths, outputTargetPath); err != nil {
 		progress.OnItemComplete("Video Assembly", lang, false, fmt.Sprintf("Error: %v", err))
-		return fmt.Errorf("video generation failed: %w", err)
+		return &stageError{stage: StageVideo, err: fmt.Errorf("video generation failed: %w", err)}
 	}
 
 	finalOutputPath := outputTargetPath
+	outputs := LanguageOutputs{Video: outputTargetPath}
+	var audioDurations, segmentDurations []float64
 	if needsPostProcess(cfg, lang) {
 		result, err := vc.postProcessService.Run(ctx, PostProcessRequest{
-			RootDir:        cfg.RootDir,
-			OutputDir:      outputDir,
-			BaseName:       outputBaseName,
-			Lang:           lang,
-			MasterVideo:    outputTargetPath,
-			Slides:         slides,
-			Texts:          texts,
-			AudioPaths:     audioPaths,
-			MediaAlignment: cfg.Timing.MediaAlignment,
-			Output:         cfg.Output,
-			Encoding:       cfg.Encoding,
-			Audio:          cfg.Audio,
-			Subtitles:      cfg.Subtitles,
-			Intro:          cfg.Intro,
-			Outro:          cfg.Outro,
-			Metadata:       cfg.Metadata,
-			Chapters:       cfg.Chapters,
+			RootDir:     cfg.RootDir,
+			OutputDir:   outputDir,
+			BaseName:    outputBaseName,
+			Lang:        lang,
+			MasterVideo: outputTargetPath,
+			Slides:      slides,
+			Texts:       displayNarrations(texts),
+			AudioPaths:  audioPaths,
+			CacheDir:    cacheDir,
+			Timing:      cfg.Timing,
+			Output:      cfg.Output,
+			Encoding:    cfg.Encoding,
+			Audio:       cfg.Audio,
+			Subtitles:   cfg.Subtitles,
+			Intro:       cfg.Intro,
+			Outro:       cfg.Outro,
+			Metadata:    cfg.Metadata,
+			Chapters:    cfg.Chapters,
 		})
 		if err != nil {
 			progress.OnItemComplete("Video Assembly", lang, false, fmt.Sprintf("Error: %v", err))
-			return fmt.Errorf("post-processing failed: %w", err)
+			return &stageError{stage: StagePostProcessing, err: fmt.Errorf("post-processing failed: %w", err)}
 		}
 		finalOutputPath = result.PrimaryOutputPath
 		_ = vc.fs.Remove(outputTargetPath)
+
+		outputs = LanguageOutputs{
+			Video:     result.PrimaryOutputPath,
+			Exports:   result.ExportedPaths,
+			Subtitles: result.SubtitlePaths,
+			Thumbnail: result.ThumbnailPath,
+		}
+		audioDurations, segmentDurations = result.AudioDurations, result.SegmentDurations
+	}
+
+	if languageReport := languageReportFrom(ctx); languageReport != nil {
+		languageReport.setOutputs(outputs)
+		if audioDurations == nil {
+			audioDurations, segmentDurations, err = vc.postProcessService.computeTimelineDurations(ctx, slides, audioPaths, cfg.Timing)
+			if err != nil {
+				logger.Warn("Failed to measure slide durations for the run report", "error", err)
+			}
+		}
+		languageReport.setSlides(slideReports(slides, audioPaths, audioDurations, segmentDurations))
 	}
 
 	logger.Info("Video created successfully", "path", finalOutputPath)
@@ -274,18 +387,68 @@ func (vc *VideoCreator) processLanguage(
 	return nil
 }
 
+// slideReports describes each slide for the run report; durations are zero when they could not be measured.
+func slideReports(slides, audioPaths []string, audioDurations, segmentDurations []float64) []SlideReport {
+	reports := make([]SlideReport, len(slides))
+	for idx, slide := range slides {
+		reports[idx] = SlideReport{Index: idx, Slide: slide}
+		if idx < len(audioPaths) {
+			reports[idx].Audio = audioPaths[idx]
+		}
+		if idx < len(audioDurations) {
+			reports[idx].NarrationSeconds = audioDurations[idx]
+		}
+		if idx < len(segmentDurations) {
+			reports[idx].DurationSeconds = segmentDurations[idx]
+		}
+	}
+	return reports
+}
+
+// loadPronunciations loads the pronunciation dictionary of a language from
+// voice.per_language.<lang>.pronunciation, falling back to data/pronunciations/<lang>.yaml when it exists.
+func (vc *VideoCreator) loadPronunciations(cfg VideoCreatorConfig, lang string) (PronunciationDictionary, error) {
+	path := cfg.Voice.PerLanguage[lang].Pronunciation
+	if path == "" {
+		path = filepath.Join(cfg.RootDir, "data", "pronunciations", lang+".yaml")
+		exists, err := afero.Exists(vc.fs, path)
+		if err != nil || !exists {
+			return nil, err
+		}
+	} else if !filepath.IsAbs(path) {
+		path = filepath.Join(cfg.RootDir, path)
+	}
+
+	return LoadPronunciationDictionary(vc.fs, path)
+}
+
 func resolveSpeechOptions(cfg config.VoiceConfig, lang string) interfaces.SpeechOptions {
 	options := interfaces.SpeechOptions{
-		Model: cfg.Model,
-		Voice: cfg.Voice,
 		Speed: cfg.Speed,
 	}
-	if options.Model == "" {
-		options.Model = "tts-1-hd"
-	}
-	if options.Voice == "" {
-		options.Voice = "alloy"
+
+	// Model and voice names are engine-specific, so only the selected provider's settings apply.
+	switch cfg.ResolveProvider(lang) {
+	case config.TTSProviderOpenAI:
+		options.Model = cfg.Model
+		options.Voice = cfg.Voice
+		if options.Model == "" {
+			options.Model = "tts-1-hd"
+		}
+		if options.Voice == "" {
+			options.Voice = "alloy"
+		}
+	case config.TTSProviderPiper:
+		options.Voice = cfg.Piper.Voice
+	case config.TTSProviderEspeak:
+		options.Voice = cfg.Espeak.Voice
+		if options.Voice == "" {
+			options.Voice = lang
+		}
+	case config.TTSProviderCommand:
+		options.Voice = cfg.Command.Voice
 	}
+
 	if options.Speed <= 0 {
 		options.Speed = 1.0
 	}
@@ -302,7 +465,8 @@ func resolveSpeechOptions(cfg config.VoiceConfig, lang string) interfaces.Speech
 	return options
 }
 
-func resolveOutputDir(rootDir, configuredDir string) string {
+// ResolveOutputDir returns the directory videos are written to, defaulting to data/out under rootDir.
+func ResolveOutputDir(rootDir, configuredDir string) string {
 	if strings.TrimSpace(configuredDir) == "" {
 		return filepath.Join(rootDir, "data", "out")
 	}
@@ -3,6 +3,7 @@ package services
 import (
 	"context"
 	"errors"
+	"fmt"
 	"testing"
 
 	"gocreator/internal/mocks"
@@ -248,3 +249,75 @@ func TestVideoCreatorCreate_FailsWhenSlideLoadingFails(t *testing.T) {
 	require.Error(t, err)
 	assert.Contains(t, err.Error(), "failed to load slides")
 }
+
+func TestVideoCreatorCreate_KeepGoingReportsFailedLanguagesAndSlides(t *testing.T) {
+	fs := afero.NewMemMapFs()
+	mockText := new(mocks.MockTextProcessor)
+	mockTranslation := new(mocks.MockTranslator)
+	mockAudio := new(mocks.MockAudioGenerator)
+	mockVideo := new(mocks.MockVideoGenerator)
+	mockSlide := new(mocks.MockSlideLoader)
+	logger := &mockLogger{}
+
+	rootDir := testPath("test")
+	slidesDir := testPath("test", "data", "slides")
+	slides := []string{
+		testPath("test", "data", "slides", "1.png"),
+		testPath("test", "data", "slides", "2.png"),
+		testPath("test", "data", "slides", "3.png"),
+	}
+	enAudio := []string{
+		testPath("test", "data", "cache", "en", "audio", "0.mp3"),
+		testPath("test", "data", "cache", "en", "audio", "1.mp3"),
+		testPath("test", "data", "cache", "en", "audio", "2.mp3"),
+	}
+	frAudio := []string{
+		testPath("test", "data", "cache", "fr", "audio", "0.mp3"),
+		testPath("test", "data", "cache", "fr", "audio", "1.mp3"),
+		testPath("test", "data", "cache", "fr", "audio", "2.mp3"),
+	}
+
+	require.NoError(t, fs.MkdirAll(slidesDir, 0755))
+	for i, text := range []string{"One", "Two", "Three"} {
+		require.NoError(t, afero.WriteFile(fs, slides[i], []byte(text), 0644))
+		require.NoError(t, afero.WriteFile(fs, testPath("test", "data", "slides", fmt.Sprintf("%d.txt", i+1)), []byte(text), 0644))
+	}
+	for i, text := range []string{"Un", "Deux", "Trois"} {
+		require.NoError(t, afero.WriteFile(fs, testPath("test", "data", "slides", fmt.Sprintf("%d.fr.txt", i+1)), []byte(text), 0644))
+	}
+
+	mockSlide.On("LoadSlides", mock.Anything, slidesDir).Return(slides, nil).Once()
+	mockAudio.On("Generate", mock.Anything, "One", enAudio[0]).Return(nil).Once()
+	mockAudio.On("Generate", mock.Anything, "Two", enAudio[1]).Return(nil).Once()
+	mockAudio.On("Generate", mock.Anything, "Three", enAudio[2]).Return(nil).Once()
+	mockAudio.On("Generate", mock.Anything, "Un", frAudio[0]).Return(nil).Once()
+	mockAudio.On("Generate", mock.Anything, "Deux", frAudio[1]).Return(errors.New("voice unavailable")).Once()
+	mockAudio.On("Generate", mock.Anything, "Trois", frAudio[2]).Return(errors.New("rate limited")).Once()
+	mockVideo.On("GenerateFromSlides", mock.Anything, slides, enAudio, testPath("test", "data", "out", "output-en.mp4")).Return(nil).Once()
+
+	creator := NewVideoCreator(fs, mockText, mockTranslation, mockAudio, mockVideo, mockSlide, logger)
+	err := creator.Create(context.Background(), VideoCreatorConfig{
+		RootDir:     rootDir,
+		InputLang:   "en",
+		OutputLangs: []string{"en", "fr"},
+		KeepGoing:   true,
+	})
+
+	var partial *PartialFailureError
+	require.ErrorAs(t, err, &partial)
+	assert.Equal(t, []string{"en"}, partial.Report.Succeeded)
+	require.Len(t, partial.Report.Failed, 1)
+
+	failure := partial.Report.Failed[0]
+	assert.Equal(t, "fr", failure.Lang)
+	assert.Equal(t, StageAudio, failure.Stage)
+	require.Len(t, failure.Slides, 2)
+	assert.Equal(t, 1, failure.Slides[0].Index)
+	assert.Contains(t, failure.Slides[0].Error, "voice unavailable")
+	assert.Equal(t, 2, failure.Slides[1].Index)
+	assert.Contains(t, failure.Slides[1].Error, "rate limited")
+	assert.Contains(t, failure.Slides[1].Error, "3")
+
+	mockAudio.AssertExpectations(t)
+	mockVideo.AssertExpectations(t)
+}
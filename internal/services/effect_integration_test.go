@@ -41,7 +41,7 @@ func TestVideoService_GenerateSingleVideo_ImageEffectsSmoke(t *testing.T) {
 	require.NoError(t, err)
 	assert.Greater(t, info.Size(), int64(0))
 
-	duration, err := service.getVideoDuration(outputPath)
+	duration, err := service.getVideoDuration(context.Background(), outputPath)
 	require.NoError(t, err)
 	assert.Greater(t, duration, 1.0)
 	assert.Less(t, duration, 2.5)
@@ -71,7 +71,7 @@ func TestVideoService_GenerateSingleVideo_StabilizeSmoke(t *testing.T) {
 	require.NoError(t, err)
 	assert.Greater(t, info.Size(), int64(0))
 
-	duration, err := service.getVideoDuration(outputPath)
+	duration, err := service.getVideoDuration(context.Background(), outputPath)
 	require.NoError(t, err)
 	assert.Greater(t, duration, 1.0)
 
@@ -367,9 +367,13 @@ func copyFileWithinFS(fs afero.Fs, sourcePath, targetPath string) error {
 	if err != nil {
 		return fmt.Errorf("failed to create target file %s: %w", targetPath, err)
 	}
-	defer func() { _ = target.Close() }()
 
-	if _, err := io.Copy(target, source); err != nil {
+	_, err = io.Copy(target, source)
+	if closeErr := target.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		_ = fs.Remove(targetPath)
 		return fmt.Errorf("failed to copy %s to %s: %w", sourcePath, targetPath, err)
 	}
 
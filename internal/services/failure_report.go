@@ -0,0 +1,170 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/spf13/afero"
+)
+
+// Pipeline stages reported when a language fails.
+const (
+	StageTranslation    = "translation"
+	StageAudio          = "audio"
+	StageVideo          = "video"
+	StagePostProcessing = "post-processing"
+)
+
+// SlideError ties a failure to one slide so reports can list every broken slide of a language.
+// Its message is the wrapped error's message.
+type SlideError struct {
+	Index int
+	Slide string
+	Err   error
+}
+
+func (e *SlideError) Error() string {
+	return e.Err.Error()
+}
+
+func (e *SlideError) Unwrap() error {
+	return e.Err
+}
+
+// stageError records the pipeline stage a language failed in.
+type stageError struct {
+	stage string
+	err   error
+}
+
+func (e *stageError) Error() string {
+	return e.err.Error()
+}
+
+func (e *stageError) Unwrap() error {
+	return e.err
+}
+
+// SlideFailure is one slide that could not be processed.
+type SlideFailure struct {
+	Index int    `json:"index"`
+	Slide string `json:"slide"`
+	Error string `json:"error"`
+}
+
+// LanguageFailure is one output language that could not be produced.
+type LanguageFailure struct {
+	Lang   string         `json:"lang"`
+	Stage  string         `json:"stage,omitempty"`
+	Error  string         `json:"error"`
+	Slides []SlideFailure `json:"slides,omitempty"`
+}
+
+// FailureReport summarizes a keep-going run: the languages that were produced and those that failed.
+type FailureReport struct {
+	Succeeded []string          `json:"succeeded"`
+	Failed    []LanguageFailure `json:"failed"`
+}
+
+// Summary renders the report for the terminal.
+func (r FailureReport) Summary() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "%d of %d languages failed\n", len(r.Failed), len(r.Failed)+len(r.Succeeded))
+	if len(r.Succeeded) > 0 {
+		fmt.Fprintf(&b, "  succeeded: %s\n", strings.Join(r.Succeeded, ", "))
+	}
+	for _, failure := range r.Failed {
+		stage := failure.Stage
+		if stage == "" {
+			stage = "unknown stage"
+		}
+		fmt.Fprintf(&b, "  %s failed during %s: %s\n", failure.Lang, stage, failure.Error)
+		for _, slide := range failure.Slides {
+			fmt.Fprintf(&b, "    slide %d (%s): %s\n", slide.Index+1, slideNarrationLabel(slide.Slide), slide.Error)
+		}
+	}
+	return b.String()
+}
+
+// PartialFailureError is returned by Create in keep-going mode when at least one language failed.
+type PartialFailureError struct {
+	Report FailureReport
+}
+
+func (e *PartialFailureError) Error() string {
+	langs := make([]string, len(e.Report.Failed))
+	for i, failure := range e.Report.Failed {
+		langs[i] = failure.Lang
+	}
+	return fmt.Sprintf("%d of %d languages failed: %s",
+		len(e.Report.Failed), len(e.Report.Failed)+len(e.Report.Succeeded), strings.Join(langs, ", "))
+}
+
+// WriteFailureReport writes the report as indented JSON.
+func WriteFailureReport(fs afero.Fs, path string, report FailureReport) error {
+	data, err := json.MarshalIndent(report, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode failure report: %w", err)
+	}
+	if err := fs.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return fmt.Errorf("failed to create report directory: %w", err)
+	}
+	if err := afero.WriteFile(fs, path, append(data, '\n'), 0644); err != nil {
+		return fmt.Errorf("failed to write failure report: %w", err)
+	}
+	return nil
+}
+
+func newLanguageFailure(lang string, err error) LanguageFailure {
+	failure := LanguageFailure{
+		Lang:   lang,
+		Error:  err.Error(),
+		Slides: collectSlideFailures(err),
+	}
+
+	var stageErr *stageError
+	if errors.As(err, &stageErr) {
+		failure.Stage = stageErr.stage
+		failure.Error = stageErr.err.Error()
+	}
+	return failure
+}
+
+// collectSlideFailures walks an error tree, including joined errors, and returns every slide error in it.
+func collectSlideFailures(err error) []SlideFailure {
+	var failures []SlideFailure
+	var walk func(err error)
+	walk = func(err error) {
+		switch e := err.(type) {
+		case nil:
+			return
+		case *SlideError:
+			failures = append(failures, SlideFailure{Index: e.Index, Slide: e.Slide, Error: e.Err.Error()})
+		case interface{ Unwrap() []error }:
+			for _, inner := range e.Unwrap() {
+				walk(inner)
+			}
+		case interface{ Unwrap() error }:
+			walk(e.Unwrap())
+		}
+	}
+	walk(err)
+	return failures
+}
+
+// joinSlideErrors combines the per-slide errors of a bounded run. A cancelled context is reported once
+// rather than once per slide.
+func joinSlideErrors(ctx context.Context, errs []error) error {
+	if err := ctx.Err(); err != nil {
+		for _, slideErr := range errs {
+			if slideErr != nil {
+				return err
+			}
+		}
+	}
+	return errors.Join(errs...)
+}
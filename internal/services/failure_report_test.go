@@ -0,0 +1,73 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewLanguageFailure_CollectsStageAndSlides(t *testing.T) {
+	slideErrs := errors.Join(
+		&SlideError{Index: 0, Slide: "/slides/01-intro.png", Err: errors.New("ffmpeg crashed")},
+		&SlideError{Index: 3, Slide: "/slides/04-demo.mp4", Err: errors.New("corrupt input")},
+	)
+	err := fmt.Errorf("failed to process language de: %w",
+		&stageError{stage: StageVideo, err: fmt.Errorf("video generation failed: %w", slideErrs)})
+
+	failure := newLanguageFailure("de", err)
+
+	assert.Equal(t, "de", failure.Lang)
+	assert.Equal(t, StageVideo, failure.Stage)
+	assert.True(t, strings.HasPrefix(failure.Error, "video generation failed"), failure.Error)
+	assert.Equal(t, []SlideFailure{
+		{Index: 0, Slide: "/slides/01-intro.png", Error: "ffmpeg crashed"},
+		{Index: 3, Slide: "/slides/04-demo.mp4", Error: "corrupt input"},
+	}, failure.Slides)
+}
+
+func TestJoinSlideErrors_ReportsCancellationOnce(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	err := joinSlideErrors(ctx, []error{context.Canceled, nil, context.Canceled})
+	assert.Equal(t, context.Canceled, err)
+
+	assert.NoError(t, joinSlideErrors(context.Background(), []error{nil, nil}))
+}
+
+func TestFailureReport_SummaryAndJSON(t *testing.T) {
+	report := FailureReport{
+		Succeeded: []string{"en", "es"},
+		Failed: []LanguageFailure{{
+			Lang:   "fr",
+			Stage:  StageAudio,
+			Error:  "audio generation failed",
+			Slides: []SlideFailure{{Index: 1, Slide: "/slides/02-setup.png", Error: "voice unavailable"}},
+		}},
+	}
+
+	summary := report.Summary()
+	assert.Contains(t, summary, "1 of 3 languages failed")
+	assert.Contains(t, summary, "succeeded: en, es")
+	assert.Contains(t, summary, "fr failed during audio: audio generation failed")
+	assert.Contains(t, summary, "slide 2 (02-setup): voice unavailable")
+
+	fs := afero.NewMemMapFs()
+	require.NoError(t, WriteFailureReport(fs, "/out/failure-report.json", report))
+
+	data, err := afero.ReadFile(fs, "/out/failure-report.json")
+	require.NoError(t, err)
+	var decoded FailureReport
+	require.NoError(t, json.Unmarshal(data, &decoded))
+	assert.Equal(t, report, decoded)
+
+	partial := &PartialFailureError{Report: report}
+	assert.Equal(t, "1 of 3 languages failed: fr", partial.Error())
+}
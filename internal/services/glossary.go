@@ -0,0 +1,176 @@
+package services
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"sort"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/goccy/go-yaml"
+	"github.com/spf13/afero"
+)
+
+// Glossary lists terms that must survive translation unchanged or be translated a fixed way.
+type Glossary struct {
+	DoNotTranslate []string                     `yaml:"do_not_translate,omitempty"`
+	Terms          map[string]map[string]string `yaml:"terms,omitempty"` // language -> source term -> forced translation
+}
+
+// glossaryRules are the glossary entries that apply to one source text and target language.
+type glossaryRules struct {
+	keep  []string
+	terms [][2]string
+}
+
+// LoadGlossary reads a glossary YAML file.
+func LoadGlossary(fs afero.Fs, path string) (*Glossary, error) {
+	data, err := afero.ReadFile(fs, path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read glossary: %w", err)
+	}
+
+	var glossary Glossary
+	if err := yaml.Unmarshal(data, &glossary); err != nil {
+		return nil, fmt.Errorf("failed to parse glossary %s: %w", path, err)
+	}
+
+	return &glossary, nil
+}
+
+// rulesFor returns the glossary entries whose source term occurs in text.
+func (g *Glossary) rulesFor(text, targetLang string) glossaryRules {
+	var rules glossaryRules
+	if g == nil {
+		return rules
+	}
+
+	for _, term := range g.DoNotTranslate {
+		term = strings.TrimSpace(term)
+		if term != "" && containsTerm(text, term) {
+			rules.keep = append(rules.keep, term)
+		}
+	}
+	sort.Strings(rules.keep)
+
+	for source, target := range g.Terms[targetLang] {
+		source = strings.TrimSpace(source)
+		target = strings.TrimSpace(target)
+		if source != "" && target != "" && containsTerm(text, source) {
+			rules.terms = append(rules.terms, [2]string{source, target})
+		}
+	}
+	sort.Slice(rules.terms, func(i, j int) bool { return rules.terms[i][0] < rules.terms[j][0] })
+
+	return rules
+}
+
+// merge combines two rule sets, dropping duplicates.
+func (r glossaryRules) merge(other glossaryRules) glossaryRules {
+	merged := glossaryRules{}
+	seenKeep := make(map[string]bool)
+	for _, term := range append(append([]string{}, r.keep...), other.keep...) {
+		if !seenKeep[term] {
+			seenKeep[term] = true
+			merged.keep = append(merged.keep, term)
+		}
+	}
+	sort.Strings(merged.keep)
+
+	seenTerms := make(map[[2]string]bool)
+	for _, term := range append(append([][2]string{}, r.terms...), other.terms...) {
+		if !seenTerms[term] {
+			seenTerms[term] = true
+			merged.terms = append(merged.terms, term)
+		}
+	}
+	sort.Slice(merged.terms, func(i, j int) bool { return merged.terms[i][0] < merged.terms[j][0] })
+
+	return merged
+}
+
+func (r glossaryRules) empty() bool {
+	return len(r.keep) == 0 && len(r.terms) == 0
+}
+
+// instructions renders the rules as extra prompt sentences.
+func (r glossaryRules) instructions() string {
+	var parts []string
+	if len(r.keep) > 0 {
+		quoted := make([]string, len(r.keep))
+		for i, term := range r.keep {
+			quoted[i] = fmt.Sprintf("'%s'", term)
+		}
+		parts = append(parts, fmt.Sprintf("Keep these terms exactly as written, without translating them: %s.", strings.Join(quoted, ", ")))
+	}
+	if len(r.terms) > 0 {
+		pairs := make([]string, len(r.terms))
+		for i, term := range r.terms {
+			pairs[i] = fmt.Sprintf("'%s' as '%s'", term[0], term[1])
+		}
+		parts = append(parts, fmt.Sprintf("Always translate %s.", strings.Join(pairs, ", ")))
+	}
+	return strings.Join(parts, " ")
+}
+
+// fingerprint identifies the rules so glossary edits invalidate only affected translations.
+func (r glossaryRules) fingerprint() string {
+	if r.empty() {
+		return ""
+	}
+
+	var b strings.Builder
+	for _, term := range r.keep {
+		fmt.Fprintf(&b, "keep:%s\n", term)
+	}
+	for _, term := range r.terms {
+		fmt.Fprintf(&b, "term:%s=%s\n", term[0], term[1])
+	}
+	hash := sha256.Sum256([]byte(b.String()))
+	return hex.EncodeToString(hash[:])
+}
+
+// missing returns the protected or forced terms absent from a translation.
+func (r glossaryRules) missing(translated string) []string {
+	var missing []string
+	for _, term := range r.keep {
+		if !containsTerm(translated, term) {
+			missing = append(missing, term)
+		}
+	}
+	for _, term := range r.terms {
+		if !containsTerm(translated, term[1]) {
+			missing = append(missing, term[1])
+		}
+	}
+	return missing
+}
+
+// containsTerm reports whether term occurs in text as a whole word, ignoring case.
+func containsTerm(text, term string) bool {
+	text = strings.ToLower(text)
+	term = strings.ToLower(term)
+
+	for offset := 0; offset < len(text); {
+		idx := strings.Index(text[offset:], term)
+		if idx < 0 {
+			return false
+		}
+		start := offset + idx
+		end := start + len(term)
+
+		before, _ := utf8.DecodeLastRuneInString(text[:start])
+		after, _ := utf8.DecodeRuneInString(text[end:])
+		if !isWordRune(before) && !isWordRune(after) {
+			return true
+		}
+		offset = start + 1
+	}
+	return false
+}
+
+func isWordRune(r rune) bool {
+	return r != utf8.RuneError && (unicode.IsLetter(r) || unicode.IsDigit(r))
+}
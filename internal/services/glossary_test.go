@@ -0,0 +1,102 @@
+package services
+
+import (
+	"context"
+	"testing"
+
+	"gocreator/internal/mocks"
+
+	"github.com/openai/openai-go/v3"
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func testGlossary() *Glossary {
+	return &Glossary{
+		DoNotTranslate: []string{"GoCreator", "Kubernetes"},
+		Terms: map[string]map[string]string{
+			"fr": {"pipeline": "chaîne de traitement"},
+		},
+	}
+}
+
+func TestLoadGlossary(t *testing.T) {
+	fs := afero.NewMemMapFs()
+	path := testPath("project", "data", "glossary.yaml")
+	require.NoError(t, writeTestFile(fs, path, `do_not_translate:
+  - GoCreator
+terms:
+  fr:
+    pipeline: chaîne de traitement
+`))
+
+	glossary, err := LoadGlossary(fs, path)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"GoCreator"}, glossary.DoNotTranslate)
+	assert.Equal(t, "chaîne de traitement", glossary.Terms["fr"]["pipeline"])
+}
+
+func TestGlossaryRulesFor_MatchesWholeWords(t *testing.T) {
+	glossary := testGlossary()
+
+	rules := glossary.rulesFor("GoCreator builds a Pipeline for gocreators", "fr")
+	assert.Equal(t, []string{"GoCreator"}, rules.keep)
+	assert.Equal(t, [][2]string{{"pipeline", "chaîne de traitement"}}, rules.terms)
+
+	assert.True(t, glossary.rulesFor("Kubernetes clusters", "de").terms == nil)
+	assert.True(t, glossary.rulesFor("nothing to protect", "fr").empty())
+	assert.True(t, (*Glossary)(nil).rulesFor("GoCreator", "fr").empty())
+}
+
+func TestGlossaryRules_Missing(t *testing.T) {
+	rules := testGlossary().rulesFor("GoCreator runs the pipeline", "fr")
+
+	assert.Empty(t, rules.missing("GoCreator exécute la chaîne de traitement"))
+	assert.Equal(t, []string{"GoCreator", "chaîne de traitement"}, rules.missing("Le créateur exécute le pipeline"))
+}
+
+func TestTranslationService_GlossaryPromptAndCacheKey(t *testing.T) {
+	mockClient := new(mocks.MockOpenAIClient)
+	service := NewTranslationService(mockClient, &mockLogger{})
+
+	plainKey := service.getCacheKey("GoCreator runs the pipeline", "fr")
+	service.SetGlossary(testGlossary(), false)
+	glossaryKey := service.getCacheKey("GoCreator runs the pipeline", "fr")
+
+	assert.NotEqual(t, plainKey, glossaryKey)
+	assert.Equal(t, NewTranslationService(mockClient, &mockLogger{}).getCacheKey("Hello", "fr"), service.getCacheKey("Hello", "fr"))
+
+	edited := testGlossary()
+	edited.Terms["fr"]["pipeline"] = "pipeline"
+	service.SetGlossary(edited, false)
+	assert.NotEqual(t, glossaryKey, service.getCacheKey("GoCreator runs the pipeline", "fr"))
+
+	mockClient.On("ChatCompletion", mock.Anything, mock.MatchedBy(func(messages []openai.ChatCompletionMessageParamUnion) bool {
+		prompt := messages[0].OfUser.Content.OfString.Value
+		return assert.Contains(t, prompt, "Keep these terms exactly as written, without translating them: 'GoCreator'.") &&
+			assert.Contains(t, prompt, "Always translate 'pipeline' as 'pipeline'.")
+	})).Return("GoCreator exécute le pipeline", nil).Once()
+
+	translated, err := service.Translate(context.Background(), "GoCreator runs the pipeline", "fr")
+	require.NoError(t, err)
+	assert.Equal(t, "GoCreator exécute le pipeline", translated)
+	mockClient.AssertExpectations(t)
+}
+
+func TestTranslationService_StrictGlossaryRejectsMissingTerms(t *testing.T) {
+	mockClient := new(mocks.MockOpenAIClient)
+	service := NewTranslationService(mockClient, &mockLogger{})
+	service.SetGlossary(testGlossary(), true)
+
+	mockClient.On("ChatCompletion", mock.Anything, mock.Anything).Return("Le créateur de Go", nil).Once()
+
+	_, err := service.Translate(context.Background(), "GoCreator", "fr")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "missing glossary terms: GoCreator")
+
+	_, cached := service.getFromMemoryCache(service.getCacheKey("GoCreator", "fr"))
+	assert.False(t, cached)
+	mockClient.AssertExpectations(t)
+}
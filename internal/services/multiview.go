@@ -1,10 +1,10 @@
 package services
 
 import (
-	"bytes"
 	"context"
 	"fmt"
-	"os/exec"
+	"math"
+	"sort"
 	"strconv"
 	"strings"
 
@@ -16,76 +16,121 @@ import (
 
 // MultiViewService handles multi-view video layouts
 type MultiViewService struct {
-	fs     afero.Fs
-	logger interfaces.Logger
+	fs              afero.Fs
+	logger          interfaces.Logger
+	commandExecutor interfaces.CommandExecutor
+	encoding        config.EncodingConfig
+	effectService   *EffectService
+	overlayService  *OverlayService
 }
 
 // NewMultiViewService creates a new multi-view service
 func NewMultiViewService(fs afero.Fs, logger interfaces.Logger) *MultiViewService {
+	return NewMultiViewServiceWithExecutor(fs, logger, nil)
+}
+
+// NewMultiViewServiceWithExecutor creates a new multi-view service with an injected command executor.
+func NewMultiViewServiceWithExecutor(fs afero.Fs, logger interfaces.Logger, executor interfaces.CommandExecutor) *MultiViewService {
+	if executor == nil {
+		executor = newCommandExecutor()
+	}
+
 	return &MultiViewService{
-		fs:     fs,
-		logger: logger,
+		fs:              fs,
+		logger:          logger,
+		commandExecutor: executor,
+		effectService:   NewEffectServiceWithExecutor(fs, logger, executor),
+		overlayService:  NewOverlayService(),
 	}
 }
 
-// GenerateMultiViewVideo generates a video with multi-view layout
+// SetEncoding sets the encoding settings used for composed segments
+func (s *MultiViewService) SetEncoding(encoding config.EncodingConfig) {
+	s.encoding = encoding
+}
+
+// GenerateMultiViewVideo composes a multi-view layout on top of a slide's narrated segment. The
+// segment's narration is mixed with the layout sources, and the output lasts duration seconds.
 func (s *MultiViewService) GenerateMultiViewVideo(
 	ctx context.Context,
 	layout config.LayoutConfig,
+	segmentPath string,
+	duration float64,
 	outputPath string,
 	outputWidth, outputHeight int,
 ) error {
+	if layout.Type == "active-speaker" && len(layout.ActiveSpeaker.Shots) == 0 {
+		shots, err := s.planSpeakerShots(ctx, layout, segmentPath, duration)
+		if err != nil {
+			return fmt.Errorf("failed to plan active speaker shots: %w", err)
+		}
+		layout.ActiveSpeaker.Shots = shots
+		s.logger.Debug("Active speaker timeline", "output", outputPath, "shots", shots)
+
+		if layout.ActiveSpeaker.DebugTimeline {
+			if err := s.writeSpeakerTimeline(outputPath, shots); err != nil {
+				s.logger.Warn("Failed to write speaker timeline", "error", err)
+			}
+		}
+	}
+
+	args, err := s.buildMultiViewArgs(layout, segmentPath, duration, outputPath, outputWidth, outputHeight)
+	if err != nil {
+		return err
+	}
+
+	s.logger.Debug("Generating multi-view video", "command", formatCommand("ffmpeg", args...))
+
+	discardPartialOutput(s.fs, outputPath)
+	result, err := s.commandExecutor.Run(ctx, "ffmpeg", args...)
+	if err != nil {
+		discardPartialOutput(s.fs, outputPath)
+		return fmt.Errorf("ffmpeg error: %w, stderr: %s", err, string(result.Stderr))
+	}
+
+	s.logger.Info("Multi-view video generated", "output", outputPath)
+	return nil
+}
+
+// buildMultiViewArgs builds the ffmpeg arguments for a layout. The narrated segment is the last
+// input, so layout sources keep their input indices.
+func (s *MultiViewService) buildMultiViewArgs(
+	layout config.LayoutConfig,
+	segmentPath string,
+	duration float64,
+	outputPath string,
+	outputWidth, outputHeight int,
+) ([]string, error) {
 	// Build filter complex
 	filterComplex, err := s.BuildFilterComplex(layout, outputWidth, outputHeight)
 	if err != nil {
-		return fmt.Errorf("failed to build filter: %w", err)
+		return nil, fmt.Errorf("failed to build filter: %w", err)
 	}
 
 	// Build FFmpeg command
 	args := []string{"-y"}
 
 	// Add input files
-	inputs := s.getInputFiles(layout)
-	for _, input := range inputs {
+	inputs := s.resolveInputFiles(layout, segmentPath)
+	offsets := s.resolveSyncOffsets(layout)
+	for i, input := range inputs {
+		if offsets[i] > 0 {
+			args = append(args, "-ss", fmt.Sprintf("%.3f", offsets[i]))
+		}
 		args = append(args, "-i", input)
 	}
+	args = append(args, "-i", segmentPath)
 
-	// Add filter complex
-	args = append(args, "-filter_complex", filterComplex, "-map", "[out]")
-
-	// Add audio (mix all sources or use first)
-	audioFilter := s.buildAudioFilter(len(inputs))
-	if audioFilter != "" {
-		args = append(args, "-filter_complex", audioFilter, "-map", "[aout]")
-	} else {
-		args = append(args, "-map", "0:a?") // Optional audio
-	}
+	// Hold the last frame when the sources are shorter than the narration
+	filterComplex += fmt.Sprintf(";[out]setsar=1,tpad=stop_mode=clone:stop_duration=%.2f[vout]", duration)
+	filterComplex += ";" + s.buildAudioFilter(layout)
+	args = append(args, "-filter_complex", filterComplex, "-map", "[vout]", "-map", "[aout]")
 
 	// Encoding settings
-	args = append(args,
-		"-c:v", "libx264",
-		"-preset", "medium",
-		"-crf", "23",
-		"-pix_fmt", "yuv420p",
-		"-c:a", "aac",
-		"-b:a", "192k",
-		"-shortest", // End when shortest input ends
-		outputPath,
-	)
-
-	// Execute FFmpeg
-	cmd := exec.CommandContext(ctx, "ffmpeg", args...)
-	var stderr bytes.Buffer
-	cmd.Stderr = &stderr
-
-	s.logger.Debug("Generating multi-view video", "command", cmd.String())
-
-	if err := cmd.Run(); err != nil {
-		return fmt.Errorf("ffmpeg error: %w, stderr: %s", err, stderr.String())
-	}
+	args = append(args, NewEncodingService(s.encoding).BuildAllArgs()...)
+	args = append(args, "-t", fmt.Sprintf("%.2f", duration), outputPath)
 
-	s.logger.Info("Multi-view video generated", "output", outputPath)
-	return nil
+	return args, nil
 }
 
 // BuildFilterComplex builds FFmpeg filter for multi-view layout
@@ -103,6 +148,8 @@ func (s *MultiViewService) BuildFilterComplex(layout config.LayoutConfig, output
 		return s.buildFocusGallery(layout, outputWidth, outputHeight)
 	case "custom":
 		return s.buildCustom(layout, outputWidth, outputHeight)
+	case "active-speaker":
+		return s.buildActiveSpeaker(layout, outputWidth, outputHeight)
 	default:
 		return "", fmt.Errorf("unknown layout type: %s", layout.Type)
 	}
@@ -116,13 +163,13 @@ func (s *MultiViewService) buildSplitHorizontal(layout config.LayoutConfig, w, h
 	leftWidth := int(float64(w) * leftRatio)
 	rightWidth := w - leftWidth - layout.Gap
 
-	// Build filter complex
+	// Build filter complex; the gap is padded onto the left of the right video
 	filter := fmt.Sprintf(
 		"[0:v]scale=%d:%d:force_original_aspect_ratio=decrease,pad=%d:%d:(ow-iw)/2:(oh-ih)/2[left];"+
-			"[1:v]scale=%d:%d:force_original_aspect_ratio=decrease,pad=%d:%d:(ow-iw)/2:(oh-ih)/2[right];"+
+			"[1:v]scale=%d:%d:force_original_aspect_ratio=decrease,pad=%d:%d:%d+(%d-iw)/2:(oh-ih)/2[right];"+
 			"[left][right]hstack=inputs=2:shortest=1[out]",
 		leftWidth, h, leftWidth, h,
-		rightWidth, h, rightWidth, h,
+		rightWidth, h, rightWidth+layout.Gap, h, layout.Gap, rightWidth,
 	)
 
 	return filter, nil
@@ -137,10 +184,10 @@ func (s *MultiViewService) buildSplitVertical(layout config.LayoutConfig, w, h i
 
 	filter := fmt.Sprintf(
 		"[0:v]scale=%d:%d:force_original_aspect_ratio=decrease,pad=%d:%d:(ow-iw)/2:(oh-ih)/2[top];"+
-			"[1:v]scale=%d:%d:force_original_aspect_ratio=decrease,pad=%d:%d:(ow-iw)/2:(oh-ih)/2[bottom];"+
+			"[1:v]scale=%d:%d:force_original_aspect_ratio=decrease,pad=%d:%d:(ow-iw)/2:%d+(%d-ih)/2[bottom];"+
 			"[top][bottom]vstack=inputs=2:shortest=1[out]",
 		w, topHeight, w, topHeight,
-		w, bottomHeight, w, bottomHeight,
+		w, bottomHeight, w, bottomHeight+layout.Gap, layout.Gap, bottomHeight,
 	)
 
 	return filter, nil
@@ -156,7 +203,9 @@ func (s *MultiViewService) buildPiP(layout config.LayoutConfig, w, h int) (strin
 
 	// Build filter
 	filter := fmt.Sprintf(
-		"[1:v]scale=%d:%d[pip];",
+		"[0:v]scale=%d:%d:force_original_aspect_ratio=decrease,pad=%d:%d:(ow-iw)/2:(oh-ih)/2[main];"+
+			"[1:v]scale=%d:%d[pip];",
+		w, h, w, h,
 		pipWidth, pipHeight,
 	)
 
@@ -170,9 +219,9 @@ func (s *MultiViewService) buildPiP(layout config.LayoutConfig, w, h int) (strin
 			"[pip]drawbox=x=0:y=0:w=%d:h=%d:color=%s:t=%d[pip_bordered];",
 			pipWidth, pipHeight, color, layout.Border.Width,
 		)
-		filter += fmt.Sprintf("[0:v][pip_bordered]overlay=%d:%d:shortest=1[out]", x, y)
+		filter += fmt.Sprintf("[main][pip_bordered]overlay=%d:%d:shortest=1[out]", x, y)
 	} else {
-		filter += fmt.Sprintf("[0:v][pip]overlay=%d:%d:shortest=1[out]", x, y)
+		filter += fmt.Sprintf("[main][pip]overlay=%d:%d:shortest=1[out]", x, y)
 	}
 
 	return filter, nil
@@ -187,7 +236,11 @@ func (s *MultiViewService) buildGrid(layout config.LayoutConfig, w, h int) (stri
 		return "", fmt.Errorf("invalid grid dimensions: %dx%d", rows, cols)
 	}
 
+	// A grid with fewer videos than cells leaves the remaining cells black
 	totalCells := rows * cols
+	if count := len(layout.GridVideos); count > 0 && count < totalCells {
+		totalCells = count
+	}
 	cellWidth := (w - (cols-1)*layout.Gap) / cols
 	cellHeight := (h - (rows-1)*layout.Gap) / rows
 
@@ -200,6 +253,12 @@ func (s *MultiViewService) buildGrid(layout config.LayoutConfig, w, h int) (stri
 		))
 	}
 
+	// A single video needs no stacking
+	if totalCells == 1 {
+		filters = append(filters, fmt.Sprintf("[v0]pad=%d:%d:0:0[out]", w, h))
+		return strings.Join(filters, ";"), nil
+	}
+
 	// Build grid layout string
 	var layoutStr string
 	for i := 0; i < totalCells; i++ {
@@ -219,12 +278,14 @@ func (s *MultiViewService) buildGrid(layout config.LayoutConfig, w, h int) (stri
 		inputs += fmt.Sprintf("[v%d]", i)
 	}
 
+	// Pad to the frame, which rounding and empty cells can leave smaller than the output
 	filter := fmt.Sprintf(
-		"%s;%sxstack=inputs=%d:layout=%s:shortest=1[out]",
+		"%s;%sxstack=inputs=%d:layout=%s:fill=black:shortest=1,pad=%d:%d:0:0[out]",
 		strings.Join(filters, ";"),
 		inputs,
 		totalCells,
 		layoutStr,
+		w, h,
 	)
 
 	return filter, nil
@@ -232,13 +293,64 @@ func (s *MultiViewService) buildGrid(layout config.LayoutConfig, w, h int) (stri
 
 // buildFocusGallery creates Zoom-style layout with main speaker and gallery
 func (s *MultiViewService) buildFocusGallery(layout config.LayoutConfig, w, h int) (string, error) {
-	gallerySize := s.parsePercentage(layout.GallerySize, 20)
 	galleryCount := len(layout.Gallery)
 
 	if galleryCount == 0 {
 		return "", fmt.Errorf("focus-gallery requires at least one gallery video")
 	}
 
+	main, gallery := s.focusGalleryGeometry(layout, w, h)
+	mainX, mainY, mainWidth, mainHeight := main.x, main.y, main.width, main.height
+	galleryX, galleryY, galleryWidth, galleryHeight := gallery.x, gallery.y, gallery.width, gallery.height
+
+	// Gallery videos are stacked along the gallery strip
+	stack := "vstack"
+	galleryItemWidth, galleryItemHeight := galleryWidth, galleryHeight/galleryCount
+	if layout.GalleryPosition == "top" || layout.GalleryPosition == "bottom" {
+		stack = "hstack"
+		galleryItemWidth, galleryItemHeight = galleryWidth/galleryCount, galleryHeight
+	}
+
+	// Scale main video and place it on the full frame
+	filter := fmt.Sprintf(
+		"[0:v]scale=%d:%d:force_original_aspect_ratio=decrease,pad=%d:%d:(ow-iw)/2:(oh-ih)/2,pad=%d:%d:%d:%d[main];",
+		mainWidth, mainHeight, mainWidth, mainHeight, w, h, mainX, mainY,
+	)
+
+	// Scale gallery videos
+	for i := 0; i < galleryCount; i++ {
+		filter += fmt.Sprintf(
+			"[%d:v]scale=%d:%d:force_original_aspect_ratio=decrease,pad=%d:%d:(ow-iw)/2:(oh-ih)/2[g%d];",
+			i+1, galleryItemWidth, galleryItemHeight, galleryItemWidth, galleryItemHeight, i,
+		)
+	}
+
+	// Stack gallery videos
+	galleryLabel := "[g0]"
+	if galleryCount > 1 {
+		galleryInputs := ""
+		for i := 0; i < galleryCount; i++ {
+			galleryInputs += fmt.Sprintf("[g%d]", i)
+		}
+		filter += fmt.Sprintf("%s%s=inputs=%d:shortest=1[gallery];", galleryInputs, stack, galleryCount)
+		galleryLabel = "[gallery]"
+	}
+
+	// Combine main and gallery
+	filter += fmt.Sprintf("[main]%soverlay=%d:%d:shortest=1[out]", galleryLabel, galleryX, galleryY)
+
+	return filter, nil
+}
+
+// multiViewRect is an area of the output frame.
+type multiViewRect struct {
+	x, y, width, height int
+}
+
+// focusGalleryGeometry splits the frame into the main area and the gallery strip.
+func (s *MultiViewService) focusGalleryGeometry(layout config.LayoutConfig, w, h int) (multiViewRect, multiViewRect) {
+	gallerySize := s.parsePercentage(layout.GallerySize, 20)
+
 	var mainWidth, mainHeight, galleryWidth, galleryHeight int
 	var mainX, mainY, galleryX, galleryY int
 
@@ -277,72 +389,74 @@ func (s *MultiViewService) buildFocusGallery(layout config.LayoutConfig, w, h in
 		galleryX, galleryY = mainWidth, 0
 	}
 
-	galleryItemHeight := galleryHeight / galleryCount
-
-	// Scale main video
-	filter := fmt.Sprintf(
-		"[0:v]scale=%d:%d:force_original_aspect_ratio=decrease,pad=%d:%d:(ow-iw)/2:(oh-ih)/2[main];",
-		mainWidth, mainHeight, mainWidth, mainHeight,
-	)
-
-	// Scale gallery videos
-	for i := 0; i < galleryCount; i++ {
-		filter += fmt.Sprintf(
-			"[%d:v]scale=%d:%d:force_original_aspect_ratio=decrease,pad=%d:%d:(ow-iw)/2:(oh-ih)/2[g%d];",
-			i+1, galleryWidth, galleryItemHeight, galleryWidth, galleryItemHeight, i,
-		)
-	}
-
-	// Stack gallery videos
-	galleryInputs := ""
-	for i := 0; i < galleryCount; i++ {
-		galleryInputs += fmt.Sprintf("[g%d]", i)
-	}
-	filter += fmt.Sprintf("%svstack=inputs=%d:shortest=1[gallery];", galleryInputs, galleryCount)
-
-	// Combine main and gallery
-	filter += fmt.Sprintf("[main][gallery]overlay=%d:%d:shortest=1[out]", galleryX-mainX, galleryY-mainY)
-
-	return filter, nil
+	return multiViewRect{mainX, mainY, mainWidth, mainHeight}, multiViewRect{galleryX, galleryY, galleryWidth, galleryHeight}
 }
 
-// buildCustom creates custom positioned layout
+// buildCustom creates custom positioned layout. Videos are drawn by z-index onto a black canvas
+// that takes its size and length from the narrated segment.
 func (s *MultiViewService) buildCustom(layout config.LayoutConfig, w, h int) (string, error) {
 	if len(layout.CustomVideos) == 0 {
 		return "", fmt.Errorf("custom layout requires at least one video")
 	}
 
-	// Sort by z-index
-	videos := make([]config.CustomVideoConfig, len(layout.CustomVideos))
-	copy(videos, layout.CustomVideos)
+	// Input indices follow config order; drawing order follows z-index
+	order := make([]int, len(layout.CustomVideos))
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(a, b int) bool {
+		return layout.CustomVideos[order[a]].ZIndex < layout.CustomVideos[order[b]].ZIndex
+	})
 
 	// Build filter for each video
-	var filters []string
-	for i, video := range videos {
-		filter := fmt.Sprintf(
-			"[%d:v]scale=%d:%d[cv%d]",
-			i, video.Size[0], video.Size[1], i,
-		)
-		filters = append(filters, filter)
+	filters := []string{fmt.Sprintf("[%d:v]scale=%d:%d,drawbox=c=black:t=fill[canvas]", len(layout.CustomVideos), w, h)}
+	for i, video := range layout.CustomVideos {
+		chain := []string{fmt.Sprintf("scale=%d:%d", video.Size[0], video.Size[1])}
+		effects, err := s.buildTileEffects(video)
+		if err != nil {
+			return "", err
+		}
+		chain = append(chain, effects...)
+		if opacity := video.ResolveOpacity(); opacity < 1 {
+			chain = append(chain, fmt.Sprintf("format=yuva420p,colorchannelmixer=aa=%.2f", opacity))
+		}
+		filters = append(filters, fmt.Sprintf("[%d:v]%s[cv%d]", i, strings.Join(chain, ","), i))
 	}
 
 	// Overlay videos in order
-	overlayFilter := "[0:v]"
-	for i, video := range videos {
-		if i == 0 {
-			overlayFilter = "[cv0]"
-		} else {
-			x, y := video.Position[0], video.Position[1]
-			overlayFilter = fmt.Sprintf("%s[cv%d]overlay=%d:%d", overlayFilter, i, x, y)
-			if i < len(videos)-1 {
-				overlayFilter = fmt.Sprintf("%s[tmp%d];[tmp%d]", overlayFilter, i, i)
-			}
+	current := "[canvas]"
+	for n, i := range order {
+		video := layout.CustomVideos[i]
+		output := fmt.Sprintf("[tmp%d]", n)
+		if n == len(order)-1 {
+			output = "[out]"
 		}
+		filters = append(filters, fmt.Sprintf("%s[cv%d]overlay=%d:%d%s", current, i, video.Position[0], video.Position[1], output))
+		current = output
 	}
-	overlayFilter += "[out]"
 
-	filter := strings.Join(filters, ";") + ";" + overlayFilter
-	return filter, nil
+	return strings.Join(filters, ";"), nil
+}
+
+// buildTileEffects returns the filters for a custom video's effects. Only effects that work frame by
+// frame on a tile are supported.
+func (s *MultiViewService) buildTileEffects(video config.CustomVideoConfig) ([]string, error) {
+	filters := make([]string, 0, len(video.Effects))
+	for _, effect := range video.Effects {
+		switch strings.ToLower(strings.TrimSpace(effect.Type)) {
+		case "color-grade":
+			filters = append(filters, s.effectService.BuildColorGradeFilter(effect))
+		case "vignette":
+			filters = append(filters, s.effectService.BuildVignetteFilter(effect))
+		case "film-grain":
+			filters = append(filters, s.effectService.BuildFilmGrainFilter(effect))
+		case "text-overlay":
+			filters = append(filters, s.overlayService.BuildTextOverlayFilter(effect))
+		default:
+			return nil, fmt.Errorf("unsupported effect type %q for multi-view video %s", effect.Type, video.Source)
+		}
+	}
+	return compactFilterParts(filters), nil
 }
 
 // Helper functions
@@ -365,23 +479,72 @@ func (s *MultiViewService) getInputFiles(layout config.LayoutConfig) []string {
 		for _, v := range layout.CustomVideos {
 			inputs = append(inputs, v.Source)
 		}
+	case "active-speaker":
+		inputs = layout.ActiveSpeaker.Speakers
 	}
 
 	return inputs
 }
 
-func (s *MultiViewService) buildAudioFilter(inputCount int) string {
-	if inputCount <= 1 {
-		return ""
+// resolveInputFiles returns the layout sources, with the slide source replaced by the narrated segment.
+func (s *MultiViewService) resolveInputFiles(layout config.LayoutConfig, segmentPath string) []string {
+	inputs := s.getInputFiles(layout)
+	resolved := make([]string, len(inputs))
+	for i, input := range inputs {
+		if input == config.MultiViewSlideSource {
+			input = segmentPath
+		}
+		resolved[i] = input
 	}
+	return resolved
+}
 
-	// Mix all audio sources
-	var inputs []string
-	for i := 0; i < inputCount; i++ {
-		inputs = append(inputs, fmt.Sprintf("[%d:a]", i))
+// resolveSyncOffsets returns how many seconds to skip at the start of each source. Negative offsets
+// shift every other source instead, so sources keep their relative alignment. The slide source
+// always starts with the narration.
+func (s *MultiViewService) resolveSyncOffsets(layout config.LayoutConfig) []float64 {
+	sources := s.getInputFiles(layout)
+	offsets := make([]float64, len(sources))
+	earliest := 0.0
+	for i, source := range sources {
+		if source == config.MultiViewSlideSource {
+			continue
+		}
+		offsets[i] = layout.Sync.SourceOffset(i)
+		earliest = math.Min(earliest, offsets[i])
+	}
+	for i, source := range sources {
+		if source != config.MultiViewSlideSource {
+			offsets[i] -= earliest
+		}
 	}
+	return offsets
+}
 
-	return fmt.Sprintf("%samix=inputs=%d:duration=shortest[aout]", strings.Join(inputs, ""), inputCount)
+// buildAudioFilter mixes the narration, which follows the layout sources as the last input, with the
+// sources' audio at their configured volumes. The mix lasts as long as the narration. Muted sources
+// and the slide source, whose audio is the narration itself, are left out.
+func (s *MultiViewService) buildAudioFilter(layout config.LayoutConfig) string {
+	sources := s.getInputFiles(layout)
+	filters := []string{fmt.Sprintf("[%d:a]volume=%.2f", len(sources), layout.Audio.ResolveNarrationVolume())}
+	mixInputs := []string{"[narration]"}
+	for i, source := range sources {
+		volume := layout.Audio.SourceVolume(i)
+		if volume == 0 || source == config.MultiViewSlideSource {
+			continue
+		}
+		filters = append(filters, fmt.Sprintf("[%d:a]volume=%.2f[src%d]", i, volume, i))
+		mixInputs = append(mixInputs, fmt.Sprintf("[src%d]", i))
+	}
+
+	if len(mixInputs) == 1 {
+		return filters[0] + "[aout]"
+	}
+
+	filters[0] += "[narration]"
+	filters = append(filters, fmt.Sprintf("%samix=inputs=%d:duration=first:dropout_transition=0:normalize=0[aout]",
+		strings.Join(mixInputs, ""), len(mixInputs)))
+	return strings.Join(filters, ";")
 }
 
 func (s *MultiViewService) parseRatio(ratio string) (float64, float64) {
@@ -406,6 +569,28 @@ func (s *MultiViewService) parseRatio(ratio string) (float64, float64) {
 }
 
 func (s *MultiViewService) parseSize(size string, refW, refH int) (int, int) {
+	return parseOverlaySize(size, refW, refH)
+}
+
+func (s *MultiViewService) calculatePosition(position string, offset []int, w, h, pipW, pipH int) (int, int) {
+	return overlayPosition(position, offset, w, h, pipW, pipH)
+}
+
+func (s *MultiViewService) parsePercentage(str string, defaultVal float64) float64 {
+	if str == "" {
+		return defaultVal
+	}
+
+	val, err := strconv.ParseFloat(strings.TrimSuffix(str, "%"), 64)
+	if err != nil {
+		return defaultVal
+	}
+	return val
+}
+
+// parseOverlaySize parses an overlay size given as a percentage of the frame ("20%") or in pixels
+// ("320x180"), defaulting to 20% of the frame.
+func parseOverlaySize(size string, refW, refH int) (int, int) {
 	if size == "" {
 		return refW / 5, refH / 5 // Default 20%
 	}
@@ -432,7 +617,9 @@ func (s *MultiViewService) parseSize(size string, refW, refH int) (int, int) {
 	return refW / 5, refH / 5
 }
 
-func (s *MultiViewService) calculatePosition(position string, offset []int, w, h, pipW, pipH int) (int, int) {
+// overlayPosition returns the top-left corner of a pipW x pipH overlay anchored at a named corner of
+// a w x h frame, inset by offset (10px by default). Picture-in-picture layouts and overlays share it.
+func overlayPosition(position string, offset []int, w, h, pipW, pipH int) (int, int) {
 	offsetX, offsetY := 10, 10
 	if len(offset) >= 2 {
 		offsetX, offsetY = offset[0], offset[1]
@@ -453,15 +640,3 @@ func (s *MultiViewService) calculatePosition(position string, offset []int, w, h
 		return w - pipW - offsetX, h - pipH - offsetY
 	}
 }
-
-func (s *MultiViewService) parsePercentage(str string, defaultVal float64) float64 {
-	if str == "" {
-		return defaultVal
-	}
-
-	val, err := strconv.ParseFloat(strings.TrimSuffix(str, "%"), 64)
-	if err != nil {
-		return defaultVal
-	}
-	return val
-}
@@ -0,0 +1,148 @@
+package services
+
+import (
+	"context"
+	"flag"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"gocreator/internal/config"
+
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var updateGolden = flag.Bool("update", false, "rewrite golden files in testdata")
+
+// goldenMultiViewLayouts has one layout per layout type.
+var goldenMultiViewLayouts = map[string]config.LayoutConfig{
+	"split-horizontal": {
+		Type:   "split-horizontal",
+		Ratio:  "65:35",
+		Gap:    4,
+		Videos: config.VideoSources{Left: "screen.mp4", Right: "webcam.mp4"},
+		Audio:  config.LayoutAudioConfig{SourceVolumes: []float64{0, 0.4}},
+	},
+	"split-vertical": {
+		Type:   "split-vertical",
+		Videos: config.VideoSources{Top: config.MultiViewSlideSource, Bottom: "webcam.mp4"},
+		Sync:   config.SyncConfig{Enabled: true, Offset: 1.5},
+	},
+	"pip": {
+		Type:     "pip",
+		Main:     "main.mp4",
+		Overlay:  "overlay.mp4",
+		Position: "bottom-right",
+		Size:     "25%",
+		Border:   config.BorderConfig{Width: 3, Color: "#00FF00"},
+	},
+	"grid": {
+		Type:       "grid",
+		Rows:       2,
+		Cols:       2,
+		Gap:        6,
+		GridVideos: []string{"person1.mp4", "person2.mp4", "person3.mp4", "person4.mp4"},
+		Sync:       config.SyncConfig{Enabled: true, Offsets: []float64{0.5, 0, -0.25, 1}},
+	},
+	"focus-gallery": {
+		Type:            "focus-gallery",
+		Focus:           "speaker.mp4",
+		Gallery:         []string{"guest1.mp4", "guest2.mp4", "guest3.mp4"},
+		GalleryPosition: "right",
+		GallerySize:     "25%",
+		Audio:           config.LayoutAudioConfig{NarrationVolume: 0.8},
+	},
+	"active-speaker": {
+		Type: "active-speaker",
+		ActiveSpeaker: config.ActiveSpeakerConfig{
+			Speakers: []string{"host.mp4", "guest.mp4", "caller.mp4"},
+			Shots: []config.SpeakerShot{
+				{Start: 0, End: 3.5, Speaker: 0},
+				{Start: 3.5, End: 6, Speaker: 1},
+				{Start: 6, End: 8.4, Speaker: 0},
+			},
+		},
+	},
+	"active-speaker-focus-gallery": {
+		Type:            "active-speaker",
+		GalleryPosition: "bottom",
+		GallerySize:     "25%",
+		ActiveSpeaker: config.ActiveSpeakerConfig{
+			Speakers: []string{"host.mp4", "guest.mp4"},
+			Mode:     config.ActiveSpeakerModeFocusGallery,
+			Shots: []config.SpeakerShot{
+				{Start: 0, End: 4, Speaker: 1},
+				{Start: 4, End: 8.4, Speaker: 0},
+			},
+		},
+	},
+	"custom": {
+		Type: "custom",
+		CustomVideos: []config.CustomVideoConfig{
+			{Source: "logo.mp4", Position: [2]int{1700, 40}, Size: [2]int{180, 100}, ZIndex: 3, Opacity: 0.6},
+			{Source: "camera.mp4", Position: [2]int{0, 0}, Size: [2]int{1280, 1080}, Effects: []config.EffectConfig{
+				{Type: "color-grade", Config: config.EffectDetails{Saturation: 1.2}},
+			}},
+			{Source: config.MultiViewSlideSource, Position: [2]int{1280, 270}, Size: [2]int{640, 360}, ZIndex: 1},
+		},
+	},
+}
+
+// formatGoldenArgs lists one argument per line, with one filter chain per line.
+func formatGoldenArgs(args []string) string {
+	var out strings.Builder
+	for _, arg := range args {
+		out.WriteString(strings.ReplaceAll(arg, ";", ";\n    "))
+		out.WriteString("\n")
+	}
+	return out.String()
+}
+
+func TestMultiViewService_buildMultiViewArgs_Golden(t *testing.T) {
+	service := NewMultiViewService(nil, nil)
+
+	for name, layout := range goldenMultiViewLayouts {
+		t.Run(name, func(t *testing.T) {
+			args, err := service.buildMultiViewArgs(layout, "video_3.mp4", 8.4, "multiview_3.mp4", 1920, 1080)
+			require.NoError(t, err)
+			got := formatGoldenArgs(args)
+
+			goldenPath := filepath.Join("testdata", "multiview", name+".golden")
+			fs := afero.NewOsFs()
+			if *updateGolden {
+				require.NoError(t, fs.MkdirAll(filepath.Dir(goldenPath), 0755))
+				require.NoError(t, afero.WriteFile(fs, goldenPath, []byte(got), 0644))
+			}
+			want, err := afero.ReadFile(fs, goldenPath)
+			require.NoError(t, err, "run go test -run Golden -update to create the golden file")
+			assert.Equal(t, string(want), got)
+		})
+	}
+}
+
+func TestMultiViewService_GenerateMultiViewVideo_UsesExecutorAndEncoding(t *testing.T) {
+	fs := afero.NewMemMapFs()
+	outputPath := testPath("out", ".temp", "multiview_0.mp4")
+	executor := newFakeCommandExecutor(expectedCommand{
+		Name: "ffmpeg",
+		Contains: []string{
+			"-i screen.mp4 -i webcam.mp4 -i video_0.mp4",
+			"-c:v libx265 -preset slow -crf 28 -pix_fmt yuv420p -movflags +faststart -c:a aac -b:a 128k",
+			"-t 6.00 " + outputPath,
+		},
+		Run: func(_ string, _ []string) {
+			_ = writeTestFile(fs, outputPath, "composed")
+		},
+	})
+
+	service := NewMultiViewServiceWithExecutor(fs, &mockLogger{}, executor)
+	service.SetEncoding(config.EncodingConfig{
+		Video: config.VideoEncodingConfig{Codec: "libx265", Preset: "slow", CRF: 28},
+		Audio: config.AudioEncodingConfig{Bitrate: "128k"},
+	})
+
+	require.NoError(t, service.GenerateMultiViewVideo(context.Background(), goldenMultiViewLayouts["split-horizontal"], "video_0.mp4", 6, outputPath, 1920, 1080))
+	executor.AssertDone(t)
+}
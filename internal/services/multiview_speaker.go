@@ -0,0 +1,248 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"gocreator/internal/config"
+
+	"github.com/spf13/afero"
+)
+
+const (
+	// speakerLevelSampleRate is the sample rate speaker audio is measured at.
+	speakerLevelSampleRate = 8000
+	// speakerSilenceLevel stands in for the level of a window without any sound.
+	speakerSilenceLevel = -120.0
+)
+
+// planSpeakerShots measures every speaker's loudness over the slide and picks who is shown when.
+func (s *MultiViewService) planSpeakerShots(ctx context.Context, layout config.LayoutConfig, segmentPath string, duration float64) ([]config.SpeakerShot, error) {
+	speakers := s.resolveInputFiles(layout, segmentPath)
+	if len(speakers) == 0 {
+		return nil, fmt.Errorf("active-speaker layout requires at least one speaker")
+	}
+
+	offsets := s.resolveSyncOffsets(layout)
+	window := layout.ActiveSpeaker.ResolveWindow()
+	levels := make([][]float64, len(speakers))
+	for i, speaker := range speakers {
+		speakerLevels, err := s.measureSpeechLevels(ctx, speaker, offsets[i], duration, window)
+		if err != nil {
+			return nil, err
+		}
+		levels[i] = speakerLevels
+	}
+
+	return pickSpeakerShots(levels, s.getInputFiles(layout), window, duration, layout.ActiveSpeaker), nil
+}
+
+// measureSpeechLevels returns the RMS level in dB of each window of a source's audio, starting offset
+// seconds into the source.
+func (s *MultiViewService) measureSpeechLevels(ctx context.Context, source string, offset, duration, window float64) ([]float64, error) {
+	args := []string{"-v", "error"}
+	if offset > 0 {
+		args = append(args, "-ss", fmt.Sprintf("%.3f", offset))
+	}
+	args = append(args,
+		"-t", fmt.Sprintf("%.2f", duration),
+		"-i", source,
+		"-vn",
+		"-af", fmt.Sprintf(
+			"aresample=%d,asetnsamples=n=%d:p=0,astats=metadata=1:reset=1,ametadata=print:key=lavfi.astats.Overall.RMS_level:file=-",
+			speakerLevelSampleRate, int(window*speakerLevelSampleRate),
+		),
+		"-f", "null", "-",
+	)
+
+	result, err := s.commandExecutor.Run(ctx, "ffmpeg", args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to measure speech levels of %s: %w, stderr: %s", source, err, string(result.Stderr))
+	}
+	return parseRMSLevels(result.Stdout), nil
+}
+
+// parseRMSLevels reads the RMS levels printed by ametadata, one per measured window.
+func parseRMSLevels(output []byte) []float64 {
+	var levels []float64
+	for _, line := range strings.Split(string(output), "\n") {
+		value, ok := strings.CutPrefix(strings.TrimSpace(line), "lavfi.astats.Overall.RMS_level=")
+		if !ok {
+			continue
+		}
+		level, err := strconv.ParseFloat(value, 64)
+		if err != nil || math.IsInf(level, 0) || math.IsNaN(level) {
+			level = speakerSilenceLevel
+		}
+		levels = append(levels, math.Max(level, speakerSilenceLevel))
+	}
+	return levels
+}
+
+// pickSpeakerShots turns per-window speaker levels into shots. The loudest speaker above the threshold
+// takes over only when they are louder than the current speaker by the hysteresis margin, and not
+// before the current shot has lasted the minimum shot length. Silence keeps the current speaker.
+func pickSpeakerShots(levels [][]float64, sources []string, window, duration float64, cfg config.ActiveSpeakerConfig) []config.SpeakerShot {
+	threshold := cfg.ResolveThreshold()
+	hysteresis := cfg.ResolveHysteresis()
+	minShot := cfg.ResolveMinShot()
+
+	level := func(speaker, index int) float64 {
+		if index < len(levels[speaker]) {
+			return levels[speaker][index]
+		}
+		return speakerSilenceLevel
+	}
+
+	windows := int(math.Ceil(duration / window))
+	current, shotStart := -1, 0.0
+	var shots []config.SpeakerShot
+	for index := 0; index < windows; index++ {
+		loudest := 0
+		for speaker := range levels {
+			if level(speaker, index) > level(loudest, index) {
+				loudest = speaker
+			}
+		}
+		if level(loudest, index) < threshold || loudest == current {
+			continue
+		}
+
+		at := float64(index) * window
+		switch {
+		case current < 0:
+			current = loudest
+		case level(loudest, index) >= level(current, index)+hysteresis && at-shotStart >= minShot && duration-at >= minShot:
+			shots = append(shots, config.SpeakerShot{Start: shotStart, End: at, Speaker: current, Source: sources[current]})
+			current, shotStart = loudest, at
+		}
+	}
+
+	// A slide without speech stays on the first speaker
+	if current < 0 {
+		current = 0
+	}
+	return append(shots, config.SpeakerShot{Start: shotStart, End: duration, Speaker: current, Source: sources[current]})
+}
+
+// writeSpeakerTimeline saves the switch timeline as JSON next to the composed segment.
+func (s *MultiViewService) writeSpeakerTimeline(outputPath string, shots []config.SpeakerShot) error {
+	data, err := json.MarshalIndent(shots, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode speaker timeline: %w", err)
+	}
+
+	timelinePath := strings.TrimSuffix(outputPath, filepath.Ext(outputPath)) + ".timeline.json"
+	if err := afero.WriteFile(s.fs, timelinePath, append(data, '\n'), 0644); err != nil {
+		return fmt.Errorf("failed to write speaker timeline: %w", err)
+	}
+	s.logger.Info("Speaker timeline written", "path", timelinePath, "shots", len(shots))
+	return nil
+}
+
+// buildActiveSpeaker shows the speaker of each shot full frame, or in the main area of a focus
+// gallery that keeps every speaker in the strip.
+func (s *MultiViewService) buildActiveSpeaker(layout config.LayoutConfig, w, h int) (string, error) {
+	speakers := layout.ActiveSpeaker.Speakers
+	if len(speakers) == 0 {
+		return "", fmt.Errorf("active-speaker layout requires at least one speaker")
+	}
+
+	// Without a timeline, the first speaker is shown throughout
+	shots := layout.ActiveSpeaker.Shots
+	if len(shots) == 0 {
+		shots = []config.SpeakerShot{{Speaker: 0}}
+	}
+
+	mainArea := multiViewRect{0, 0, w, h}
+	var gallery multiViewRect
+	mode := layout.ActiveSpeaker.ResolveMode()
+	switch mode {
+	case config.ActiveSpeakerModeCut:
+	case config.ActiveSpeakerModeFocusGallery:
+		mainArea, gallery = s.focusGalleryGeometry(layout, w, h)
+	default:
+		return "", fmt.Errorf("unknown active-speaker mode: %s", mode)
+	}
+
+	// Each speaker's shots as one enable expression. A single shot needs none.
+	active := make([]bool, len(speakers))
+	spans := make([][]string, len(speakers))
+	for _, shot := range shots {
+		if shot.Speaker < 0 || shot.Speaker >= len(speakers) {
+			return "", fmt.Errorf("speaker timeline refers to unknown speaker %d", shot.Speaker)
+		}
+		active[shot.Speaker] = true
+		if len(shots) > 1 {
+			spans[shot.Speaker] = append(spans[shot.Speaker], fmt.Sprintf("between(t,%.2f,%.2f)", shot.Start, shot.End))
+		}
+	}
+
+	type overlayStep struct {
+		input  string
+		x, y   int
+		enable string
+	}
+
+	// The canvas takes its size and length from the narrated segment
+	filters := []string{fmt.Sprintf("[%d:v]scale=%d:%d,drawbox=c=black:t=fill[canvas]", len(speakers), w, h)}
+	var galleryOverlays, mainOverlays []overlayStep
+	for i := range speakers {
+		mainInput := fmt.Sprintf("[%d:v]", i)
+		if mode == config.ActiveSpeakerModeFocusGallery {
+			thumbInput := mainInput
+			if active[i] {
+				filters = append(filters, fmt.Sprintf("[%d:v]split=2[main%d][thumb%d]", i, i, i))
+				mainInput, thumbInput = fmt.Sprintf("[main%d]", i), fmt.Sprintf("[thumb%d]", i)
+			}
+
+			itemWidth, itemHeight := gallery.width, gallery.height/len(speakers)
+			x, y := gallery.x, gallery.y+i*itemHeight
+			if layout.GalleryPosition == "top" || layout.GalleryPosition == "bottom" {
+				itemWidth, itemHeight = gallery.width/len(speakers), gallery.height
+				x, y = gallery.x+i*itemWidth, gallery.y
+			}
+			filters = append(filters, fmt.Sprintf(
+				"%sscale=%d:%d:force_original_aspect_ratio=decrease,pad=%d:%d:(ow-iw)/2:(oh-ih)/2[g%d]",
+				thumbInput, itemWidth, itemHeight, itemWidth, itemHeight, i,
+			))
+			galleryOverlays = append(galleryOverlays, overlayStep{input: fmt.Sprintf("[g%d]", i), x: x, y: y})
+		}
+
+		if active[i] {
+			filters = append(filters, fmt.Sprintf(
+				"%sscale=%d:%d:force_original_aspect_ratio=decrease,pad=%d:%d:(ow-iw)/2:(oh-ih)/2[sp%d]",
+				mainInput, mainArea.width, mainArea.height, mainArea.width, mainArea.height, i,
+			))
+			mainOverlays = append(mainOverlays, overlayStep{
+				input:  fmt.Sprintf("[sp%d]", i),
+				x:      mainArea.x,
+				y:      mainArea.y,
+				enable: strings.Join(spans[i], "+"),
+			})
+		}
+	}
+
+	// Stack the gallery, then the active speakers, onto the canvas
+	current := "[canvas]"
+	steps := append(galleryOverlays, mainOverlays...)
+	for n, step := range steps {
+		output := fmt.Sprintf("[as%d]", n)
+		if n == len(steps)-1 {
+			output = "[out]"
+		}
+		enable := ""
+		if step.enable != "" {
+			enable = fmt.Sprintf(":enable='%s'", step.enable)
+		}
+		filters = append(filters, fmt.Sprintf("%s%soverlay=%d:%d%s%s", current, step.input, step.x, step.y, enable, output))
+		current = output
+	}
+
+	return strings.Join(filters, ";"), nil
+}
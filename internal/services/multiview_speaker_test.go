@@ -0,0 +1,146 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"testing"
+
+	"gocreator/internal/config"
+
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// speakerLevelOutput prints levels the way ametadata does.
+func speakerLevelOutput(levels ...string) string {
+	var out strings.Builder
+	for i, level := range levels {
+		fmt.Fprintf(&out, "frame:%d    pts:%d    pts_time:%d\n", i, i*8000, i)
+		fmt.Fprintf(&out, "lavfi.astats.Overall.RMS_level=%s\n", level)
+	}
+	return out.String()
+}
+
+func TestParseRMSLevels(t *testing.T) {
+	levels := parseRMSLevels([]byte(speakerLevelOutput("-20.5", "-inf", "-130")))
+	assert.Equal(t, []float64{-20.5, speakerSilenceLevel, speakerSilenceLevel}, levels)
+}
+
+func TestPickSpeakerShots(t *testing.T) {
+	sources := []string{"host.mp4", "guest.mp4"}
+	cfg := config.ActiveSpeakerConfig{MinShot: 2}
+
+	tests := []struct {
+		name   string
+		levels [][]float64
+		want   []config.SpeakerShot
+	}{
+		{
+			name: "switches to a clearly louder speaker",
+			levels: [][]float64{
+				{-20, -20, -20, -20, -40, -40, -40, -40, -40, -40},
+				{-50, -50, -50, -50, -22, -22, -22, -22, -22, -22},
+			},
+			want: []config.SpeakerShot{
+				{Start: 0, End: 2, Speaker: 0, Source: "host.mp4"},
+				{Start: 2, End: 5, Speaker: 1, Source: "guest.mp4"},
+			},
+		},
+		{
+			name: "hysteresis ignores a slightly louder speaker",
+			levels: [][]float64{
+				{-20, -20, -20, -20, -24, -24, -24, -24, -24, -24},
+				{-50, -50, -50, -50, -21, -21, -21, -21, -21, -21},
+			},
+			want: []config.SpeakerShot{{Start: 0, End: 5, Speaker: 0, Source: "host.mp4"}},
+		},
+		{
+			name: "minimum shot length delays a switch",
+			levels: [][]float64{
+				{-20, -20, -40, -40, -40, -40, -40, -40, -40, -40},
+				{-50, -50, -20, -20, -20, -20, -20, -20, -20, -20},
+			},
+			want: []config.SpeakerShot{
+				{Start: 0, End: 2, Speaker: 0, Source: "host.mp4"},
+				{Start: 2, End: 5, Speaker: 1, Source: "guest.mp4"},
+			},
+		},
+		{
+			name: "silence keeps the current speaker",
+			levels: [][]float64{
+				{-60, -60, -60, -60, -60, -60, -60, -60, -60, -60},
+				{-60, -20, -20, -20, -70, -70, -70, -70, -70, -70},
+			},
+			want: []config.SpeakerShot{{Start: 0, End: 5, Speaker: 1, Source: "guest.mp4"}},
+		},
+		{
+			name: "no switch leaves a short final shot",
+			levels: [][]float64{
+				{-20, -20, -20, -20, -20, -20, -20, -40, -40, -40},
+				{-50, -50, -50, -50, -50, -50, -50, -20, -20, -20},
+			},
+			want: []config.SpeakerShot{{Start: 0, End: 5, Speaker: 0, Source: "host.mp4"}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, pickSpeakerShots(tt.levels, sources, 0.5, 5, cfg))
+		})
+	}
+}
+
+func TestMultiViewService_GenerateMultiViewVideo_ActiveSpeaker(t *testing.T) {
+	fs := afero.NewMemMapFs()
+	host := testPath("media", "host.mp4")
+	guest := testPath("media", "guest.mp4")
+	segment := testPath("out", ".temp", "video_2.mp4")
+	outputPath := testPath("out", ".temp", "multiview_2.mp4")
+
+	executor := newFakeCommandExecutor(
+		expectedCommand{
+			Name:     "ffmpeg",
+			Contains: []string{"-t 4.00 -i " + host, "asetnsamples=n=8000:p=0,astats=metadata=1:reset=1", "-f null -"},
+			Result:   newCommandResult(speakerLevelOutput("-18", "-18", "-45", "-45"), ""),
+		},
+		expectedCommand{
+			Name:     "ffmpeg",
+			Contains: []string{"-ss 0.500 -t 4.00 -i " + guest},
+			Result:   newCommandResult(speakerLevelOutput("-60", "-60", "-19", "-19"), ""),
+		},
+		expectedCommand{
+			Name: "ffmpeg",
+			Contains: []string{
+				"[canvas][sp0]overlay=0:0:enable='between(t,0.00,2.00)'[as0]",
+				"[as0][sp1]overlay=0:0:enable='between(t,2.00,4.00)'[out]",
+				"-t 4.00 " + outputPath,
+			},
+		},
+	)
+
+	layout := config.LayoutConfig{
+		Type: "active-speaker",
+		Sync: config.SyncConfig{Enabled: true, Offsets: []float64{0, 0.5}},
+		ActiveSpeaker: config.ActiveSpeakerConfig{
+			Speakers:      []string{host, guest},
+			Window:        1,
+			MinShot:       1,
+			DebugTimeline: true,
+		},
+	}
+	service := NewMultiViewServiceWithExecutor(fs, &mockLogger{}, executor)
+	require.NoError(t, service.GenerateMultiViewVideo(context.Background(), layout, segment, 4, outputPath, 1920, 1080))
+	executor.AssertDone(t)
+
+	data, err := afero.ReadFile(fs, testPath("out", ".temp", "multiview_2.timeline.json"))
+	require.NoError(t, err)
+	var shots []config.SpeakerShot
+	require.NoError(t, json.Unmarshal(data, &shots))
+	assert.Equal(t, []config.SpeakerShot{
+		{Start: 0, End: 2, Speaker: 0, Source: host},
+		{Start: 2, End: 4, Speaker: 1, Source: guest},
+	}, shots)
+}
@@ -0,0 +1,145 @@
+package services
+
+import (
+	"context"
+	"encoding/binary"
+	"fmt"
+	"math"
+	"sync"
+
+	"gocreator/internal/config"
+)
+
+const (
+	// syncSampleRate is the sample rate source audio is decoded at for sync detection.
+	syncSampleRate = 8000
+	// syncEnvelopeRate is how many loudness values per second are compared, which sets the precision
+	// of detected offsets.
+	syncEnvelopeRate = 100
+	// syncMinOverlap is the least audio, in seconds, two sources must share for an offset to count.
+	syncMinOverlap = 2.0
+)
+
+// syncedLayout holds a layout whose automatic sync offsets are detected at most once.
+type syncedLayout struct {
+	once   sync.Once
+	layout config.LayoutConfig
+}
+
+// syncLayout returns the layout with detected sync offsets when sync.auto is set. When detection
+// fails, the configured offsets are kept.
+func (s *MultiViewService) syncLayout(ctx context.Context, synced *syncedLayout) config.LayoutConfig {
+	synced.once.Do(func() {
+		if !synced.layout.Sync.Enabled || !synced.layout.Sync.Auto {
+			return
+		}
+		offsets, err := s.detectSyncOffsets(ctx, synced.layout)
+		if err != nil {
+			s.logger.Warn("Failed to detect multi-view sync offsets, using configured offsets", "type", synced.layout.Type, "error", err)
+			return
+		}
+		synced.layout.Sync.Offsets = offsets
+		s.logger.Info("Detected multi-view sync offsets", "type", synced.layout.Type, "offsets", offsets)
+	})
+	return synced.layout
+}
+
+// detectSyncOffsets measures how far each source lags behind the first one by cross-correlating their
+// loudness over time. Detected offsets are added to the configured sync.offsets.
+func (s *MultiViewService) detectSyncOffsets(ctx context.Context, layout config.LayoutConfig) ([]float64, error) {
+	sources := s.getInputFiles(layout)
+	offsets := make([]float64, len(sources))
+	for i := range offsets {
+		if i < len(layout.Sync.Offsets) {
+			offsets[i] = layout.Sync.Offsets[i]
+		}
+	}
+
+	maxLag := int(layout.Sync.ResolveMaxOffset() * syncEnvelopeRate)
+	var reference []float64
+	for i, source := range sources {
+		if source == config.MultiViewSlideSource {
+			continue
+		}
+		envelope, err := s.loadAudioEnvelope(ctx, source, layout.Sync.ResolveWindow()+layout.Sync.ResolveMaxOffset())
+		if err != nil {
+			return nil, err
+		}
+		if reference == nil {
+			reference = envelope
+			continue
+		}
+		lag, ok := crossCorrelationLag(reference, envelope, maxLag)
+		if !ok {
+			return nil, fmt.Errorf("not enough audio to synchronize %s", source)
+		}
+		offsets[i] += float64(lag) / syncEnvelopeRate
+	}
+
+	return offsets, nil
+}
+
+// loadAudioEnvelope decodes up to window seconds of a source's audio and returns its mean absolute
+// amplitude per envelope frame.
+func (s *MultiViewService) loadAudioEnvelope(ctx context.Context, path string, window float64) ([]float64, error) {
+	result, err := s.commandExecutor.Run(ctx, "ffmpeg", "-v", "error",
+		"-t", fmt.Sprintf("%.2f", window), "-i", path,
+		"-vn", "-ac", "1", "-ar", fmt.Sprintf("%d", syncSampleRate), "-f", "s16le", "-")
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode audio of %s for sync: %w, stderr: %s", path, err, string(result.Stderr))
+	}
+
+	samplesPerFrame := syncSampleRate / syncEnvelopeRate
+	pcm := result.Stdout
+	envelope := make([]float64, 0, len(pcm)/2/samplesPerFrame)
+	for start := 0; start+2*samplesPerFrame <= len(pcm); start += 2 * samplesPerFrame {
+		sum := 0.0
+		for i := 0; i < samplesPerFrame; i++ {
+			sample := int16(binary.LittleEndian.Uint16(pcm[start+2*i:]))
+			sum += math.Abs(float64(sample))
+		}
+		envelope = append(envelope, sum/float64(samplesPerFrame))
+	}
+	return envelope, nil
+}
+
+// crossCorrelationLag returns the shift, within ±maxLag frames, at which source best matches
+// reference: source[i+lag] lines up with reference[i]. It reports false when no shift leaves enough
+// overlapping audio to compare.
+func crossCorrelationLag(reference, source []float64, maxLag int) (int, bool) {
+	minOverlap := int(syncMinOverlap * syncEnvelopeRate)
+	bestLag, bestScore, found := 0, math.Inf(-1), false
+
+	for lag := -maxLag; lag <= maxLag; lag++ {
+		start := max(0, -lag)
+		end := min(len(reference), len(source)-lag)
+		n := end - start
+		if n < minOverlap {
+			continue
+		}
+
+		// Pearson correlation over the overlap, so louder sources do not dominate
+		var sumR, sumS, sumRR, sumSS, sumRS float64
+		for i := start; i < end; i++ {
+			r, s := reference[i], source[i+lag]
+			sumR += r
+			sumS += s
+			sumRR += r * r
+			sumSS += s * s
+			sumRS += r * s
+		}
+		count := float64(n)
+		covariance := sumRS - sumR*sumS/count
+		variance := (sumRR - sumR*sumR/count) * (sumSS - sumS*sumS/count)
+		if variance <= 0 {
+			continue
+		}
+
+		score := covariance / math.Sqrt(variance)
+		if score > bestScore {
+			bestLag, bestScore, found = lag, score, true
+		}
+	}
+
+	return bestLag, found
+}
@@ -0,0 +1,95 @@
+package services
+
+import (
+	"context"
+	"encoding/binary"
+	"math/rand"
+	"testing"
+
+	"gocreator/internal/config"
+
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// syncTestEnvelope returns seconds of loudness values that vary like speech.
+func syncTestEnvelope(seed int64, seconds float64) []float64 {
+	random := rand.New(rand.NewSource(seed))
+	envelope := make([]float64, int(seconds*syncEnvelopeRate))
+	for i := range envelope {
+		envelope[i] = float64(random.Intn(8000))
+	}
+	return envelope
+}
+
+// syncTestPCM encodes an envelope as s16le audio whose frames have those amplitudes.
+func syncTestPCM(envelope []float64) string {
+	samplesPerFrame := syncSampleRate / syncEnvelopeRate
+	pcm := make([]byte, 0, len(envelope)*samplesPerFrame*2)
+	for _, level := range envelope {
+		for i := 0; i < samplesPerFrame; i++ {
+			sample := int16(level)
+			if i%2 == 1 {
+				sample = -sample
+			}
+			pcm = binary.LittleEndian.AppendUint16(pcm, uint16(sample))
+		}
+	}
+	return string(pcm)
+}
+
+func TestCrossCorrelationLag(t *testing.T) {
+	reference := syncTestEnvelope(1, 20)
+
+	// The source started recording 1.5s before the reference
+	source := append(syncTestEnvelope(2, 1.5), reference...)
+	lag, ok := crossCorrelationLag(reference, source, 5*syncEnvelopeRate)
+	require.True(t, ok)
+	assert.Equal(t, 150, lag)
+
+	// The source started recording 0.8s after the reference
+	lag, ok = crossCorrelationLag(reference, reference[80:], 5*syncEnvelopeRate)
+	require.True(t, ok)
+	assert.Equal(t, -80, lag)
+
+	_, ok = crossCorrelationLag(reference[:50], source, 5*syncEnvelopeRate)
+	assert.False(t, ok)
+}
+
+func TestMultiViewService_syncLayout_DetectsOffsetsOnce(t *testing.T) {
+	reference := syncTestEnvelope(3, 12)
+	interviewer := testPath("media", "interviewer.mp4")
+	guest := testPath("media", "guest.mp4")
+
+	executor := newFakeCommandExecutor(
+		expectedCommand{
+			Name:     "ffmpeg",
+			Contains: []string{"-t 15.00 -i " + interviewer, "-ac 1 -ar 8000 -f s16le -"},
+			Result:   newCommandResult(syncTestPCM(reference), ""),
+		},
+		expectedCommand{
+			Name:     "ffmpeg",
+			Contains: []string{"-i " + guest},
+			Result:   newCommandResult(syncTestPCM(append(syncTestEnvelope(4, 2.25), reference...)), ""),
+		},
+	)
+	service := NewMultiViewServiceWithExecutor(afero.NewMemMapFs(), &mockLogger{}, executor)
+
+	layout := config.LayoutConfig{
+		Type:   "split-horizontal",
+		Videos: config.VideoSources{Left: interviewer, Right: guest},
+		Sync:   config.SyncConfig{Enabled: true, Auto: true, Offsets: []float64{0, 0.1}, MaxOffset: 3, Window: 12},
+	}
+	synced := &syncedLayout{layout: layout}
+
+	got := service.syncLayout(context.Background(), synced)
+	executor.AssertDone(t)
+	require.Len(t, got.Sync.Offsets, 2)
+	assert.Equal(t, 0.0, got.Sync.Offsets[0])
+	assert.InDelta(t, 2.35, got.Sync.Offsets[1], 0.001)
+	assert.Equal(t, []float64{0, 0.1}, layout.Sync.Offsets)
+
+	// Other slides with the same layout reuse the detected offsets
+	assert.Equal(t, got, service.syncLayout(context.Background(), synced))
+}
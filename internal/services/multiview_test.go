@@ -151,6 +151,62 @@ func TestBuildGrid3x3(t *testing.T) {
 	}
 }
 
+func TestLayouts_FillOutputFrame(t *testing.T) {
+	service := NewMultiViewService(nil, nil)
+
+	tests := []struct {
+		name   string
+		build  func(config.LayoutConfig, int, int) (string, error)
+		layout config.LayoutConfig
+		want   []string
+	}{
+		{
+			name:   "split gap",
+			build:  service.buildSplitHorizontal,
+			layout: config.LayoutConfig{Ratio: "50:50", Gap: 20},
+			want:   []string{"pad=960:1080:20+(940-iw)/2:(oh-ih)/2[right]"},
+		},
+		{
+			name:   "pip main",
+			build:  service.buildPiP,
+			layout: config.LayoutConfig{Position: "top-right", Size: "25%"},
+			want:   []string{"[0:v]scale=1920:1080:force_original_aspect_ratio=decrease,pad=1920:1080:(ow-iw)/2:(oh-ih)/2[main]", "[main][pip]overlay="},
+		},
+		{
+			name:   "partial grid",
+			build:  service.buildGrid,
+			layout: config.LayoutConfig{Rows: 2, Cols: 2, GridVideos: []string{"a.mp4", "b.mp4", "c.mp4"}},
+			want:   []string{"xstack=inputs=3:", "fill=black:shortest=1,pad=1920:1080:0:0[out]"},
+		},
+		{
+			name:   "single cell grid",
+			build:  service.buildGrid,
+			layout: config.LayoutConfig{Rows: 1, Cols: 1, GridVideos: []string{"a.mp4"}},
+			want:   []string{"[v0]pad=1920:1080:0:0[out]"},
+		},
+		{
+			name:   "bottom gallery",
+			build:  service.buildFocusGallery,
+			layout: config.LayoutConfig{Gallery: []string{"a.mp4", "b.mp4"}, GalleryPosition: "bottom", GallerySize: "20%"},
+			want:   []string{"pad=1920:1080:0:0[main]", "[g0][g1]hstack=inputs=2:shortest=1[gallery]", "[main][gallery]overlay=0:864"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			filter, err := tt.build(tt.layout, 1920, 1080)
+			if err != nil {
+				t.Fatalf("Failed to build filter: %v", err)
+			}
+			for _, want := range tt.want {
+				if !strings.Contains(filter, want) {
+					t.Errorf("Filter should contain %q, got: %s", want, filter)
+				}
+			}
+		})
+	}
+}
+
 func TestParseRatio(t *testing.T) {
 	service := NewMultiViewService(nil, nil)
 
@@ -284,6 +340,185 @@ func TestGetInputFiles(t *testing.T) {
 	}
 }
 
+func TestBuildMultiViewArgs_ComposesOnNarratedSegment(t *testing.T) {
+	service := NewMultiViewService(nil, nil)
+
+	layout := config.LayoutConfig{
+		Type:    "pip",
+		Main:    "screen.mp4",
+		Overlay: "webcam.mp4",
+		Audio:   config.LayoutAudioConfig{SourceVolumes: []float64{0, 0.5}},
+		Sync:    config.SyncConfig{Enabled: true, Offsets: []float64{0, 1.25}},
+	}
+
+	args, err := service.buildMultiViewArgs(layout, "video_1.mp4", 12.5, "multiview_1.mp4", 1920, 1080)
+	if err != nil {
+		t.Fatalf("Failed to build args: %v", err)
+	}
+	command := strings.Join(args, " ")
+
+	for _, want := range []string{
+		"-i screen.mp4 -ss 1.250 -i webcam.mp4 -i video_1.mp4",
+		"[out]setsar=1,tpad=stop_mode=clone:stop_duration=12.50[vout]",
+		"[2:a]volume=1.00[narration];[1:a]volume=0.50[src1];[narration][src1]amix=inputs=2:duration=first:dropout_transition=0:normalize=0[aout]",
+		"-map [vout] -map [aout]",
+		"-t 12.50 multiview_1.mp4",
+	} {
+		if !strings.Contains(command, want) {
+			t.Errorf("Command should contain %q, got: %s", want, command)
+		}
+	}
+	if strings.Contains(command, "-shortest") {
+		t.Errorf("Command should follow the narration duration, got: %s", command)
+	}
+}
+
+func TestBuildCustom_StacksByZIndex(t *testing.T) {
+	service := NewMultiViewService(nil, nil)
+
+	layout := config.LayoutConfig{
+		Type: "custom",
+		CustomVideos: []config.CustomVideoConfig{
+			{Source: "logo.mp4", Position: [2]int{20, 20}, Size: [2]int{200, 100}, ZIndex: 2, Opacity: 0.5},
+			{
+				Source:   "camera.mp4",
+				Position: [2]int{0, 0},
+				Size:     [2]int{1920, 1080},
+				Effects: []config.EffectConfig{
+					{Type: "vignette", Config: config.EffectDetails{Intensity: 0.5}},
+				},
+			},
+		},
+	}
+
+	filter, err := service.buildCustom(layout, 1920, 1080)
+	if err != nil {
+		t.Fatalf("Failed to build filter: %v", err)
+	}
+
+	want := "[2:v]scale=1920:1080,drawbox=c=black:t=fill[canvas];" +
+		"[0:v]scale=200:100,format=yuva420p,colorchannelmixer=aa=0.50[cv0];" +
+		"[1:v]scale=1920:1080,vignette=angle=PI/8.0[cv1];" +
+		"[canvas][cv1]overlay=0:0[tmp0];" +
+		"[tmp0][cv0]overlay=20:20[out]"
+	if filter != want {
+		t.Errorf("buildCustom() = %q, want %q", filter, want)
+	}
+}
+
+func TestBuildCustom_RejectsUnsupportedEffects(t *testing.T) {
+	service := NewMultiViewService(nil, nil)
+
+	layout := config.LayoutConfig{
+		Type: "custom",
+		CustomVideos: []config.CustomVideoConfig{
+			{Source: "camera.mp4", Size: [2]int{640, 360}, Effects: []config.EffectConfig{{Type: "ken-burns"}}},
+		},
+	}
+
+	if _, err := service.buildCustom(layout, 1920, 1080); err == nil {
+		t.Error("buildCustom() should reject ken-burns on a tile")
+	}
+}
+
+func TestResolveSyncOffsets(t *testing.T) {
+	service := NewMultiViewService(nil, nil)
+
+	tests := []struct {
+		name string
+		sync config.SyncConfig
+		main string
+		want []float64
+	}{
+		{
+			name: "disabled",
+			sync: config.SyncConfig{Offset: 2},
+			main: "main.mp4",
+			want: []float64{0, 0},
+		},
+		{
+			name: "single offset for later sources",
+			sync: config.SyncConfig{Enabled: true, Offset: 1.5},
+			main: "main.mp4",
+			want: []float64{0, 1.5},
+		},
+		{
+			name: "negative offsets shift the other sources",
+			sync: config.SyncConfig{Enabled: true, Offsets: []float64{0.5, -1}},
+			main: "main.mp4",
+			want: []float64{1.5, 0},
+		},
+		{
+			name: "slide source follows the narration",
+			sync: config.SyncConfig{Enabled: true, Offsets: []float64{3, 2}},
+			main: config.MultiViewSlideSource,
+			want: []float64{0, 2},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			layout := config.LayoutConfig{Type: "pip", Main: tt.main, Overlay: "overlay.mp4", Sync: tt.sync}
+			got := service.resolveSyncOffsets(layout)
+			if len(got) != len(tt.want) || got[0] != tt.want[0] || got[1] != tt.want[1] {
+				t.Errorf("resolveSyncOffsets() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestBuildAudioFilter(t *testing.T) {
+	service := NewMultiViewService(nil, nil)
+
+	tests := []struct {
+		name   string
+		layout config.LayoutConfig
+		want   string
+	}{
+		{
+			name: "sources at full volume",
+			layout: config.LayoutConfig{
+				Type:   "split-horizontal",
+				Videos: config.VideoSources{Left: "left.mp4", Right: "right.mp4"},
+			},
+			want: "[2:a]volume=1.00[narration];[0:a]volume=1.00[src0];[1:a]volume=1.00[src1];" +
+				"[narration][src0][src1]amix=inputs=3:duration=first:dropout_transition=0:normalize=0[aout]",
+		},
+		{
+			name: "narration only",
+			layout: config.LayoutConfig{
+				Type:   "split-horizontal",
+				Videos: config.VideoSources{Left: config.MultiViewSlideSource, Right: "right.mp4"},
+				Audio:  config.LayoutAudioConfig{NarrationVolume: 1.5, SourceVolumes: []float64{1, 0}},
+			},
+			want: "[2:a]volume=1.50[aout]",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := service.buildAudioFilter(tt.layout); got != tt.want {
+				t.Errorf("buildAudioFilter() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestResolveInputFiles_UsesSegmentForSlideSource(t *testing.T) {
+	service := NewMultiViewService(nil, nil)
+
+	layout := config.LayoutConfig{
+		Type:    "pip",
+		Main:    config.MultiViewSlideSource,
+		Overlay: "webcam.mp4",
+	}
+
+	got := service.resolveInputFiles(layout, "video_0.mp4")
+	if len(got) != 2 || got[0] != "video_0.mp4" || got[1] != "webcam.mp4" {
+		t.Errorf("resolveInputFiles() = %v, want [video_0.mp4 webcam.mp4]", got)
+	}
+}
+
 func TestParseSlides(t *testing.T) {
 	tests := []struct {
 		name        string
@@ -0,0 +1,336 @@
+package services
+
+import (
+	"encoding/xml"
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/goccy/go-yaml"
+	"github.com/spf13/afero"
+)
+
+// Narration sidecars may use a small inline markup:
+//
+//	[pause 800ms]  silence, in ms or s
+//	*emphasis*     stressed words
+//	{GIF|jiff}     shown as GIF, spoken as jiff
+//
+// Subtitles show the text without markup, translation keeps pauses and substitutions intact, and
+// TTS renders the markup as SSML or by joining speech chunks with generated silence.
+var (
+	pauseMarkupPattern        = regexp.MustCompile(`\[pause\s+(\d+(?:\.\d+)?)\s*(ms|s)\]`)
+	substitutionMarkupPattern = regexp.MustCompile(`\{([^{}|\n]+)\|([^{}\n]*)\}`)
+	emphasisMarkupPattern     = regexp.MustCompile(`\*([^*\s](?:[^*\n]*[^*\s])?)\*`)
+	protectedMarkupPattern    = regexp.MustCompile(pauseMarkupPattern.String() + `|` + substitutionMarkupPattern.String())
+	markupPlaceholderPattern  = regexp.MustCompile(`⟦(\d+)⟧`)
+	repeatedSpacePattern      = regexp.MustCompile(`[ \t]{2,}`)
+)
+
+// narrationChunk is a run of speech or a pause between runs.
+type narrationChunk struct {
+	text  string
+	pause float64 // seconds of silence when text is empty
+}
+
+// hasNarrationMarkup reports whether text uses any narration markup.
+func hasNarrationMarkup(text string) bool {
+	return pauseMarkupPattern.MatchString(text) || substitutionMarkupPattern.MatchString(text) || emphasisMarkupPattern.MatchString(text)
+}
+
+// displayNarration returns text as it should be read on screen, without markup.
+func displayNarration(text string) string {
+	if !hasNarrationMarkup(text) {
+		return text
+	}
+	text = pauseMarkupPattern.ReplaceAllString(text, "")
+	text = substitutionMarkupPattern.ReplaceAllString(text, "$1")
+	text = emphasisMarkupPattern.ReplaceAllString(text, "$1")
+	return strings.TrimSpace(repeatedSpacePattern.ReplaceAllString(text, " "))
+}
+
+// displayNarrations applies displayNarration to every text.
+func displayNarrations(texts []string) []string {
+	display := make([]string, len(texts))
+	for i, text := range texts {
+		display[i] = displayNarration(text)
+	}
+	return display
+}
+
+// spokenNarration returns the words a TTS engine without SSML should say for text without pauses.
+func spokenNarration(text string) string {
+	text = substitutionMarkupPattern.ReplaceAllString(text, "$2")
+	text = emphasisMarkupPattern.ReplaceAllString(text, "$1")
+	return strings.TrimSpace(repeatedSpacePattern.ReplaceAllString(text, " "))
+}
+
+// narrationSSML renders text as an SSML document.
+func narrationSSML(text string) string {
+	var escaped strings.Builder
+	_ = xml.EscapeText(&escaped, []byte(text))
+	ssml := pauseMarkupPattern.ReplaceAllString(escaped.String(), `<break time="$1$2"/>`)
+	ssml = substitutionMarkupPattern.ReplaceAllString(ssml, `<sub alias="$2">$1</sub>`)
+	ssml = emphasisMarkupPattern.ReplaceAllString(ssml, `<emphasis>$1</emphasis>`)
+	return "<speak>" + ssml + "</speak>"
+}
+
+// narrationChunks splits text at its pauses. Adjacent pauses are merged and empty speech is dropped.
+func narrationChunks(text string) []narrationChunk {
+	var chunks []narrationChunk
+	addSpeech := func(segment string) {
+		if spoken := spokenNarration(segment); spoken != "" {
+			chunks = append(chunks, narrationChunk{text: spoken})
+		}
+	}
+
+	offset := 0
+	for _, match := range pauseMarkupPattern.FindAllStringSubmatchIndex(text, -1) {
+		addSpeech(text[offset:match[0]])
+		offset = match[1]
+
+		seconds, _ := strconv.ParseFloat(text[match[2]:match[3]], 64)
+		if text[match[4]:match[5]] == "ms" {
+			seconds /= 1000
+		}
+		if seconds <= 0 {
+			continue
+		}
+		if last := len(chunks) - 1; last >= 0 && chunks[last].text == "" {
+			chunks[last].pause += seconds
+			continue
+		}
+		chunks = append(chunks, narrationChunk{pause: seconds})
+	}
+	addSpeech(text[offset:])
+	return chunks
+}
+
+// splitNarrationText splits text into parts of at most maxChars characters for separate speech
+// requests. It breaks between sentences where it can, then between words; pauses and
+// substitutions are never split, even when that leaves a part over the limit. Text that fits is
+// returned unchanged.
+func splitNarrationText(text string, maxChars int) []string {
+	if maxChars <= 0 || utf8.RuneCountInString(text) <= maxChars {
+		return []string{text}
+	}
+
+	var pieces []string
+	for _, sentence := range splitNarrationAt(text, isSentenceEnd) {
+		if utf8.RuneCountInString(sentence) <= maxChars {
+			pieces = append(pieces, sentence)
+			continue
+		}
+		for _, word := range splitNarrationAt(sentence, isWordEnd) {
+			// Words longer than the limit are cut, unless they hold markup
+			for utf8.RuneCountInString(word) > maxChars && !protectedMarkupPattern.MatchString(word) {
+				cut := len(string([]rune(word)[:maxChars]))
+				pieces = append(pieces, word[:cut])
+				word = word[cut:]
+			}
+			pieces = append(pieces, word)
+		}
+	}
+
+	// Pack as many pieces as fit into each part
+	var parts []string
+	var current strings.Builder
+	size := 0
+	for _, piece := range pieces {
+		length := utf8.RuneCountInString(piece)
+		if size > 0 && size+1+length > maxChars {
+			parts = append(parts, current.String())
+			current.Reset()
+			size = 0
+		}
+		if size > 0 {
+			current.WriteByte(' ')
+			size++
+		}
+		current.WriteString(piece)
+		size += length
+	}
+	if size > 0 {
+		parts = append(parts, current.String())
+	}
+	return parts
+}
+
+// splitNarrationAt splits text at every byte offset where boundary reports true, except inside
+// pauses and substitutions, and returns the trimmed non-empty parts.
+func splitNarrationAt(text string, boundary func(text string, end int) bool) []string {
+	protected := protectedMarkupPattern.FindAllStringIndex(text, -1)
+	var parts []string
+	start := 0
+	for i := range text {
+		_, width := utf8.DecodeRuneInString(text[i:])
+		end := i + width
+		if withinRanges(protected, end) || !boundary(text, end) {
+			continue
+		}
+		if part := strings.TrimSpace(text[start:end]); part != "" {
+			parts = append(parts, part)
+		}
+		start = end
+	}
+	if part := strings.TrimSpace(text[start:]); part != "" {
+		parts = append(parts, part)
+	}
+	return parts
+}
+
+// isSentenceEnd reports whether a sentence ends at offset end: after a line break, a full-width
+// stop, or terminal punctuation (and any closing quotes) followed by whitespace.
+func isSentenceEnd(text string, end int) bool {
+	last, _ := utf8.DecodeLastRuneInString(text[:end])
+	if last == '\n' || strings.ContainsRune("。！？", last) {
+		return true
+	}
+	if next, _ := utf8.DecodeRuneInString(text[end:]); end < len(text) && !unicode.IsSpace(next) {
+		return false
+	}
+	last, _ = utf8.DecodeLastRuneInString(strings.TrimRight(text[:end], `"')]»”’`))
+	return strings.ContainsRune(".!?…", last)
+}
+
+// isWordEnd reports whether whitespace follows offset end.
+func isWordEnd(text string, end int) bool {
+	next, _ := utf8.DecodeRuneInString(text[end:])
+	return end < len(text) && unicode.IsSpace(next)
+}
+
+func withinRanges(ranges [][]int, offset int) bool {
+	for _, r := range ranges {
+		if offset > r[0] && offset < r[1] {
+			return true
+		}
+	}
+	return false
+}
+
+// protectNarrationMarkup replaces pauses and substitutions with numbered placeholders so a
+// translation provider cannot translate or drop their parts.
+func protectNarrationMarkup(text string) (string, []string) {
+	var tokens []string
+	protected := protectedMarkupPattern.ReplaceAllStringFunc(text, func(token string) string {
+		tokens = append(tokens, token)
+		return fmt.Sprintf("⟦%d⟧", len(tokens)-1)
+	})
+	return protected, tokens
+}
+
+// restoreNarrationMarkup puts protected markup back and returns the tokens a translation dropped.
+func restoreNarrationMarkup(text string, tokens []string) (string, []string) {
+	if len(tokens) == 0 {
+		return text, nil
+	}
+
+	used := make([]bool, len(tokens))
+	restored := markupPlaceholderPattern.ReplaceAllStringFunc(text, func(placeholder string) string {
+		index, err := strconv.Atoi(markupPlaceholderPattern.FindStringSubmatch(placeholder)[1])
+		if err != nil || index >= len(tokens) {
+			return placeholder
+		}
+		used[index] = true
+		return tokens[index]
+	})
+
+	var missing []string
+	for i, token := range tokens {
+		if !used[i] {
+			missing = append(missing, token)
+		}
+	}
+	return restored, missing
+}
+
+// PronunciationDictionary maps terms to how TTS should say them. Matching is case-insensitive and
+// on whole words; a match becomes a {term|alias} substitution.
+type PronunciationDictionary map[string]string
+
+// LoadPronunciationDictionary reads a pronunciation dictionary YAML file of term: alias pairs.
+func LoadPronunciationDictionary(fs afero.Fs, path string) (PronunciationDictionary, error) {
+	data, err := afero.ReadFile(fs, path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read pronunciation dictionary: %w", err)
+	}
+
+	var dictionary PronunciationDictionary
+	if err := yaml.Unmarshal(data, &dictionary); err != nil {
+		return nil, fmt.Errorf("failed to parse pronunciation dictionary %s: %w", path, err)
+	}
+	return dictionary, nil
+}
+
+// apply marks every dictionary term in text with a substitution. Existing pauses and substitutions
+// are left alone, so applying a dictionary twice changes nothing.
+func (d PronunciationDictionary) apply(text string) string {
+	if len(d) == 0 {
+		return text
+	}
+
+	terms := make([]string, 0, len(d))
+	for term, alias := range d {
+		if strings.TrimSpace(term) != "" && strings.TrimSpace(alias) != "" {
+			terms = append(terms, term)
+		}
+	}
+	// Longer terms first, so "GIF image" wins over "GIF"
+	sort.Slice(terms, func(i, j int) bool {
+		if len(terms[i]) != len(terms[j]) {
+			return len(terms[i]) > len(terms[j])
+		}
+		return terms[i] < terms[j]
+	})
+
+	for _, term := range terms {
+		alias := strings.TrimSpace(d[term])
+		term = strings.TrimSpace(term)
+
+		var b strings.Builder
+		offset := 0
+		for _, match := range protectedMarkupPattern.FindAllStringIndex(text, -1) {
+			b.WriteString(substituteTerm(text[offset:match[0]], term, alias))
+			b.WriteString(text[match[0]:match[1]])
+			offset = match[1]
+		}
+		b.WriteString(substituteTerm(text[offset:], term, alias))
+		text = b.String()
+	}
+	return text
+}
+
+// substituteTerm wraps whole-word, case-insensitive occurrences of term in a substitution.
+func substituteTerm(text, term, alias string) string {
+	lower := strings.ToLower(text)
+	lowerTerm := strings.ToLower(term)
+	if len(lower) != len(text) || len(lowerTerm) != len(term) {
+		// Case folding changed byte offsets; fall back to exact matches
+		lower, lowerTerm = text, term
+	}
+
+	var b strings.Builder
+	offset := 0
+	for search := 0; search < len(lower); {
+		idx := strings.Index(lower[search:], lowerTerm)
+		if idx < 0 {
+			break
+		}
+		start := search + idx
+		end := start + len(lowerTerm)
+		before, _ := utf8.DecodeLastRuneInString(text[:start])
+		after, _ := utf8.DecodeRuneInString(text[end:])
+		if !isWordRune(before) && !isWordRune(after) {
+			b.WriteString(text[offset:start])
+			fmt.Fprintf(&b, "{%s|%s}", text[start:end], alias)
+			offset = end
+		}
+		search = end
+	}
+	b.WriteString(text[offset:])
+	return b.String()
+}
@@ -0,0 +1,219 @@
+package services
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"gocreator/internal/config"
+	"gocreator/internal/interfaces"
+	"gocreator/internal/mocks"
+
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNarrationMarkup(t *testing.T) {
+	text := "Say *hello* [pause 800ms] to {GIF|jiff} & friends."
+
+	assert.Equal(t, "Say hello to GIF & friends.", displayNarration(text))
+	assert.Equal(t, "Plain text", displayNarration("Plain text"))
+	assert.Equal(t,
+		`<speak>Say <emphasis>hello</emphasis> <break time="800ms"/> to <sub alias="jiff">GIF</sub> &amp; friends.</speak>`,
+		narrationSSML(text))
+	assert.Equal(t, []narrationChunk{
+		{text: "Say hello"},
+		{pause: 0.8},
+		{text: "to jiff & friends."},
+	}, narrationChunks(text))
+
+	// Adjacent pauses merge and a leading pause is kept
+	assert.Equal(t, []narrationChunk{
+		{pause: 0.5},
+		{text: "One"},
+		{pause: 1.25},
+		{text: "two"},
+	}, narrationChunks("[pause 0.5s] One [pause 1s][pause 250ms] two"))
+
+	// Stray asterisks are not emphasis
+	assert.False(t, hasNarrationMarkup("5 * 3 = 15"))
+}
+
+func TestProtectNarrationMarkup(t *testing.T) {
+	protected, tokens := protectNarrationMarkup("Hello [pause 1s] {GIF|jiff} *world*")
+	assert.Equal(t, "Hello ⟦0⟧ ⟦1⟧ *world*", protected)
+	assert.Equal(t, []string{"[pause 1s]", "{GIF|jiff}"}, tokens)
+
+	restored, missing := restoreNarrationMarkup("Bonjour ⟦1⟧ ⟦0⟧ *monde*", tokens)
+	assert.Equal(t, "Bonjour {GIF|jiff} [pause 1s] *monde*", restored)
+	assert.Empty(t, missing)
+
+	restored, missing = restoreNarrationMarkup("Bonjour ⟦0⟧", tokens)
+	assert.Equal(t, "Bonjour [pause 1s]", restored)
+	assert.Equal(t, []string{"{GIF|jiff}"}, missing)
+}
+
+func TestPronunciationDictionary(t *testing.T) {
+	fs := afero.NewMemMapFs()
+	path := testPath("data", "pronunciations", "en.yaml")
+	require.NoError(t, writeTestFile(fs, path, "GIF: jiff\nGIF image: jiff picture\nSQL: sequel\n"))
+
+	dictionary, err := LoadPronunciationDictionary(fs, path)
+	require.NoError(t, err)
+
+	applied := dictionary.apply("A GIF image, a gif, GIFs, and MySQL {SQL|ess queue ell}.")
+	assert.Equal(t, "A {GIF image|jiff picture}, a {gif|jiff}, GIFs, and MySQL {SQL|ess queue ell}.", applied)
+	assert.Equal(t, applied, dictionary.apply(applied))
+	assert.Equal(t, "A GIF image, a gif, GIFs, and MySQL SQL.", displayNarration(applied))
+
+	require.NoError(t, writeTestFile(fs, path, "- not a mapping\n"))
+	_, err = LoadPronunciationDictionary(fs, path)
+	require.Error(t, err)
+}
+
+func TestAudioService_Generate_JoinsChunksAroundPauses(t *testing.T) {
+	fs := afero.NewMemMapFs()
+	logger := &mockLogger{}
+	outputPath := testPath("cache", "en", "audio", "0.mp3")
+	mockClient := new(mocks.MockOpenAIClient)
+	mockClient.On("GenerateSpeech", mock.Anything, "Welcome").Return(newMockReadCloser("first"), nil)
+	mockClient.On("GenerateSpeech", mock.Anything, "to the jiff demo").Return(newMockReadCloser("second"), nil)
+	executor := newFakeCommandExecutor(expectedCommand{
+		Name: "ffmpeg",
+		Contains: []string{
+			"-i " + testPath("cache", "en", "audio", "chunks"),
+			"-f lavfi -t 0.800 -i anullsrc=r=44100:cl=stereo",
+			"[a0][a1][a2]concat=n=3:v=0:a=1[out]",
+		},
+		Run: func(_ string, args []string) {
+			_ = writeTestFile(fs, args[len(args)-1], "joined")
+		},
+	})
+	registry := NewTTSProviderRegistryWithExecutor(fs, mockClient, config.VoiceConfig{}, executor)
+	service := NewAudioServiceWithExecutor(fs, registry, NewTextService(fs, logger), logger, executor).
+		WithPronunciations(PronunciationDictionary{"GIF": "jiff"})
+
+	require.NoError(t, service.Generate(context.Background(), "Welcome [pause 800ms] to the GIF demo", outputPath))
+	executor.AssertDone(t)
+	mockClient.AssertExpectations(t)
+
+	content, err := afero.ReadFile(fs, outputPath)
+	require.NoError(t, err)
+	assert.Equal(t, "joined", string(content))
+}
+
+func TestAudioService_Generate_ResynthesizesIncompleteChunks(t *testing.T) {
+	fs := afero.NewMemMapFs()
+	logger := &mockLogger{}
+	outputPath := testPath("cache", "en", "audio", "0.mp3")
+	mockClient := new(mocks.MockOpenAIClient)
+	mockClient.On("GenerateSpeech", mock.Anything, "First part.").Return(newMockReadCloser("first"), nil).Once()
+	mockClient.On("GenerateSpeech", mock.Anything, "Second part.").Return(newMockReadCloser("second"), nil).Once()
+	executor := newFakeCommandExecutor(expectedCommand{Name: "ffmpeg", Contains: []string{"concat=n=3"}})
+	registry := NewTTSProviderRegistryWithExecutor(fs, mockClient, config.VoiceConfig{}, executor)
+	service := NewAudioServiceWithExecutor(fs, registry, NewTextService(fs, logger), logger, executor)
+
+	// A chunk left half-written by a killed run has no hash file.
+	chunkDir := testPath("cache", "en", "audio", "chunks")
+	partialChunk := filepath.Join(chunkDir, service.computeSpeechHash("First part.")+".mp3")
+	require.NoError(t, writeTestFile(fs, partialChunk, "fir"))
+
+	require.NoError(t, service.Generate(context.Background(), "First part. [pause 500ms] Second part.", outputPath))
+	executor.AssertDone(t)
+	mockClient.AssertExpectations(t)
+
+	content, err := afero.ReadFile(fs, partialChunk)
+	require.NoError(t, err)
+	assert.Equal(t, "first", string(content))
+	assert.True(t, outputComplete(fs, partialChunk, service.computeSpeechHash("First part.")))
+}
+
+func TestAudioService_Generate_SendsSSMLToSSMLProviders(t *testing.T) {
+	fs := afero.NewMemMapFs()
+	logger := &mockLogger{}
+	var ssml string
+	executor := newFakeCommandExecutor(expectedCommand{
+		Name:     "espeak-ng",
+		Contains: []string{"-m -v en"},
+		Run: func(_ string, args []string) {
+			for i, arg := range args {
+				switch arg {
+				case "-f":
+					data, _ := afero.ReadFile(fs, args[i+1])
+					ssml = string(data)
+				case "-w":
+					_ = writeTestFile(fs, args[i+1], "RIFF-audio")
+				}
+			}
+		},
+	})
+	registry := NewTTSProviderRegistryWithExecutor(fs, new(mocks.MockOpenAIClient), config.VoiceConfig{
+		Espeak: config.LocalTTSConfig{SSML: true},
+	}, executor)
+	service, err := NewAudioServiceWithExecutor(fs, registry, NewTextService(fs, logger), logger, executor).WithProvider(config.TTSProviderEspeak)
+	require.NoError(t, err)
+
+	require.NoError(t, service.WithSpeechOptions(interfaces.SpeechOptions{Voice: "en"}).Generate(context.Background(), "Hello [pause 1s] *world*", testPath("cache", "en", "audio", "0.mp3")))
+	executor.AssertDone(t)
+	assert.Equal(t, `<speak>Hello <break time="1s"/> <emphasis>world</emphasis></speak>`, ssml)
+}
+
+func TestSplitNarrationText(t *testing.T) {
+	assert.Equal(t, []string{"Short enough."}, splitNarrationText("Short enough.", 40))
+	assert.Equal(t,
+		[]string{"First sentence. Second one!", "Third (quoted.) Fourth?"},
+		splitNarrationText("First sentence. Second one! Third (quoted.) Fourth?", 30))
+
+	// Long sentences break between words and never inside markup
+	assert.Equal(t,
+		[]string{"one two three", "{Dr. X|doctor ex}", "[pause 1s] four"},
+		splitNarrationText("one two three {Dr. X|doctor ex} [pause 1s] four", 15))
+
+	// Words longer than the limit are cut
+	assert.Equal(t, []string{"abcd", "efgh", "ij"}, splitNarrationText("abcdefghij", 4))
+}
+
+func TestAudioService_Generate_ChunksLongNarration(t *testing.T) {
+	fs := afero.NewMemMapFs()
+	logger := &mockLogger{}
+	outputPath := testPath("cache", "en", "audio", "0.mp3")
+	joinCommand := func() expectedCommand {
+		return expectedCommand{
+			Name: "ffmpeg",
+			Contains: []string{
+				"-f lavfi -t 0.250 -i anullsrc",
+				"[0:a]loudnorm=I=-16:TP=-1.5:LRA=11,aresample=44100",
+				"[1:a]aresample=44100",
+				"[a0][a1][a2]concat=n=3:v=0:a=1[out]",
+			},
+			Run: func(_ string, args []string) {
+				_ = writeTestFile(fs, args[len(args)-1], "joined")
+			},
+		}
+	}
+	mockClient := new(mocks.MockOpenAIClient)
+	mockClient.On("GenerateSpeech", mock.Anything, "First sentence here.").Return(newMockReadCloser("first"), nil).Once()
+	mockClient.On("GenerateSpeech", mock.Anything, "Second one is here.").Return(newMockReadCloser("second"), nil).Once()
+	executor := newFakeCommandExecutor(joinCommand())
+	registry := NewTTSProviderRegistryWithExecutor(fs, mockClient, config.VoiceConfig{}, executor)
+	service := NewAudioServiceWithExecutor(fs, registry, NewTextService(fs, logger), logger, executor).
+		WithChunking(config.TTSChunkingConfig{MaxChars: 20, Gap: 0.25, MatchLoudness: true})
+
+	require.NoError(t, service.Generate(context.Background(), "First sentence here. Second one is here.", outputPath))
+	executor.AssertDone(t)
+	mockClient.AssertExpectations(t)
+
+	// Editing one sentence only re-synthesizes its chunk
+	mockClient = new(mocks.MockOpenAIClient)
+	mockClient.On("GenerateSpeech", mock.Anything, "Second one changed.").Return(newMockReadCloser("changed"), nil).Once()
+	executor = newFakeCommandExecutor(joinCommand())
+	registry = NewTTSProviderRegistryWithExecutor(fs, mockClient, config.VoiceConfig{}, executor)
+	service = NewAudioServiceWithExecutor(fs, registry, NewTextService(fs, logger), logger, executor).
+		WithChunking(config.TTSChunkingConfig{MaxChars: 20, Gap: 0.25, MatchLoudness: true})
+
+	require.NoError(t, service.Generate(context.Background(), "First sentence here. Second one changed.", outputPath))
+	executor.AssertDone(t)
+	mockClient.AssertExpectations(t)
+}
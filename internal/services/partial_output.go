@@ -0,0 +1,35 @@
+package services
+
+import (
+	"fmt"
+
+	"github.com/spf13/afero"
+)
+
+// discardPartialOutput removes a generated file together with its cache hash. It runs before a
+// step rewrites the file, so a stale hash cannot vouch for new content, and again when the step
+// fails or is cancelled, so a half-written file is never mistaken for a cache hit.
+func discardPartialOutput(fs afero.Fs, path string) {
+	_ = fs.Remove(path)
+	_ = fs.Remove(path + ".hash")
+}
+
+// outputComplete reports whether a content-addressed file was fully written, which markOutputComplete
+// records in its hash file once the step that writes it succeeds. A file left behind by a killed run
+// has no hash file and is regenerated.
+func outputComplete(fs afero.Fs, path, hash string) bool {
+	stored, err := afero.ReadFile(fs, path+".hash")
+	if err != nil || string(stored) != hash {
+		return false
+	}
+	exists, err := afero.Exists(fs, path)
+	return err == nil && exists
+}
+
+// markOutputComplete records that a content-addressed file was fully written.
+func markOutputComplete(fs afero.Fs, path, hash string) error {
+	if err := afero.WriteFile(fs, path+".hash", []byte(hash), 0644); err != nil {
+		return fmt.Errorf("failed to write cache hash: %w", err)
+	}
+	return nil
+}
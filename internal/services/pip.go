@@ -0,0 +1,271 @@
+package services
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"gocreator/internal/config"
+
+	"github.com/spf13/afero"
+)
+
+const defaultPipBorderWidth = 4
+
+// pipPlacement is one overlay shown on one slide segment.
+type pipPlacement struct {
+	overlay config.PipOverlayConfig
+	// start is where the overlay video is cut in, so a recording spanning several slides keeps playing
+	// across them instead of restarting on every slide.
+	start   float64
+	fadeIn  bool
+	fadeOut bool
+}
+
+// ResolvePipVideos returns a copy of cfg with overlay video paths resolved against rootDir.
+func ResolvePipVideos(rootDir string, cfg config.PipConfig) config.PipConfig {
+	resolved := cfg
+	resolved.Overlays = make([]config.PipOverlayConfig, len(cfg.Overlays))
+	for i, overlay := range cfg.Overlays {
+		if overlay.Video != "" && !filepath.IsAbs(overlay.Video) {
+			overlay.Video = filepath.Join(rootDir, overlay.Video)
+		}
+		resolved.Overlays[i] = overlay
+	}
+	return resolved
+}
+
+// applyPipOverlays composites the configured picture-in-picture videos over their slide segments
+func (s *VideoService) applyPipOverlays(ctx context.Context, videoFiles []string, tempDir string, width, height int) error {
+	if s.pipConfig == nil || !s.pipConfig.Enabled || len(s.pipConfig.Overlays) == 0 {
+		return nil
+	}
+
+	placements, durations, err := s.planPipPlacements(ctx, videoFiles)
+	if err != nil {
+		return err
+	}
+
+	errors := runBounded(ctx, len(videoFiles), DefaultScheduler().FFmpegLimit(), func(slideIdx int) error {
+		slidePlacements, ok := placements[slideIdx]
+		if !ok {
+			return nil
+		}
+
+		pipPath := filepath.Join(tempDir, fmt.Sprintf("pip_%d.mp4", slideIdx))
+		if err := s.renderPipSegment(ctx, videoFiles[slideIdx], pipPath, slidePlacements, durations[slideIdx], width, height); err != nil {
+			return fmt.Errorf("failed to apply pip overlay to slide %d: %w", slideIdx, err)
+		}
+
+		videoFiles[slideIdx] = pipPath
+		return nil
+	})
+
+	if err := joinSlideErrors(ctx, errors); err != nil {
+		return err
+	}
+
+	s.logger.Info("PiP overlays applied", "slides", len(placements))
+	return nil
+}
+
+// planPipPlacements works out which overlays each segment shows and where each overlay video is cut in.
+// An overlay fades in at the start of each contiguous run of its slides and fades out at the end.
+func (s *VideoService) planPipPlacements(ctx context.Context, videoFiles []string) (map[int][]pipPlacement, map[int]float64, error) {
+	placements := make(map[int][]pipPlacement)
+	durations := make(map[int]float64)
+
+	for _, overlay := range s.pipConfig.Overlays {
+		indices := overlay.ParseSlides(len(videoFiles))
+		start := 0.0
+		for i, idx := range indices {
+			duration, ok := durations[idx]
+			if !ok {
+				var err error
+				duration, err = s.getVideoDuration(ctx, videoFiles[idx])
+				if err != nil {
+					return nil, nil, fmt.Errorf("failed to get duration of segment %d: %w", idx, err)
+				}
+				durations[idx] = duration
+			}
+
+			placements[idx] = append(placements[idx], pipPlacement{
+				overlay: overlay,
+				start:   start,
+				fadeIn:  i == 0 || indices[i-1] != idx-1,
+				fadeOut: i == len(indices)-1 || indices[i+1] != idx+1,
+			})
+			start += duration
+		}
+	}
+
+	return placements, durations, nil
+}
+
+func (s *VideoService) renderPipSegment(
+	ctx context.Context,
+	segmentPath,
+	outputPath string,
+	placements []pipPlacement,
+	duration float64,
+	width,
+	height int,
+) error {
+	hash, err := s.computePipHash(segmentPath, placements)
+	if err != nil {
+		s.logger.Warn("Failed to compute pip cache hash", "error", err)
+	}
+	cached := hash != "" && s.hashMatches(outputPath, hash)
+	recordCacheLookup(ctx, CacheStageSegment, cached)
+	if cached {
+		s.logger.Info("Using cached pip segment", "path", outputPath)
+		return nil
+	}
+
+	args := []string{"-y", "-i", segmentPath}
+	for _, placement := range placements {
+		if placement.start > 0 {
+			args = append(args, "-ss", fmt.Sprintf("%.3f", placement.start))
+		}
+		args = append(args, "-i", placement.overlay.Video)
+	}
+	args = append(args,
+		"-filter_complex", buildPipFilter(placements, duration, width, height),
+		"-map", "[out]",
+		"-map", "0:a",
+		"-c:v", "libx264",
+		"-pix_fmt", "yuv420p",
+		"-c:a", "copy",
+		"-t", fmt.Sprintf("%.3f", duration),
+		outputPath,
+	)
+
+	s.logger.Debug("Applying pip overlay", "command", formatCommand("ffmpeg", args...))
+
+	discardPartialOutput(s.fs, outputPath)
+	result, err := s.commandExecutor.Run(ctx, "ffmpeg", args...)
+	if err != nil {
+		discardPartialOutput(s.fs, outputPath)
+		return fmt.Errorf("ffmpeg error: %w, stderr: %s", err, string(result.Stderr))
+	}
+
+	if hash != "" {
+		if err := afero.WriteFile(s.fs, outputPath+".hash", []byte(hash), 0644); err != nil {
+			s.logger.Warn("Failed to save pip segment hash", "error", err)
+		}
+	}
+	return nil
+}
+
+// buildPipFilter builds the filter graph stacking every placement over input 0, in config order.
+func buildPipFilter(placements []pipPlacement, duration float64, width, height int) string {
+	filters := make([]string, 0, len(placements)*2)
+	base := "[0:v]"
+
+	for i, placement := range placements {
+		overlay := placement.overlay
+
+		pipW, pipH := parseOverlaySize(overlay.Size, width, height)
+		pipW -= pipW % 2
+		pipH -= pipH % 2
+		chain := []string{fmt.Sprintf("scale=%d:%d", pipW, pipH), "setsar=1"}
+
+		border := overlay.Border.Width
+		if overlay.Border.Enabled && border == 0 {
+			border = defaultPipBorderWidth
+		}
+		outerW, outerH := pipW, pipH
+		if border > 0 {
+			color := overlay.Border.Color
+			if color == "" {
+				color = "white"
+			}
+			outerW, outerH = pipW+2*border, pipH+2*border
+			chain = append(chain, fmt.Sprintf("pad=%d:%d:%d:%d:color=%s", outerW, outerH, border, border, color))
+		}
+
+		chain = append(chain, "format=yuva420p")
+		if overlay.Opacity > 0 && overlay.Opacity < 1 {
+			chain = append(chain, fmt.Sprintf("colorchannelmixer=aa=%.2f", overlay.Opacity))
+		}
+		if placement.fadeIn && overlay.FadeIn > 0 {
+			chain = append(chain, fmt.Sprintf("fade=t=in:st=0:d=%.2f:alpha=1", min(overlay.FadeIn, duration)))
+		}
+		if placement.fadeOut && overlay.FadeOut > 0 {
+			fadeOut := min(overlay.FadeOut, duration)
+			chain = append(chain, fmt.Sprintf("fade=t=out:st=%.2f:d=%.2f:alpha=1", duration-fadeOut, fadeOut))
+		}
+
+		x, y := overlayPosition(overlay.Position, nil, width, height, outerW, outerH)
+		if overlay.Position == "custom" && overlay.CustomPosition.X != nil && overlay.CustomPosition.Y != nil {
+			x, y = *overlay.CustomPosition.X, *overlay.CustomPosition.Y
+		}
+
+		pipLabel := fmt.Sprintf("[pip%d]", i)
+		outLabel := fmt.Sprintf("[v%d]", i)
+		if i == len(placements)-1 {
+			outLabel = "[out]"
+		}
+		filters = append(filters,
+			fmt.Sprintf("[%d:v]%s%s", i+1, strings.Join(chain, ","), pipLabel),
+			fmt.Sprintf("%s%soverlay=%d:%d:eof_action=pass%s", base, pipLabel, x, y, outLabel),
+		)
+		base = outLabel
+	}
+
+	return strings.Join(filters, ";")
+}
+
+// computePipHash computes a cache key for an overlaid segment from the segment, the overlay videos, and
+// every overlay setting.
+func (s *VideoService) computePipHash(segmentPath string, placements []pipPlacement) (string, error) {
+	type cachedPlacement struct {
+		Overlay config.PipOverlayConfig `json:"overlay"`
+		Start   float64                 `json:"start"`
+		FadeIn  bool                    `json:"fade_in"`
+		FadeOut bool                    `json:"fade_out"`
+	}
+
+	hasher := sha256.New()
+	segmentData, err := afero.ReadFile(s.fs, segmentPath)
+	if err != nil {
+		return "", fmt.Errorf("failed to read segment file: %w", err)
+	}
+	hasher.Write(segmentData)
+
+	normalized := make([]cachedPlacement, len(placements))
+	for i, placement := range placements {
+		videoData, err := afero.ReadFile(s.fs, placement.overlay.Video)
+		if err != nil {
+			return "", fmt.Errorf("failed to read pip video: %w", err)
+		}
+		hasher.Write(videoData)
+		normalized[i] = cachedPlacement{
+			Overlay: placement.overlay,
+			Start:   placement.start,
+			FadeIn:  placement.fadeIn,
+			FadeOut: placement.fadeOut,
+		}
+	}
+
+	data, err := json.Marshal(normalized)
+	if err != nil {
+		return "", fmt.Errorf("failed to serialize pip overlays for cache: %w", err)
+	}
+	hasher.Write(data)
+
+	return hex.EncodeToString(hasher.Sum(nil)), nil
+}
+
+// hashMatches reports whether path exists with a stored hash equal to hash.
+func (s *VideoService) hashMatches(path, hash string) bool {
+	if exists, err := afero.Exists(s.fs, path); err != nil || !exists {
+		return false
+	}
+	stored, err := afero.ReadFile(s.fs, path+".hash")
+	return err == nil && string(stored) == hash
+}
@@ -0,0 +1,136 @@
+package services
+
+import (
+	"context"
+	"testing"
+
+	"gocreator/internal/config"
+
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBuildPipFilter(t *testing.T) {
+	x, y := 40, 60
+	placements := []pipPlacement{
+		{
+			overlay: config.PipOverlayConfig{
+				Position: "bottom-right",
+				Size:     "25%",
+				Border:   config.PipBorderConfig{Enabled: true, Color: "black"},
+				Opacity:  0.8,
+				FadeIn:   0.5,
+				FadeOut:  1,
+			},
+			fadeIn:  true,
+			fadeOut: true,
+		},
+		{
+			overlay: config.PipOverlayConfig{
+				Position:       "custom",
+				CustomPosition: config.PipPositionConfig{X: &x, Y: &y},
+				Size:           "320x180",
+				FadeIn:         0.5,
+			},
+		},
+	}
+
+	filter := buildPipFilter(placements, 6, 1920, 1080)
+
+	assert.Equal(t,
+		"[1:v]scale=480:270,setsar=1,pad=488:278:4:4:color=black,format=yuva420p,colorchannelmixer=aa=0.80,"+
+			"fade=t=in:st=0:d=0.50:alpha=1,fade=t=out:st=5.00:d=1.00:alpha=1[pip0];"+
+			"[0:v][pip0]overlay=1422:792:eof_action=pass[v0];"+
+			"[2:v]scale=320:180,setsar=1,format=yuva420p[pip1];"+
+			"[v0][pip1]overlay=40:60:eof_action=pass[out]",
+		filter)
+}
+
+func TestVideoService_planPipPlacements_ContinuesVideoAcrossSlides(t *testing.T) {
+	executor := newFakeCommandExecutor(
+		expectedCommand{Name: "ffprobe", Contains: []string{"video_1.mp4"}, Result: newCommandResult("4.0\n", "")},
+		expectedCommand{Name: "ffprobe", Contains: []string{"video_2.mp4"}, Result: newCommandResult("3.5\n", "")},
+		expectedCommand{Name: "ffprobe", Contains: []string{"video_4.mp4"}, Result: newCommandResult("2.0\n", "")},
+	)
+	service := NewVideoServiceWithExecutor(afero.NewMemMapFs(), &mockLogger{}, executor)
+	service.SetPip(&config.PipConfig{
+		Enabled:  true,
+		Overlays: []config.PipOverlayConfig{{Slides: []interface{}{uint64(1), uint64(2), uint64(4)}, Video: "/media/webcam.mp4"}},
+	})
+
+	videoFiles := []string{"/tmp/video_0.mp4", "/tmp/video_1.mp4", "/tmp/video_2.mp4", "/tmp/video_3.mp4", "/tmp/video_4.mp4"}
+	placements, durations, err := service.planPipPlacements(context.Background(), videoFiles)
+	require.NoError(t, err)
+	executor.AssertDone(t)
+
+	require.Len(t, placements, 3)
+	assert.Equal(t, 0.0, placements[1][0].start)
+	assert.Equal(t, 4.0, placements[2][0].start)
+	assert.Equal(t, 7.5, placements[4][0].start)
+	assert.True(t, placements[1][0].fadeIn)
+	assert.False(t, placements[1][0].fadeOut)
+	assert.False(t, placements[2][0].fadeIn)
+	assert.True(t, placements[2][0].fadeOut)
+	assert.True(t, placements[4][0].fadeIn)
+	assert.True(t, placements[4][0].fadeOut)
+	assert.Equal(t, 3.5, durations[2])
+}
+
+func TestVideoService_applyPipOverlays_RendersAndCaches(t *testing.T) {
+	fs := afero.NewMemMapFs()
+	tempDir := testPath("out", ".temp")
+	segment := testPath("out", ".temp", "video_0.mp4")
+	webcam := testPath("media", "webcam.mp4")
+	pipPath := testPath("out", ".temp", "pip_0.mp4")
+	require.NoError(t, writeTestFile(fs, segment, "segment"))
+	require.NoError(t, writeTestFile(fs, webcam, "webcam"))
+
+	render := func() *fakeCommandExecutor {
+		return newFakeCommandExecutor(
+			expectedCommand{Name: "ffprobe", Contains: []string{segment}, Result: newCommandResult("5.0\n", "")},
+			expectedCommand{
+				Name:     "ffmpeg",
+				Contains: []string{"-i " + segment + " -i " + webcam, "overlay=1526:854:eof_action=pass[out]", "-map 0:a", "-c:a copy", pipPath},
+				Run: func(_ string, _ []string) {
+					_ = writeTestFile(fs, pipPath, "overlaid")
+				},
+			},
+		)
+	}
+
+	pip := &config.PipConfig{
+		Enabled:  true,
+		Overlays: []config.PipOverlayConfig{{Slides: "0", Video: webcam, Size: "20%"}},
+	}
+
+	executor := render()
+	service := NewVideoServiceWithExecutor(fs, &mockLogger{}, executor)
+	service.SetPip(pip)
+	videoFiles := []string{segment}
+	require.NoError(t, service.applyPipOverlays(context.Background(), videoFiles, tempDir, 1920, 1080))
+	executor.AssertDone(t)
+	assert.Equal(t, []string{pipPath}, videoFiles)
+
+	// Same settings: only the duration probe runs.
+	cachedExecutor := newFakeCommandExecutor(
+		expectedCommand{Name: "ffprobe", Contains: []string{segment}, Result: newCommandResult("5.0\n", "")},
+	)
+	service = NewVideoServiceWithExecutor(fs, &mockLogger{}, cachedExecutor)
+	service.SetPip(pip)
+	videoFiles = []string{segment}
+	require.NoError(t, service.applyPipOverlays(context.Background(), videoFiles, tempDir, 1920, 1080))
+	cachedExecutor.AssertDone(t)
+	assert.Equal(t, []string{pipPath}, videoFiles)
+
+	// Changed overlay settings invalidate the cached segment.
+	pip.Overlays[0].Position = "top-left"
+	executor = newFakeCommandExecutor(
+		expectedCommand{Name: "ffprobe", Contains: []string{segment}, Result: newCommandResult("5.0\n", "")},
+		expectedCommand{Name: "ffmpeg", Contains: []string{"overlay=10:10:eof_action=pass[out]"}},
+	)
+	service = NewVideoServiceWithExecutor(fs, &mockLogger{}, executor)
+	service.SetPip(pip)
+	require.NoError(t, service.applyPipOverlays(context.Background(), []string{segment}, tempDir, 1920, 1080))
+	executor.AssertDone(t)
+}
@@ -18,33 +18,35 @@ import (
 // PostProcessService applies optional post-render features such as subtitles,
 // audio mixing, intro/outro clips, metadata, chapters, thumbnails, and exports.
 type PostProcessService struct {
-	fs              afero.Fs
-	logger          interfaces.Logger
-	commandExecutor interfaces.CommandExecutor
-	audioMixer      *AudioMixer
-	subtitleService *SubtitleService
-	exportService   *ExportService
+	fs               afero.Fs
+	logger           interfaces.Logger
+	commandExecutor  interfaces.CommandExecutor
+	audioMixer       *AudioMixer
+	subtitleService  *SubtitleService
+	alignmentService *AlignmentService
+	exportService    *ExportService
 }
 
 // PostProcessRequest describes the artifacts and configuration for one language render.
 type PostProcessRequest struct {
-	RootDir        string
-	OutputDir      string
-	BaseName       string
-	Lang           string
-	MasterVideo    string
-	Slides         []string
-	Texts          []string
-	AudioPaths     []string
-	MediaAlignment string
-	Output         config.OutputConfig
-	Encoding       config.EncodingConfig
-	Audio          config.AudioConfig
-	Subtitles      config.SubtitlesConfig
-	Intro          config.IntroConfig
-	Outro          config.OutroConfig
-	Metadata       config.MetadataConfig
-	Chapters       config.ChaptersConfig
+	RootDir     string
+	OutputDir   string
+	BaseName    string
+	Lang        string
+	MasterVideo string
+	Slides      []string
+	Texts       []string
+	AudioPaths  []string
+	CacheDir    string // language cache directory; aligned subtitle timings are cached here
+	Timing      config.TimingConfig
+	Output      config.OutputConfig
+	Encoding    config.EncodingConfig
+	Audio       config.AudioConfig
+	Subtitles   config.SubtitlesConfig
+	Intro       config.IntroConfig
+	Outro       config.OutroConfig
+	Metadata    config.MetadataConfig
+	Chapters    config.ChaptersConfig
 }
 
 // PostProcessResult summarizes the emitted artifacts for a language render.
@@ -53,6 +55,8 @@ type PostProcessResult struct {
 	ExportedPaths     []string
 	SubtitlePaths     []string
 	ThumbnailPath     string
+	AudioDurations    []float64 // narration length per slide, in seconds
+	SegmentDurations  []float64 // on-screen length per slide, in seconds
 }
 
 type edgeClipConfig struct {
@@ -75,12 +79,13 @@ func NewPostProcessServiceWithExecutor(fs afero.Fs, logger interfaces.Logger, ex
 	}
 
 	return &PostProcessService{
-		fs:              fs,
-		logger:          logger,
-		commandExecutor: executor,
-		audioMixer:      NewAudioMixerWithExecutor(fs, logger, executor),
-		subtitleService: NewSubtitleServiceWithExecutor(fs, logger, executor),
-		exportService:   NewExportServiceWithExecutor(fs, logger, executor),
+		fs:               fs,
+		logger:           logger,
+		commandExecutor:  executor,
+		audioMixer:       NewAudioMixerWithExecutor(fs, logger, executor),
+		subtitleService:  NewSubtitleServiceWithExecutor(fs, logger, executor),
+		alignmentService: NewAlignmentServiceWithExecutor(fs, logger, executor),
+		exportService:    NewExportServiceWithExecutor(fs, logger, executor),
 	}
 }
 
@@ -102,10 +107,11 @@ func (s *PostProcessService) Run(ctx context.Context, req PostProcessRequest) (P
 		}
 	}()
 
-	audioDurations, segmentDurations, err := s.computeTimelineDurations(ctx, req.Slides, req.AudioPaths, req.MediaAlignment)
+	narrationTimings, segmentDurations, err := s.computeTimeline(ctx, req.Slides, req.AudioPaths, req.Timing)
 	if err != nil {
 		return PostProcessResult{}, err
 	}
+	audioDurations := narrationDurations(narrationTimings)
 	slideStarts := cumulativeDurations(segmentDurations)
 
 	workingVideo := req.MasterVideo
@@ -129,14 +135,14 @@ func (s *PostProcessService) Run(ctx context.Context, req PostProcessRequest) (P
 		return PostProcessResult{}, err
 	}
 
-	subtitlePaths, subtitleSRT, err := s.generateSubtitles(req, audioDurations, introDuration)
+	subtitlePaths, subtitleASS, err := s.generateSubtitles(ctx, req, narrationTimings, slideStarts, introDuration)
 	if err != nil {
 		return PostProcessResult{}, err
 	}
-	if req.Subtitles.Enabled && req.Subtitles.BurnIn && subtitleSRT != "" {
+	if req.Subtitles.Enabled && req.Subtitles.BurnIn && subtitleASS != "" {
 		burnedPath := filepath.Join(tempDir, req.BaseName+".burned.mp4")
 		tempFiles = append(tempFiles, burnedPath)
-		if err := s.subtitleService.BurnSubtitles(ctx, workingVideo, subtitleSRT, burnedPath, req.Subtitles); err != nil {
+		if err := s.subtitleService.BurnSubtitles(ctx, workingVideo, subtitleASS, burnedPath, req.Subtitles); err != nil {
 			return PostProcessResult{}, err
 		}
 		workingVideo = burnedPath
@@ -180,6 +186,8 @@ func (s *PostProcessService) Run(ctx context.Context, req PostProcessRequest) (P
 		ExportedPaths:     exported,
 		SubtitlePaths:     subtitlePaths,
 		ThumbnailPath:     thumbnailPath,
+		AudioDurations:    audioDurations,
+		SegmentDurations:  segmentDurations,
 	}, nil
 }
 
@@ -249,27 +257,40 @@ func (s *PostProcessService) applyAudioPostProcessing(
 	return workingVideo, nil
 }
 
-func (s *PostProcessService) generateSubtitles(req PostProcessRequest, audioDurations []float64, introOffset float64) ([]string, string, error) {
+func (s *PostProcessService) generateSubtitles(ctx context.Context, req PostProcessRequest, timings []slideTiming, slideStarts []float64, introOffset float64) ([]string, string, error) {
 	if !req.Subtitles.Enabled || !subtitleLanguageEnabled(req.Subtitles, req.Lang) {
 		return nil, "", nil
 	}
 
 	segments := make([]SubtitleSegment, 0, len(req.Texts))
-	currentTime := introOffset
 	for index, text := range req.Texts {
-		duration := audioDurations[index]
-		if duration < 0 {
-			duration = 0
+		duration := max(0, timings[index].narration)
+		if strings.TrimSpace(text) == "" || duration <= 0 {
+			continue
+		}
+
+		cues, err := s.alignedCues(ctx, req, index, timings[index])
+		if err != nil {
+			return nil, "", err
 		}
-		if strings.TrimSpace(text) != "" && duration > 0 {
+		if len(cues) == 0 {
+			cues = []SubtitleSegment{{StartTime: 0, EndTime: duration, Text: text}}
+		}
+
+		startTime := introOffset + slideStarts[index]
+		for _, cue := range cues {
+			words := make([]interfaces.WordTimestamp, len(cue.Words))
+			for i, word := range cue.Words {
+				words[i] = interfaces.WordTimestamp{Word: word.Word, Start: startTime + word.Start, End: startTime + word.End}
+			}
 			segments = append(segments, SubtitleSegment{
 				Index:     len(segments) + 1,
-				StartTime: currentTime,
-				EndTime:   currentTime + duration,
-				Text:      prepareSubtitleText(s.subtitleService, text, req.Subtitles.Timing),
+				StartTime: startTime + cue.StartTime,
+				EndTime:   startTime + cue.EndTime,
+				Text:      prepareSubtitleText(s.subtitleService, cue.Text, req.Subtitles.Timing),
+				Words:     words,
 			})
 		}
-		currentTime += duration
 	}
 
 	if len(segments) == 0 {
@@ -278,13 +299,17 @@ func (s *PostProcessService) generateSubtitles(req PostProcessRequest, audioDura
 
 	srtPath := filepath.Join(req.OutputDir, req.BaseName+".srt")
 	vttPath := filepath.Join(req.OutputDir, req.BaseName+".vtt")
+	assPath := filepath.Join(req.OutputDir, req.BaseName+".ass")
 	if err := s.subtitleService.GenerateSRT(segments, srtPath); err != nil {
 		return nil, "", err
 	}
 	if err := s.subtitleService.GenerateVTT(segments, vttPath); err != nil {
 		return nil, "", err
 	}
-	return []string{srtPath, vttPath}, srtPath, nil
+	if err := s.subtitleService.GenerateASS(segments, assPath, req.Subtitles); err != nil {
+		return nil, "", err
+	}
+	return []string{srtPath, vttPath, assPath}, assPath, nil
 }
 
 func (s *PostProcessService) generateThumbnail(
@@ -392,23 +417,85 @@ func (s *PostProcessService) materializeOutput(
 	return moveOrCopyWithinFS(s.fs, currentPath, outputPath)
 }
 
-func (s *PostProcessService) computeTimelineDurations(ctx context.Context, slides, audioPaths []string, mediaAlignment string) ([]float64, []float64, error) {
-	audioDurations := make([]float64, len(audioPaths))
+// alignedCues returns the subtitle cues of a slide timed from the word alignment of its narration,
+// relative to the slide start, or nil when no alignment is available. Word times follow the
+// narration speed, and words cut at the maximum slide duration are dropped.
+func (s *PostProcessService) alignedCues(ctx context.Context, req PostProcessRequest, index int, timing slideTiming) ([]SubtitleSegment, error) {
+	if index >= len(req.AudioPaths) || req.AudioPaths[index] == "" {
+		return nil, nil
+	}
+
+	aligned, err := s.alignmentService.Align(ctx, req.AudioPaths[index], req.Texts[index], req.Lang, req.Subtitles.Alignment, req.CacheDir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to align subtitles for slide %d: %w", index, err)
+	}
+
+	speed := timing.speed
+	if speed <= 0 {
+		speed = 1
+	}
+	var words []interfaces.WordTimestamp
+	for _, word := range alignDisplayWords(req.Texts[index], aligned) {
+		word.Start /= speed
+		word.End = min(word.End/speed, timing.narration)
+		if word.Start >= timing.narration {
+			break
+		}
+		words = append(words, word)
+	}
+	if len(words) == 0 {
+		return nil, nil
+	}
+	return s.subtitleService.CreateSegmentsFromWords(words, req.Subtitles.Timing, timing.narration), nil
+}
+
+// computeTimelineDurations returns the narration and on-screen length of every slide after timing
+// settings are applied, matching how VideoService renders the segments.
+func (s *PostProcessService) computeTimelineDurations(ctx context.Context, slides, audioPaths []string, timing config.TimingConfig) ([]float64, []float64, error) {
+	timings, segmentDurations, err := s.computeTimeline(ctx, slides, audioPaths, timing)
+	if err != nil {
+		return nil, nil, err
+	}
+	return narrationDurations(timings), segmentDurations, nil
+}
+
+// narrationDurations returns the narration length of every slide.
+func narrationDurations(timings []slideTiming) []float64 {
+	durations := make([]float64, len(timings))
+	for i, timing := range timings {
+		durations[i] = timing.narration
+	}
+	return durations
+}
+
+// computeTimeline plans the narration timing of every slide and returns it with the on-screen
+// length of each slide.
+func (s *PostProcessService) computeTimeline(ctx context.Context, slides, audioPaths []string, timing config.TimingConfig) ([]slideTiming, []float64, error) {
+	timings := make([]slideTiming, len(slides))
 	segmentDurations := make([]float64, len(slides))
 
 	for index := range slides {
-		audioDuration, err := s.getDuration(ctx, audioPaths[index])
-		if err != nil {
-			return nil, nil, fmt.Errorf("failed to inspect narration duration for slide %d: %w", index, err)
+		narration := 0.0
+		if audioPaths[index] != "" {
+			audioDuration, err := s.getDuration(ctx, audioPaths[index])
+			if err != nil {
+				return nil, nil, fmt.Errorf("failed to inspect narration duration for slide %d: %w", index, err)
+			}
+			narration = audioDuration
 		}
-		audioDurations[index] = audioDuration
 
 		isVideo, err := s.isVideoFile(ctx, slides[index])
 		if err != nil {
 			return nil, nil, fmt.Errorf("failed to inspect slide %d: %w", index, err)
 		}
-		if !isVideo || strings.EqualFold(mediaAlignment, config.MediaAlignmentSlide) {
-			segmentDurations[index] = audioDuration
+		plan := planSlideTiming(timing, index, isVideo, narration)
+		timings[index] = plan
+		if !isVideo {
+			segmentDurations[index] = plan.duration
+			continue
+		}
+		if strings.EqualFold(timing.MediaAlignment, config.MediaAlignmentSlide) && audioPaths[index] != "" {
+			segmentDurations[index] = plan.narration
 			continue
 		}
 
@@ -419,7 +506,7 @@ func (s *PostProcessService) computeTimelineDurations(ctx context.Context, slide
 		segmentDurations[index] = videoDuration
 	}
 
-	return audioDurations, segmentDurations, nil
+	return timings, segmentDurations, nil
 }
 
 func (s *PostProcessService) applyEdgeClip(
@@ -46,15 +46,15 @@ func TestPostProcessService_Run_Smoke(t *testing.T) {
 
 	service := NewPostProcessService(afero.NewOsFs(), &mockLogger{})
 	result, err := service.Run(context.Background(), PostProcessRequest{
-		RootDir:        tempDir,
-		OutputDir:      outputDir,
-		BaseName:       "smoke",
-		Lang:           "en",
-		MasterVideo:    masterVideo,
-		Slides:         []string{slide1, slide2},
-		Texts:          []string{"Smoke subtitle one", "Smoke subtitle two"},
-		AudioPaths:     []string{audio1, audio2},
-		MediaAlignment: config.MediaAlignmentSlide,
+		RootDir:     tempDir,
+		OutputDir:   outputDir,
+		BaseName:    "smoke",
+		Lang:        "en",
+		MasterVideo: masterVideo,
+		Slides:      []string{slide1, slide2},
+		Texts:       []string{"Smoke subtitle one", "Smoke subtitle two"},
+		AudioPaths:  []string{audio1, audio2},
+		Timing:      config.TimingConfig{MediaAlignment: config.MediaAlignmentSlide},
 		Output: config.OutputConfig{
 			Format:  "mp4",
 			Quality: "medium",
@@ -83,15 +83,15 @@ func TestPostProcessServiceRun_GeneratesArtifactsAndExports(t *testing.T) {
 	service := NewPostProcessServiceWithExecutor(fs, logger, executor)
 
 	result, err := service.Run(context.Background(), PostProcessRequest{
-		RootDir:        rootDir,
-		OutputDir:      outputDir,
-		BaseName:       "output-en",
-		Lang:           "en",
-		MasterVideo:    masterVideo,
-		Slides:         slides,
-		Texts:          []string{"Welcome to the demo", "Thanks for watching"},
-		AudioPaths:     audioPaths,
-		MediaAlignment: config.MediaAlignmentSlide,
+		RootDir:     rootDir,
+		OutputDir:   outputDir,
+		BaseName:    "output-en",
+		Lang:        "en",
+		MasterVideo: masterVideo,
+		Slides:      slides,
+		Texts:       []string{"Welcome to the demo", "Thanks for watching"},
+		AudioPaths:  audioPaths,
+		Timing:      config.TimingConfig{MediaAlignment: config.MediaAlignmentSlide},
 		Output: config.OutputConfig{
 			Format:  "mp4",
 			Quality: "medium",
@@ -134,6 +134,7 @@ func TestPostProcessServiceRun_GeneratesArtifactsAndExports(t *testing.T) {
 	assert.Equal(t, []string{
 		testPath("test", "data", "out", "output-en.srt"),
 		testPath("test", "data", "out", "output-en.vtt"),
+		testPath("test", "data", "out", "output-en.ass"),
 	}, result.SubtitlePaths)
 	assert.Equal(t, testPath("test", "data", "out", "output-en-thumbnail.jpg"), result.ThumbnailPath)
 
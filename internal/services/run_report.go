@@ -0,0 +1,362 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"gocreator/internal/interfaces"
+
+	"github.com/spf13/afero"
+)
+
+// Cache stages counted in the run report.
+const (
+	CacheStageTranslation   = "translation"
+	CacheStageAudio         = "audio"
+	CacheStageSegment       = "video_segment"
+	CacheStagePicture       = "picture"
+	CacheStageFinalVideo    = "final_video"
+	CacheStageAlignment     = "alignment"
+	CacheStageTranscription = "transcription"
+)
+
+// API call kinds counted in the run report.
+const (
+	APICallTranslation   = "translation"
+	APICallTTS           = "tts"
+	APICallTranscription = "transcription"
+)
+
+// Run and language statuses used in the run report.
+const (
+	RunStatusSucceeded = "succeeded"
+	RunStatusFailed    = "failed"
+	RunStatusCancelled = "cancelled"
+	RunStatusPending   = "pending"
+)
+
+// RunReport is a machine-readable record of one create invocation: what was produced for each
+// language, how long slides run, how often caches hit, and how much work ffmpeg and the APIs did.
+// Attach it to the context with WithRunReport; services record into it as they run.
+type RunReport struct {
+	mu sync.Mutex
+
+	Status          string            `json:"status"`
+	Error           string            `json:"error,omitempty"`
+	StartedAt       time.Time         `json:"started_at"`
+	FinishedAt      time.Time         `json:"finished_at"`
+	DurationSeconds float64           `json:"duration_seconds"`
+	Languages       []*LanguageReport `json:"languages"`
+	Totals          RunTotals         `json:"totals"`
+	Warnings        []RunWarning      `json:"warnings"`
+}
+
+// LanguageReport describes the output of one language.
+type LanguageReport struct {
+	mu sync.Mutex
+
+	Lang            string                 `json:"lang"`
+	Status          string                 `json:"status"`
+	Error           string                 `json:"error,omitempty"`
+	DurationSeconds float64                `json:"duration_seconds"`
+	Outputs         LanguageOutputs        `json:"outputs"`
+	Slides          []SlideReport          `json:"slides"`
+	Cache           map[string]*CacheStats `json:"cache"`
+	APICalls        map[string]int         `json:"api_calls"`
+	FFmpeg          FFmpegStats            `json:"ffmpeg"`
+}
+
+// LanguageOutputs lists the files produced for one language.
+type LanguageOutputs struct {
+	Video     string   `json:"video,omitempty"`
+	Exports   []string `json:"exports,omitempty"`
+	Subtitles []string `json:"subtitles,omitempty"`
+	Thumbnail string   `json:"thumbnail,omitempty"`
+}
+
+// SlideReport describes one slide of a language render.
+type SlideReport struct {
+	Index            int     `json:"index"`
+	Slide            string  `json:"slide"`
+	Audio            string  `json:"audio,omitempty"`
+	NarrationSeconds float64 `json:"narration_seconds"`
+	DurationSeconds  float64 `json:"duration_seconds"`
+}
+
+// CacheStats counts cache lookups for one stage.
+type CacheStats struct {
+	Hits   int `json:"hits"`
+	Misses int `json:"misses"`
+}
+
+// FFmpegStats counts ffmpeg and ffprobe processes and the wall time they ran.
+type FFmpegStats struct {
+	Runs        int     `json:"runs"`
+	WallSeconds float64 `json:"wall_seconds"`
+}
+
+// RunTotals sums the per-language counters.
+type RunTotals struct {
+	Cache    map[string]*CacheStats `json:"cache"`
+	APICalls map[string]int         `json:"api_calls"`
+	FFmpeg   FFmpegStats            `json:"ffmpeg"`
+}
+
+// RunWarning is a warning logged during the run.
+type RunWarning struct {
+	Message string            `json:"message"`
+	Fields  map[string]string `json:"fields,omitempty"`
+}
+
+// NewRunReport starts a run report.
+func NewRunReport() *RunReport {
+	return &RunReport{
+		Status:    RunStatusPending,
+		StartedAt: time.Now(),
+		Languages: []*LanguageReport{},
+		Warnings:  []RunWarning{},
+	}
+}
+
+// Finish records the end of the run and computes the totals.
+func (r *RunReport) Finish(err error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.FinishedAt = time.Now()
+	r.DurationSeconds = r.FinishedAt.Sub(r.StartedAt).Seconds()
+	r.Status, r.Error = runStatus(err)
+
+	totals := RunTotals{Cache: map[string]*CacheStats{}, APICalls: map[string]int{}}
+	for _, lang := range r.Languages {
+		lang.mu.Lock()
+		for stage, stats := range lang.Cache {
+			total := totals.Cache[stage]
+			if total == nil {
+				total = &CacheStats{}
+				totals.Cache[stage] = total
+			}
+			total.Hits += stats.Hits
+			total.Misses += stats.Misses
+		}
+		for kind, count := range lang.APICalls {
+			totals.APICalls[kind] += count
+		}
+		totals.FFmpeg.Runs += lang.FFmpeg.Runs
+		totals.FFmpeg.WallSeconds += lang.FFmpeg.WallSeconds
+		lang.mu.Unlock()
+	}
+	r.Totals = totals
+}
+
+// Language returns the report of lang, adding it on first use.
+func (r *RunReport) Language(lang string) *LanguageReport {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, existing := range r.Languages {
+		if existing.Lang == lang {
+			return existing
+		}
+	}
+	report := &LanguageReport{
+		Lang:     lang,
+		Status:   RunStatusPending,
+		Slides:   []SlideReport{},
+		Cache:    map[string]*CacheStats{},
+		APICalls: map[string]int{},
+	}
+	r.Languages = append(r.Languages, report)
+	return report
+}
+
+// Logger wraps base so every warning it logs is also recorded in the report.
+func (r *RunReport) Logger(base interfaces.Logger) interfaces.Logger {
+	return &reportingLogger{Logger: base, report: r}
+}
+
+func (r *RunReport) addWarning(msg string, args []any) {
+	warning := RunWarning{Message: msg}
+	for i := 0; i+1 < len(args); i += 2 {
+		if warning.Fields == nil {
+			warning.Fields = make(map[string]string)
+		}
+		warning.Fields[fmt.Sprint(args[i])] = fmt.Sprint(args[i+1])
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.Warnings = append(r.Warnings, warning)
+}
+
+// WriteRunReport writes the report as indented JSON.
+func WriteRunReport(fs afero.Fs, path string, report *RunReport) error {
+	report.mu.Lock()
+	data, err := json.MarshalIndent(report, "", "  ")
+	report.mu.Unlock()
+	if err != nil {
+		return fmt.Errorf("failed to encode run report: %w", err)
+	}
+	if err := fs.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return fmt.Errorf("failed to create report directory: %w", err)
+	}
+	if err := afero.WriteFile(fs, path, append(data, '\n'), 0644); err != nil {
+		return fmt.Errorf("failed to write run report: %w", err)
+	}
+	return nil
+}
+
+func (l *LanguageReport) finish(err error, duration time.Duration) {
+	if l == nil {
+		return
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.Status, l.Error = runStatus(err)
+	l.DurationSeconds = duration.Seconds()
+}
+
+func (l *LanguageReport) setOutputs(outputs LanguageOutputs) {
+	if l == nil {
+		return
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.Outputs = outputs
+}
+
+func (l *LanguageReport) setSlides(slides []SlideReport) {
+	if l == nil {
+		return
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.Slides = slides
+}
+
+func runStatus(err error) (string, string) {
+	switch {
+	case err == nil:
+		return RunStatusSucceeded, ""
+	case errors.Is(err, context.Canceled):
+		return RunStatusCancelled, err.Error()
+	default:
+		return RunStatusFailed, err.Error()
+	}
+}
+
+type runReportKey struct{}
+
+type languageReportKey struct{}
+
+// WithRunReport attaches a run report to ctx.
+func WithRunReport(ctx context.Context, report *RunReport) context.Context {
+	return context.WithValue(ctx, runReportKey{}, report)
+}
+
+func runReportFrom(ctx context.Context) *RunReport {
+	report, _ := ctx.Value(runReportKey{}).(*RunReport)
+	return report
+}
+
+func withLanguageReport(ctx context.Context, report *LanguageReport) context.Context {
+	return context.WithValue(ctx, languageReportKey{}, report)
+}
+
+func languageReportFrom(ctx context.Context) *LanguageReport {
+	report, _ := ctx.Value(languageReportKey{}).(*LanguageReport)
+	return report
+}
+
+// recordCacheLookup counts a cache hit or miss for the language being processed.
+func recordCacheLookup(ctx context.Context, stage string, hit bool) {
+	report := languageReportFrom(ctx)
+	if report == nil {
+		return
+	}
+	report.mu.Lock()
+	defer report.mu.Unlock()
+
+	stats := report.Cache[stage]
+	if stats == nil {
+		stats = &CacheStats{}
+		report.Cache[stage] = stats
+	}
+	if hit {
+		stats.Hits++
+	} else {
+		stats.Misses++
+	}
+}
+
+// countAPIAttempts returns a context in which every request attempt the API client reports through
+// interfaces.NotifyAPIAttempt is counted, retries included. Call done after the request; it counts
+// one call when the client reported no attempts.
+func countAPIAttempts(ctx context.Context, kind string) (context.Context, func()) {
+	var attempts atomic.Int32
+	ctx = interfaces.WithAPIAttemptHook(ctx, func() {
+		attempts.Add(1)
+		recordAPICall(ctx, kind)
+	})
+	return ctx, func() {
+		if attempts.Load() == 0 {
+			recordAPICall(ctx, kind)
+		}
+	}
+}
+
+// recordAPICall counts a request sent to a remote translation, speech, or transcription API.
+func recordAPICall(ctx context.Context, kind string) {
+	report := languageReportFrom(ctx)
+	if report == nil {
+		return
+	}
+	report.mu.Lock()
+	defer report.mu.Unlock()
+	report.APICalls[kind]++
+}
+
+// recordCommand adds the wall time of an ffmpeg or ffprobe process; other commands are ignored.
+func recordCommand(ctx context.Context, name string, elapsed time.Duration) {
+	switch strings.TrimSuffix(strings.ToLower(filepath.Base(name)), ".exe") {
+	case "ffmpeg", "ffprobe":
+	default:
+		return
+	}
+
+	report := languageReportFrom(ctx)
+	if report == nil {
+		return
+	}
+	report.mu.Lock()
+	defer report.mu.Unlock()
+	report.FFmpeg.Runs++
+	report.FFmpeg.WallSeconds += elapsed.Seconds()
+}
+
+// reportingLogger copies warnings into a run report.
+type reportingLogger struct {
+	interfaces.Logger
+	report *RunReport
+	fields []any
+}
+
+func (l *reportingLogger) Warn(msg string, args ...any) {
+	l.Logger.Warn(msg, args...)
+	l.report.addWarning(msg, append(append([]any{}, l.fields...), args...))
+}
+
+func (l *reportingLogger) With(args ...any) interfaces.Logger {
+	return &reportingLogger{
+		Logger: l.Logger.With(args...),
+		report: l.report,
+		fields: append(append([]any{}, l.fields...), args...),
+	}
+}
@@ -0,0 +1,165 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"gocreator/internal/interfaces"
+	"gocreator/internal/mocks"
+
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRunReport_RecordsTranslationCacheAndAPICalls(t *testing.T) {
+	mockClient := new(mocks.MockOpenAIClient)
+	mockClient.On("ChatCompletion", mock.Anything, mock.Anything).Return("Bonjour", nil).Once()
+	service := NewTranslationService(mockClient, &mockLogger{})
+
+	report := NewRunReport()
+	ctx := withLanguageReport(WithRunReport(context.Background(), report), report.Language("fr"))
+
+	for range 2 {
+		translated, err := service.Translate(ctx, "Hello", "fr")
+		require.NoError(t, err)
+		assert.Equal(t, "Bonjour", translated)
+	}
+	report.Finish(nil)
+
+	fr := report.Languages[0]
+	assert.Equal(t, &CacheStats{Hits: 1, Misses: 1}, fr.Cache[CacheStageTranslation])
+	assert.Equal(t, 1, fr.APICalls[APICallTranslation])
+	assert.Equal(t, &CacheStats{Hits: 1, Misses: 1}, report.Totals.Cache[CacheStageTranslation])
+	assert.Equal(t, 1, report.Totals.APICalls[APICallTranslation])
+	mockClient.AssertExpectations(t)
+}
+
+func TestRunReport_CountsEveryAPIAttempt(t *testing.T) {
+	mockClient := new(mocks.MockOpenAIClient)
+	mockClient.On("ChatCompletion", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+		// The client retried once
+		ctx := args.Get(0).(context.Context)
+		interfaces.NotifyAPIAttempt(ctx)
+		interfaces.NotifyAPIAttempt(ctx)
+	}).Return("Bonjour", nil).Once()
+	service := NewTranslationService(mockClient, &mockLogger{})
+
+	report := NewRunReport()
+	ctx := withLanguageReport(WithRunReport(context.Background(), report), report.Language("fr"))
+
+	_, err := service.Translate(ctx, "Hello", "fr")
+	require.NoError(t, err)
+	report.Finish(nil)
+
+	assert.Equal(t, 2, report.Languages[0].APICalls[APICallTranslation])
+	assert.Equal(t, 2, report.Totals.APICalls[APICallTranslation])
+	mockClient.AssertExpectations(t)
+}
+
+func TestRunReport_RecordsOnlyFFmpegCommands(t *testing.T) {
+	report := NewRunReport()
+	ctx := withLanguageReport(context.Background(), report.Language("en"))
+
+	recordCommand(ctx, "ffmpeg", 2*time.Second)
+	recordCommand(ctx, "/usr/bin/ffprobe", 500*time.Millisecond)
+	recordCommand(ctx, "piper", time.Minute)
+	recordCommand(context.Background(), "ffmpeg", time.Minute)
+	report.Finish(nil)
+
+	assert.Equal(t, FFmpegStats{Runs: 2, WallSeconds: 2.5}, report.Languages[0].FFmpeg)
+	assert.Equal(t, FFmpegStats{Runs: 2, WallSeconds: 2.5}, report.Totals.FFmpeg)
+}
+
+func TestRunReport_LoggerCollectsWarnings(t *testing.T) {
+	report := NewRunReport()
+	logger := report.Logger(&mockLogger{}).With("lang", "de")
+
+	logger.Info("not a warning")
+	logger.Warn("Failed to save segment hash", "error", errors.New("disk full"))
+
+	require.Len(t, report.Warnings, 1)
+	assert.Equal(t, RunWarning{
+		Message: "Failed to save segment hash",
+		Fields:  map[string]string{"lang": "de", "error": "disk full"},
+	}, report.Warnings[0])
+}
+
+func TestRunReport_FinishAndWrite(t *testing.T) {
+	report := NewRunReport()
+	report.Language("en").finish(nil, 3*time.Second)
+	report.Language("fr").finish(fmt.Errorf("audio generation failed: %w", context.Canceled), time.Second)
+	report.Finish(context.Canceled)
+
+	assert.Equal(t, RunStatusCancelled, report.Status)
+	assert.Equal(t, RunStatusSucceeded, report.Languages[0].Status)
+	assert.Equal(t, 3.0, report.Languages[0].DurationSeconds)
+	assert.Equal(t, RunStatusCancelled, report.Languages[1].Status)
+
+	fs := afero.NewMemMapFs()
+	require.NoError(t, WriteRunReport(fs, "/out/run-report.json", report))
+
+	data, err := afero.ReadFile(fs, "/out/run-report.json")
+	require.NoError(t, err)
+	var decoded map[string]any
+	require.NoError(t, json.Unmarshal(data, &decoded))
+	assert.Equal(t, "cancelled", decoded["status"])
+	assert.Len(t, decoded["languages"], 2)
+}
+
+func TestVideoCreatorCreate_FillsRunReport(t *testing.T) {
+	fs := afero.NewMemMapFs()
+	mockText := new(mocks.MockTextProcessor)
+	mockTranslation := new(mocks.MockTranslator)
+	mockAudio := new(mocks.MockAudioGenerator)
+	mockVideo := new(mocks.MockVideoGenerator)
+	mockSlide := new(mocks.MockSlideLoader)
+	logger := &mockLogger{}
+
+	slidesDir := testPath("test", "data", "slides")
+	slides := []string{testPath("test", "data", "slides", "1.png")}
+	audioPaths := []string{testPath("test", "data", "cache", "en", "audio", "0.mp3")}
+	outputPath := testPath("test", "data", "out", "output-en.mp4")
+
+	require.NoError(t, fs.MkdirAll(slidesDir, 0755))
+	require.NoError(t, afero.WriteFile(fs, slides[0], []byte("slide1"), 0644))
+	require.NoError(t, afero.WriteFile(fs, testPath("test", "data", "slides", "1.txt"), []byte("Hello"), 0644))
+
+	mockSlide.On("LoadSlides", mock.Anything, slidesDir).Return(slides, nil).Once()
+	mockAudio.On("Generate", mock.Anything, "Hello", audioPaths[0]).Return(nil).Once()
+	mockVideo.On("GenerateFromSlides", mock.Anything, slides, audioPaths, outputPath).Return(nil).Once()
+
+	executor := newFakeCommandExecutor(
+		expectedCommand{Name: "ffprobe", Contains: []string{"format=duration", audioPaths[0]}, Result: newCommandResult("2.5\n", "")},
+		expectedCommand{Name: "ffprobe", Contains: []string{"stream=codec_type:format=duration", slides[0]}, Result: newCommandResult("codec_type=video\nduration=0\n", "")},
+	)
+	postProcessor := NewPostProcessServiceWithExecutor(fs, logger, executor)
+	creator := NewVideoCreatorWithPostProcessor(fs, mockText, mockTranslation, mockAudio, mockVideo, mockSlide, logger, postProcessor)
+
+	report := NewRunReport()
+	err := creator.Create(WithRunReport(context.Background(), report), VideoCreatorConfig{
+		RootDir:     testPath("test"),
+		InputLang:   "en",
+		OutputLangs: []string{"en"},
+	})
+	require.NoError(t, err)
+	executor.AssertDone(t)
+
+	require.Len(t, report.Languages, 1)
+	en := report.Languages[0]
+	assert.Equal(t, "en", en.Lang)
+	assert.Equal(t, RunStatusSucceeded, en.Status)
+	assert.Equal(t, LanguageOutputs{Video: outputPath}, en.Outputs)
+	assert.Equal(t, []SlideReport{{
+		Index:            0,
+		Slide:            slides[0],
+		Audio:            audioPaths[0],
+		NarrationSeconds: 2.5,
+		DurationSeconds:  2.5,
+	}}, en.Slides)
+}
@@ -0,0 +1,105 @@
+package services
+
+import (
+	"context"
+	"sync"
+
+	"gocreator/internal/config"
+)
+
+// Scheduler bounds how many external processes and API requests run at once. A single scheduler
+// is shared by every service so limits hold across all languages and slides of a run.
+type Scheduler struct {
+	ffmpeg chan struct{}
+	api    chan struct{}
+}
+
+var (
+	defaultScheduler      = NewScheduler(config.DefaultConcurrencyConfig().ResolveFFmpeg(), config.DefaultConcurrencyConfig().ResolveAPI())
+	defaultSchedulerMutex sync.RWMutex
+)
+
+// NewScheduler creates a scheduler with the given process and API request limits.
+func NewScheduler(ffmpegLimit, apiLimit int) *Scheduler {
+	return &Scheduler{
+		ffmpeg: make(chan struct{}, max(1, ffmpegLimit)),
+		api:    make(chan struct{}, max(1, apiLimit)),
+	}
+}
+
+// DefaultScheduler returns the process-wide scheduler.
+func DefaultScheduler() *Scheduler {
+	defaultSchedulerMutex.RLock()
+	defer defaultSchedulerMutex.RUnlock()
+	return defaultScheduler
+}
+
+// SetDefaultScheduler replaces the process-wide scheduler, typically once at startup.
+func SetDefaultScheduler(scheduler *Scheduler) {
+	defaultSchedulerMutex.Lock()
+	defer defaultSchedulerMutex.Unlock()
+	defaultScheduler = scheduler
+}
+
+// FFmpegLimit returns the maximum number of concurrent external processes.
+func (s *Scheduler) FFmpegLimit() int {
+	return cap(s.ffmpeg)
+}
+
+// APILimit returns the maximum number of concurrent API requests.
+func (s *Scheduler) APILimit() int {
+	return cap(s.api)
+}
+
+// AcquireFFmpeg waits for an external process slot. The returned function releases it.
+func (s *Scheduler) AcquireFFmpeg(ctx context.Context) (func(), error) {
+	return acquireSlot(ctx, s.ffmpeg)
+}
+
+// AcquireAPI waits for an API request slot. The returned function releases it.
+func (s *Scheduler) AcquireAPI(ctx context.Context) (func(), error) {
+	return acquireSlot(ctx, s.api)
+}
+
+func acquireSlot(ctx context.Context, slots chan struct{}) (func(), error) {
+	select {
+	case slots <- struct{}{}:
+		var once sync.Once
+		return func() { once.Do(func() { <-slots }) }, nil
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+// runBounded calls fn for every index in [0, count) using at most workers goroutines and returns
+// the per-index errors. Items not started before ctx is cancelled report the context error.
+func runBounded(ctx context.Context, count, workers int, fn func(idx int) error) []error {
+	errors := make([]error, count)
+	if count == 0 {
+		return errors
+	}
+
+	indexes := make(chan int)
+	var wg sync.WaitGroup
+	for w := 0; w < min(max(1, workers), count); w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for idx := range indexes {
+				if err := ctx.Err(); err != nil {
+					errors[idx] = err
+					continue
+				}
+				errors[idx] = fn(idx)
+			}
+		}()
+	}
+
+	for idx := 0; idx < count; idx++ {
+		indexes <- idx
+	}
+	close(indexes)
+	wg.Wait()
+
+	return errors
+}
@@ -0,0 +1,106 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestScheduler_BoundsConcurrentSlots(t *testing.T) {
+	scheduler := NewScheduler(2, 3)
+	assert.Equal(t, 2, scheduler.FFmpegLimit())
+	assert.Equal(t, 3, scheduler.APILimit())
+
+	var active, peak int32
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			release, err := scheduler.AcquireFFmpeg(context.Background())
+			require.NoError(t, err)
+			defer release()
+
+			current := atomic.AddInt32(&active, 1)
+			for {
+				previous := atomic.LoadInt32(&peak)
+				if current <= previous || atomic.CompareAndSwapInt32(&peak, previous, current) {
+					break
+				}
+			}
+			time.Sleep(2 * time.Millisecond)
+			atomic.AddInt32(&active, -1)
+		}()
+	}
+	wg.Wait()
+
+	assert.LessOrEqual(t, peak, int32(2))
+}
+
+func TestScheduler_AcquireHonorsContext(t *testing.T) {
+	scheduler := NewScheduler(1, 1)
+	release, err := scheduler.AcquireAPI(context.Background())
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err = scheduler.AcquireAPI(ctx)
+	assert.ErrorIs(t, err, context.Canceled)
+
+	release()
+	release() // releasing twice must not free a second slot
+	_, err = scheduler.AcquireAPI(context.Background())
+	require.NoError(t, err)
+}
+
+func TestRunBounded(t *testing.T) {
+	var active, peak int32
+	results := make([]int, 20)
+
+	errs := runBounded(context.Background(), len(results), 4, func(idx int) error {
+		current := atomic.AddInt32(&active, 1)
+		defer atomic.AddInt32(&active, -1)
+		for {
+			previous := atomic.LoadInt32(&peak)
+			if current <= previous || atomic.CompareAndSwapInt32(&peak, previous, current) {
+				break
+			}
+		}
+		time.Sleep(time.Millisecond)
+
+		results[idx] = idx * 2
+		if idx == 7 {
+			return errors.New("boom")
+		}
+		return nil
+	})
+
+	assert.LessOrEqual(t, peak, int32(4))
+	for idx, err := range errs {
+		assert.Equal(t, idx*2, results[idx])
+		if idx == 7 {
+			assert.EqualError(t, err, "boom")
+		} else {
+			assert.NoError(t, err)
+		}
+	}
+}
+
+func TestRunBounded_StopsAfterCancel(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	errs := runBounded(ctx, 3, 2, func(int) error {
+		t.Error("work should not start after cancellation")
+		return nil
+	})
+	for _, err := range errs {
+		assert.ErrorIs(t, err, context.Canceled)
+	}
+}
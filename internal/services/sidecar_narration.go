@@ -2,46 +2,88 @@ package services
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"path/filepath"
 	"regexp"
 	"strconv"
 	"strings"
-	"sync"
 
+	"gocreator/internal/config"
 	"gocreator/internal/interfaces"
 
+	"github.com/goccy/go-yaml"
 	"github.com/spf13/afero"
 )
 
+// frontMatterDelimiter opens and closes the optional YAML front-matter block of a text sidecar.
+const frontMatterDelimiter = "---"
+
 var (
 	pdfPageNarrationPattern           = regexp.MustCompile(`^(.*)-page-(\d+)$`)
 	supportedNarrationAudioExtensions = []string{".aac", ".flac", ".m4a", ".mp3", ".ogg", ".opus", ".wav"}
 )
 
+// sidecarFrontMatter holds the slide settings of a text sidecar's front-matter. They are merged
+// with the global configuration, so they follow the slide when slides are inserted or reordered.
+type sidecarFrontMatter struct {
+	Chapter      string                   `yaml:"chapter,omitempty"`       // chapter title starting at this slide
+	Effects      []config.EffectConfig    `yaml:"effects,omitempty"`       // effects applied to this slide only
+	TransitionIn *config.TransitionConfig `yaml:"transition_in,omitempty"` // transition from the previous slide
+	Duration     float64                  `yaml:"duration,omitempty"`      // explicit slide duration in seconds
+	Voice        string                   `yaml:"voice,omitempty"`         // TTS voice for this slide
+	PauseAfter   float64                  `yaml:"pause_after,omitempty"`   // seconds held after the narration
+}
+
+// textSidecar is a parsed text sidecar.
+type textSidecar struct {
+	path        string
+	frontMatter sidecarFrontMatter
+	text        string
+}
+
 func (vc *VideoCreator) resolveTextsForLanguage(
 	ctx context.Context,
 	inputLang string,
 	lang string,
 	slidesDir string,
 	slides []string,
-) ([]string, int, error) {
+	timing config.TimingConfig,
+) ([]string, []int, error) {
 	texts := make([]string, len(slides))
 	sourceTexts := make([]string, 0, len(slides))
 	sourceIndexes := make([]int, 0, len(slides))
 
 	for idx, slidePath := range slides {
+		if timing.ForSlide(idx).Silent {
+			continue
+		}
+
 		audioPath, found, err := vc.lookupAudioForLanguage(slidesDir, slidePath, inputLang, lang)
 		if err != nil {
-			return nil, 0, err
+			return nil, nil, err
 		}
 		if found && audioPath != "" {
+			if vc.transcriptionService == nil {
+				continue
+			}
+			// Prerecorded narration keeps its sidecar text, or is transcribed for subtitles
+			text, found, err := vc.lookupTextForLanguage(slidesDir, slidePath, inputLang, lang)
+			if err != nil {
+				return nil, nil, err
+			}
+			if !found {
+				if text, err = vc.transcribeSlide(ctx, slidesDir, slidePath, audioPath, lang); err != nil {
+					return nil, nil, err
+				}
+			}
+			texts[idx] = text
 			continue
 		}
 
 		text, found, err := vc.lookupTextForLanguage(slidesDir, slidePath, inputLang, lang)
 		if err != nil {
-			return nil, 0, err
+			return nil, nil, err
 		}
 		if found {
 			texts[idx] = text
@@ -52,9 +94,9 @@ func (vc *VideoCreator) resolveTextsForLanguage(
 			continue
 		}
 
-		sourceText, found, err := vc.lookupSourceText(slidesDir, slidePath, inputLang)
+		sourceText, found, err := vc.resolveSourceText(ctx, slidesDir, slidePath, inputLang)
 		if err != nil {
-			return nil, 0, err
+			return nil, nil, err
 		}
 		if !found {
 			continue
@@ -65,19 +107,22 @@ func (vc *VideoCreator) resolveTextsForLanguage(
 	}
 
 	if len(sourceTexts) == 0 {
-		return texts, 0, nil
+		return texts, nil, nil
 	}
 
 	translatedTexts, err := vc.translationService.TranslateBatch(ctx, sourceTexts, lang)
 	if err != nil {
-		return nil, 0, err
+		if errors.Is(err, ErrManualTranslationRequired) {
+			return nil, nil, missingTranslationSidecarsError(slidesDir, slides, sourceIndexes, lang)
+		}
+		return nil, nil, err
 	}
 
 	for i, slideIndex := range sourceIndexes {
 		texts[slideIndex] = translatedTexts[i]
 	}
 
-	return texts, len(sourceIndexes), nil
+	return texts, sourceIndexes, nil
 }
 
 func (vc *VideoCreator) resolveAudioForLanguage(
@@ -89,18 +134,25 @@ func (vc *VideoCreator) resolveAudioForLanguage(
 	slides []string,
 	texts []string,
 	audioDir string,
+	timing config.TimingConfig,
 ) ([]string, int, int, error) {
 	audioPaths := make([]string, len(slides))
 	type ttsJob struct {
-		index int
-		text  string
-		path  string
+		index     int
+		text      string
+		path      string
+		generator interfaces.AudioGenerator
 	}
 
 	ttsJobs := make([]ttsJob, 0, len(slides))
 	prerecordedCount := 0
+	var missing []error
 
 	for idx, slidePath := range slides {
+		if timing.ForSlide(idx).Silent {
+			continue
+		}
+
 		audioPath, found, err := vc.lookupAudioForLanguage(slidesDir, slidePath, inputLang, lang)
 		if err != nil {
 			return nil, 0, 0, err
@@ -112,16 +164,35 @@ func (vc *VideoCreator) resolveAudioForLanguage(
 		}
 
 		if strings.TrimSpace(texts[idx]) == "" {
-			return nil, 0, 0, fmt.Errorf("slide %s has no matching text or audio sidecar for language %s", slideNarrationLabel(slidePath), lang)
+			if _, silent := timing.SilentDuration(idx); silent {
+				// Shown for its configured duration without narration
+				continue
+			}
+			missing = append(missing, &SlideError{
+				Index: idx,
+				Slide: slidePath,
+				Err:   fmt.Errorf("slide %s has no matching text or audio sidecar for language %s", slideNarrationLabel(slidePath), lang),
+			})
+			continue
+		}
+
+		voice, err := vc.lookupSlideVoice(slidesDir, slidePath, inputLang, lang)
+		if err != nil {
+			return nil, 0, 0, err
 		}
 
 		ttsJobs = append(ttsJobs, ttsJob{
-			index: idx,
-			text:  texts[idx],
-			path:  filepath.Join(audioDir, fmt.Sprintf("%d.mp3", idx)),
+			index:     idx,
+			text:      texts[idx],
+			path:      filepath.Join(audioDir, fmt.Sprintf("%d.mp3", idx)),
+			generator: withSlideVoice(audioGenerator, voice),
 		})
 	}
 
+	if len(missing) > 0 {
+		return nil, 0, 0, errors.Join(missing...)
+	}
+
 	if len(ttsJobs) == 0 {
 		return audioPaths, prerecordedCount, 0, nil
 	}
@@ -130,30 +201,134 @@ func (vc *VideoCreator) resolveAudioForLanguage(
 		return nil, 0, 0, fmt.Errorf("failed to create audio cache directory: %w", err)
 	}
 
-	errors := make([]error, len(ttsJobs))
-	var wg sync.WaitGroup
+	jobErrors := runBounded(ctx, len(ttsJobs), DefaultScheduler().APILimit(), func(jobIndex int) error {
+		current := ttsJobs[jobIndex]
+		if err := current.generator.Generate(ctx, current.text, current.path); err != nil {
+			return &SlideError{
+				Index: current.index,
+				Slide: slides[current.index],
+				Err:   fmt.Errorf("failed to generate narration for slide %s: %w", slideNarrationLabel(slides[current.index]), err),
+			}
+		}
+		audioPaths[current.index] = current.path
+		return nil
+	})
+
+	if err := joinSlideErrors(ctx, jobErrors); err != nil {
+		return nil, 0, 0, err
+	}
+
+	return audioPaths, prerecordedCount, len(ttsJobs), nil
+}
 
-	for i, job := range ttsJobs {
-		wg.Add(1)
-		go func(jobIndex int, current ttsJob) {
-			defer wg.Done()
-			if err := audioGenerator.Generate(ctx, current.text, current.path); err != nil {
-				errors[jobIndex] = fmt.Errorf("failed to generate narration for slide %s: %w", slideNarrationLabel(slides[current.index]), err)
-				return
+// resolveSilentSlides marks every slide with a basename.silent marker as silent in the returned timing.
+// A marker may contain the slide duration in seconds; otherwise the configured timing applies.
+func (vc *VideoCreator) resolveSilentSlides(slidesDir string, slides []string, timing config.TimingConfig) (config.TimingConfig, error) {
+	for idx, slidePath := range slides {
+		matches, err := existingPaths(vc.fs, buildSilentMarkerPaths(slidesDir, slideNarrationBaseCandidates(slidePath)))
+		if err != nil {
+			return config.TimingConfig{}, err
+		}
+		if len(matches) == 0 {
+			continue
+		}
+		if len(matches) > 1 {
+			return config.TimingConfig{}, fmt.Errorf("multiple matching silent markers found in %s: %s", slidesDir, strings.Join(matches, ", "))
+		}
+
+		data, err := afero.ReadFile(vc.fs, matches[0])
+		if err != nil {
+			return config.TimingConfig{}, fmt.Errorf("failed to read silent marker %s: %w", matches[0], err)
+		}
+
+		slideTiming := config.SlideTimingConfig{Slide: idx, Silent: true}
+		if content := strings.TrimSpace(string(data)); content != "" {
+			seconds, err := strconv.ParseFloat(content, 64)
+			if err != nil || seconds <= 0 {
+				return config.TimingConfig{}, fmt.Errorf("silent marker %s must be empty or contain a positive duration in seconds, got %q", matches[0], content)
 			}
-			audioPaths[current.index] = current.path
-		}(i, job)
+			slideTiming.Duration = seconds
+		}
+		timing = timing.WithSlide(slideTiming)
 	}
 
-	wg.Wait()
+	return timing, nil
+}
+
+// applySidecarFrontMatter merges the front-matter of each slide's source text sidecar into cfg.
+// Durations and pauses become per-slide timing, chapter titles replace chapter markers for the
+// slide without turning chapters on, effects are scoped to the slide, and transition_in overrides the transition into it.
+// Voices are resolved per language when narration is generated.
+func (vc *VideoCreator) applySidecarFrontMatter(slidesDir string, slides []string, cfg VideoCreatorConfig) (VideoCreatorConfig, error) {
+	effects := append([]config.EffectConfig(nil), cfg.Effects...)
+	markers := append([]config.ChapterMarker(nil), cfg.Chapters.Markers...)
+	transitions := make(map[int]TransitionConfig, len(cfg.SlideTransitions))
+	for index, transition := range cfg.SlideTransitions {
+		transitions[index] = transition
+	}
 
-	for _, err := range errors {
+	for idx, slidePath := range slides {
+		sidecar, found, err := vc.lookupSourceSidecar(slidesDir, slidePath, cfg.InputLang)
 		if err != nil {
-			return nil, 0, 0, err
+			return cfg, err
+		}
+		if !found {
+			continue
+		}
+		frontMatter := sidecar.frontMatter
+
+		if frontMatter.Duration > 0 || frontMatter.PauseAfter > 0 {
+			cfg.Timing = cfg.Timing.WithSlide(config.SlideTimingConfig{
+				Slide:      idx,
+				Duration:   frontMatter.Duration,
+				PauseAfter: frontMatter.PauseAfter,
+			})
+		}
+
+		if title := strings.TrimSpace(frontMatter.Chapter); title != "" {
+			kept := markers[:0]
+			for _, marker := range markers {
+				if marker.Slide != idx {
+					kept = append(kept, marker)
+				}
+			}
+			markers = append(kept, config.ChapterMarker{Slide: idx, Title: title})
+		}
+
+		for _, effect := range frontMatter.Effects {
+			effect.Slides = []int{idx}
+			effects = append(effects, effect)
+		}
+
+		if frontMatter.TransitionIn != nil {
+			transitions[idx] = frontMatter.transitionIn()
 		}
 	}
 
-	return audioPaths, prerecordedCount, len(ttsJobs), nil
+	cfg.Effects = effects
+	cfg.Chapters.Markers = markers
+	cfg.SlideTransitions = transitions
+	return cfg, nil
+}
+
+// withSlideVoice returns generator narrating with voice instead of the configured one.
+func withSlideVoice(generator interfaces.AudioGenerator, voice string) interfaces.AudioGenerator {
+	service, ok := generator.(*AudioService)
+	if !ok || voice == "" {
+		return generator
+	}
+	speech := service.speech
+	speech.Voice = voice
+	return service.WithSpeechOptions(speech)
+}
+
+func missingTranslationSidecarsError(slidesDir string, slides []string, slideIndexes []int, lang string) error {
+	missing := make([]string, 0, len(slideIndexes))
+	for _, idx := range slideIndexes {
+		baseName := slideNarrationBaseCandidates(slides[idx])[0]
+		missing = append(missing, filepath.Join(slidesDir, fmt.Sprintf("%s.%s.txt", baseName, lang)))
+	}
+	return fmt.Errorf("%w; missing %s text sidecars: %s", ErrManualTranslationRequired, lang, strings.Join(missing, ", "))
 }
 
 func (vc *VideoCreator) lookupTextForLanguage(slidesDir, slidePath, inputLang, lang string) (string, bool, error) {
@@ -171,13 +346,74 @@ func (vc *VideoCreator) lookupTextForLanguage(slidesDir, slidePath, inputLang, l
 }
 
 func (vc *VideoCreator) lookupSourceText(slidesDir, slidePath, inputLang string) (string, bool, error) {
+	sidecar, found, err := vc.lookupSourceSidecar(slidesDir, slidePath, inputLang)
+	return sidecar.text, found, err
+}
+
+// resolveSourceText returns the source text of a slide, transcribing its prerecorded source
+// narration when it has no text sidecar and transcription is enabled.
+func (vc *VideoCreator) resolveSourceText(ctx context.Context, slidesDir, slidePath, inputLang string) (string, bool, error) {
+	text, found, err := vc.lookupSourceText(slidesDir, slidePath, inputLang)
+	if err != nil || found || vc.transcriptionService == nil {
+		return text, found, err
+	}
+
+	audioPath, found, err := vc.lookupAudioForLanguage(slidesDir, slidePath, inputLang, inputLang)
+	if err != nil || !found {
+		return "", false, err
+	}
+	text, err = vc.transcribeSlide(ctx, slidesDir, slidePath, audioPath, inputLang)
+	return text, err == nil, err
+}
+
+// transcribeSlide transcribes the prerecorded narration of a slide in lang and, when enabled,
+// saves the transcript as the slide's basename.<lang>.txt sidecar.
+func (vc *VideoCreator) transcribeSlide(ctx context.Context, slidesDir, slidePath, audioPath, lang string) (string, error) {
+	text, err := vc.transcriptionService.Transcribe(ctx, audioPath, lang)
+	if err != nil {
+		return "", err
+	}
+
+	if vc.writeTranscripts {
+		sidecarPath := buildTextCandidatePaths(slidesDir, slideNarrationBaseCandidates(slidePath)[:1], lang)[0]
+		if err := afero.WriteFile(vc.fs, sidecarPath, []byte(text+"\n"), 0644); err != nil {
+			return "", fmt.Errorf("failed to write transcript sidecar %s: %w", sidecarPath, err)
+		}
+		vc.logger.Info("Saved transcript sidecar", "path", sidecarPath)
+	}
+	return text, nil
+}
+
+func (vc *VideoCreator) lookupSourceSidecar(slidesDir, slidePath, inputLang string) (textSidecar, bool, error) {
 	baseNames := slideNarrationBaseCandidates(slidePath)
-	return vc.readPreferredTextSidecar(slidesDir, [][]string{
+	return vc.readPreferredSidecar(slidesDir, [][]string{
 		buildTextCandidatePaths(slidesDir, baseNames, inputLang),
 		buildGenericTextCandidatePaths(slidesDir, baseNames),
 	})
 }
 
+// lookupSlideVoice returns the front-matter voice for a slide in lang. The language's own sidecar
+// wins over the source sidecar, whose voice applies to every language.
+func (vc *VideoCreator) lookupSlideVoice(slidesDir, slidePath, inputLang, lang string) (string, error) {
+	if lang != inputLang {
+		sidecar, found, err := vc.readPreferredSidecar(slidesDir, [][]string{
+			buildTextCandidatePaths(slidesDir, slideNarrationBaseCandidates(slidePath), lang),
+		})
+		if err != nil {
+			return "", err
+		}
+		if found && sidecar.frontMatter.Voice != "" {
+			return sidecar.frontMatter.Voice, nil
+		}
+	}
+
+	sidecar, _, err := vc.lookupSourceSidecar(slidesDir, slidePath, inputLang)
+	if err != nil {
+		return "", err
+	}
+	return sidecar.frontMatter.Voice, nil
+}
+
 func (vc *VideoCreator) lookupAudioForLanguage(slidesDir, slidePath, inputLang, lang string) (string, bool, error) {
 	baseNames := slideNarrationBaseCandidates(slidePath)
 	groups := [][]string{
@@ -190,26 +426,98 @@ func (vc *VideoCreator) lookupAudioForLanguage(slidesDir, slidePath, inputLang,
 }
 
 func (vc *VideoCreator) readPreferredTextSidecar(slidesDir string, groups [][]string) (string, bool, error) {
+	sidecar, found, err := vc.readPreferredSidecar(slidesDir, groups)
+	return sidecar.text, found, err
+}
+
+func (vc *VideoCreator) readPreferredSidecar(slidesDir string, groups [][]string) (textSidecar, bool, error) {
 	for _, group := range groups {
 		matches, err := existingPaths(vc.fs, group)
 		if err != nil {
-			return "", false, err
+			return textSidecar{}, false, err
 		}
 		if len(matches) == 0 {
 			continue
 		}
 		if len(matches) > 1 {
-			return "", false, fmt.Errorf("multiple matching text sidecars found in %s: %s", slidesDir, strings.Join(matches, ", "))
+			return textSidecar{}, false, fmt.Errorf("multiple matching text sidecars found in %s: %s", slidesDir, strings.Join(matches, ", "))
 		}
 
 		data, err := afero.ReadFile(vc.fs, matches[0])
 		if err != nil {
-			return "", false, fmt.Errorf("failed to read text sidecar %s: %w", matches[0], err)
+			return textSidecar{}, false, fmt.Errorf("failed to read text sidecar %s: %w", matches[0], err)
 		}
-		return strings.TrimSpace(string(data)), true, nil
+		sidecar, err := parseTextSidecar(matches[0], data)
+		if err != nil {
+			return textSidecar{}, false, err
+		}
+		return sidecar, true, nil
 	}
 
-	return "", false, nil
+	return textSidecar{}, false, nil
+}
+
+// parseTextSidecar splits an optional front-matter block, delimited by "---" lines at the very top
+// of the file, from the narration text that follows it.
+func parseTextSidecar(path string, data []byte) (textSidecar, error) {
+	content := strings.ReplaceAll(strings.TrimPrefix(string(data), "\ufeff"), "\r\n", "\n")
+	lines := strings.Split(content, "\n")
+	if strings.TrimSpace(lines[0]) != frontMatterDelimiter {
+		return textSidecar{path: path, text: strings.TrimSpace(content)}, nil
+	}
+
+	for end := 1; end < len(lines); end++ {
+		if strings.TrimSpace(lines[end]) != frontMatterDelimiter {
+			continue
+		}
+
+		var frontMatter sidecarFrontMatter
+		if block := strings.Join(lines[1:end], "\n"); strings.TrimSpace(block) != "" {
+			if err := yaml.Unmarshal([]byte(block), &frontMatter); err != nil {
+				return textSidecar{}, fmt.Errorf("invalid front-matter in text sidecar %s: %w", path, err)
+			}
+		}
+		if err := frontMatter.validate(); err != nil {
+			return textSidecar{}, fmt.Errorf("invalid front-matter in text sidecar %s: %w", path, err)
+		}
+		return textSidecar{
+			path:        path,
+			frontMatter: frontMatter,
+			text:        strings.TrimSpace(strings.Join(lines[end+1:], "\n")),
+		}, nil
+	}
+
+	return textSidecar{}, fmt.Errorf("front-matter in text sidecar %s is not closed with %q", path, frontMatterDelimiter)
+}
+
+func (f sidecarFrontMatter) validate() error {
+	if f.Duration < 0 {
+		return fmt.Errorf("duration must not be negative, got %g", f.Duration)
+	}
+	if f.PauseAfter < 0 {
+		return fmt.Errorf("pause_after must not be negative, got %g", f.PauseAfter)
+	}
+	if f.TransitionIn != nil {
+		if err := f.transitionIn().Validate(); err != nil {
+			return fmt.Errorf("transition_in: %w", err)
+		}
+	}
+	return nil
+}
+
+// transitionIn converts the transition_in setting, filling a missing type or duration from the default transition.
+func (f sidecarFrontMatter) transitionIn() TransitionConfig {
+	transition := TransitionConfig{
+		Type:     TransitionType(strings.TrimSpace(f.TransitionIn.Type)),
+		Duration: f.TransitionIn.Duration,
+	}
+	if transition.Type == "" {
+		transition.Type = DefaultTransitionConfig().Type
+	}
+	if transition.Type != TransitionNone && transition.Duration == 0 {
+		transition.Duration = DefaultTransitionConfig().Duration
+	}
+	return transition
 }
 
 func (vc *VideoCreator) findPreferredAudioSidecar(groups [][]string) (string, bool, error) {
@@ -266,6 +574,14 @@ func buildGenericAudioCandidatePaths(slidesDir string, baseNames []string) []str
 	return paths
 }
 
+func buildSilentMarkerPaths(slidesDir string, baseNames []string) []string {
+	paths := make([]string, 0, len(baseNames))
+	for _, baseName := range baseNames {
+		paths = append(paths, filepath.Join(slidesDir, baseName+".silent"))
+	}
+	return paths
+}
+
 func existingPaths(fs afero.Fs, candidates []string) ([]string, error) {
 	matches := make([]string, 0, 1)
 	for _, candidate := range candidates {
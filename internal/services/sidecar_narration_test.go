@@ -1,10 +1,16 @@
 package services
 
 import (
+	"context"
 	"testing"
 
+	"gocreator/internal/config"
+	"gocreator/internal/interfaces"
+	"gocreator/internal/mocks"
+
 	"github.com/spf13/afero"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 )
 
@@ -40,3 +46,157 @@ func TestVideoCreatorLookupSourceText_FailsOnAmbiguousPDFAliases(t *testing.T) {
 	require.Error(t, err)
 	assert.Contains(t, err.Error(), "multiple matching text sidecars")
 }
+
+func TestVideoCreatorResolveSilentSlides(t *testing.T) {
+	fs := afero.NewMemMapFs()
+	creator := &VideoCreator{fs: fs}
+	slidesDir := testPath("test", "data", "slides")
+	slides := []string{
+		testPath("test", "data", "slides", "01-title.png"),
+		testPath("test", "data", "slides", "02-demo.mp4"),
+		testPath("test", "data", "cache", "pdf", "03-handout-page-0001.png"),
+	}
+
+	require.NoError(t, afero.WriteFile(fs, testPath("test", "data", "slides", "01-title.silent"), []byte("4.5\n"), 0644))
+	require.NoError(t, afero.WriteFile(fs, testPath("test", "data", "slides", "02-demo.silent"), nil, 0644))
+	require.NoError(t, afero.WriteFile(fs, testPath("test", "data", "slides", "03-handout-p1.silent"), nil, 0644))
+
+	timing, err := creator.resolveSilentSlides(slidesDir, slides, config.TimingConfig{
+		PerSlide: []config.SlideTimingConfig{{Slide: 1, Speed: 1.2}},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []config.SlideTimingConfig{
+		{Slide: 1, Speed: 1.2, Silent: true},
+		{Slide: 0, Duration: 4.5, Silent: true},
+		{Slide: 2, Silent: true},
+	}, timing.PerSlide)
+
+	require.NoError(t, afero.WriteFile(fs, testPath("test", "data", "slides", "01-title.silent"), []byte("soon"), 0644))
+	_, err = creator.resolveSilentSlides(slidesDir, slides, config.TimingConfig{})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "positive duration")
+}
+
+func TestVideoCreatorCreate_SilentSlideSkipsNarration(t *testing.T) {
+	fs := afero.NewMemMapFs()
+	mockText := new(mocks.MockTextProcessor)
+	mockTranslation := new(mocks.MockTranslator)
+	mockAudio := new(mocks.MockAudioGenerator)
+	mockVideo := new(mocks.MockVideoGenerator)
+	mockSlide := new(mocks.MockSlideLoader)
+
+	slidesDir := testPath("test", "data", "slides")
+	slides := []string{
+		testPath("test", "data", "slides", "1.png"),
+		testPath("test", "data", "slides", "2.png"),
+	}
+	audioPaths := []string{"", testPath("test", "data", "cache", "fr", "audio", "1.mp3")}
+	outputPath := testPath("test", "data", "out", "output-fr.mp4")
+
+	require.NoError(t, afero.WriteFile(fs, slides[0], []byte("slide1"), 0644))
+	require.NoError(t, afero.WriteFile(fs, slides[1], []byte("slide2"), 0644))
+	// The title card has text for the speaker notes, but the marker keeps it silent and untranslated.
+	require.NoError(t, afero.WriteFile(fs, testPath("test", "data", "slides", "1.txt"), []byte("Title notes"), 0644))
+	require.NoError(t, afero.WriteFile(fs, testPath("test", "data", "slides", "1.silent"), []byte("3"), 0644))
+	require.NoError(t, afero.WriteFile(fs, testPath("test", "data", "slides", "2.txt"), []byte("Hello"), 0644))
+
+	mockSlide.On("LoadSlides", mock.Anything, slidesDir).Return(slides, nil).Once()
+	mockTranslation.On("TranslateBatch", mock.Anything, []string{"Hello"}, "fr").Return([]string{"Bonjour"}, nil).Once()
+	mockAudio.On("Generate", mock.Anything, "Bonjour", audioPaths[1]).Return(nil).Once()
+	mockVideo.On("GenerateFromSlides", mock.Anything, slides, audioPaths, outputPath).Return(nil).Once()
+
+	creator := NewVideoCreator(fs, mockText, mockTranslation, mockAudio, mockVideo, mockSlide, &mockLogger{})
+	err := creator.Create(context.Background(), VideoCreatorConfig{
+		RootDir:     testPath("test"),
+		InputLang:   "en",
+		OutputLangs: []string{"fr"},
+	})
+
+	require.NoError(t, err)
+	mockTranslation.AssertExpectations(t)
+	mockAudio.AssertExpectations(t)
+	mockVideo.AssertExpectations(t)
+}
+
+func TestParseTextSidecar(t *testing.T) {
+	sidecar, err := parseTextSidecar("01-intro.txt", []byte("---\nchapter: Introduction\nduration: 6\nvoice: nova\npause_after: 1.5\ntransition_in:\n  type: wipeleft\neffects:\n  - type: vignette\n    intensity: 0.4\n---\n\nWelcome to the course.\n"))
+	require.NoError(t, err)
+	assert.Equal(t, "Welcome to the course.", sidecar.text)
+	assert.Equal(t, "Introduction", sidecar.frontMatter.Chapter)
+	assert.Equal(t, 6.0, sidecar.frontMatter.Duration)
+	assert.Equal(t, "nova", sidecar.frontMatter.Voice)
+	assert.Equal(t, 1.5, sidecar.frontMatter.PauseAfter)
+	require.Len(t, sidecar.frontMatter.Effects, 1)
+	assert.Equal(t, "vignette", sidecar.frontMatter.Effects[0].Type)
+	assert.Equal(t, TransitionConfig{Type: TransitionWipeleft, Duration: 0.5}, sidecar.frontMatter.transitionIn())
+
+	sidecar, err = parseTextSidecar("02-body.txt", []byte("  Plain narration -- no front-matter.\r\n"))
+	require.NoError(t, err)
+	assert.Equal(t, "Plain narration -- no front-matter.", sidecar.text)
+	assert.Equal(t, sidecarFrontMatter{}, sidecar.frontMatter)
+
+	_, err = parseTextSidecar("03-open.txt", []byte("---\nchapter: Open\nNarration"))
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "not closed")
+
+	_, err = parseTextSidecar("04-bad.txt", []byte("---\nduration: -2\n---\nNarration"))
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "04-bad.txt")
+	assert.Contains(t, err.Error(), "duration must not be negative")
+}
+
+func TestVideoCreatorApplySidecarFrontMatter(t *testing.T) {
+	fs := afero.NewMemMapFs()
+	creator := &VideoCreator{fs: fs}
+	slidesDir := testPath("test", "data", "slides")
+	slides := []string{
+		testPath("test", "data", "slides", "01-title.png"),
+		testPath("test", "data", "slides", "02-agenda.png"),
+	}
+
+	require.NoError(t, afero.WriteFile(fs, testPath("test", "data", "slides", "01-title.txt"), []byte("Welcome."), 0644))
+	require.NoError(t, afero.WriteFile(fs, testPath("test", "data", "slides", "02-agenda.txt"), []byte("---\nchapter: Agenda\nduration: 8\npause_after: 2\ntransition_in:\n  type: fade\n  duration: 1\neffects:\n  - type: vignette\n---\nToday we cover three topics."), 0644))
+
+	cfg, err := creator.applySidecarFrontMatter(slidesDir, slides, VideoCreatorConfig{
+		InputLang: "en",
+		Effects:   []config.EffectConfig{{Type: "film-grain", Slides: "all"}},
+		Chapters:  config.ChaptersConfig{Markers: []config.ChapterMarker{{Slide: 0, Title: "Start"}, {Slide: 1, Title: "Old title"}}},
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, config.SlideTimingConfig{Slide: 1, Duration: 8, PauseAfter: 2}, cfg.Timing.ForSlide(1))
+	assert.False(t, cfg.Chapters.Enabled, "a chapter title must not turn chapters on")
+	assert.Equal(t, []config.ChapterMarker{{Slide: 0, Title: "Start"}, {Slide: 1, Title: "Agenda"}}, cfg.Chapters.Markers)
+	require.Len(t, cfg.Effects, 2)
+	assert.Equal(t, "vignette", cfg.Effects[1].Type)
+	assert.Equal(t, []int{1}, cfg.Effects[1].ParseSlides(len(slides)))
+	assert.Equal(t, map[int]TransitionConfig{1: {Type: TransitionFade, Duration: 1}}, cfg.SlideTransitions)
+
+	text, found, err := creator.lookupSourceText(slidesDir, slides[1], "en")
+	require.NoError(t, err)
+	assert.True(t, found)
+	assert.Equal(t, "Today we cover three topics.", text)
+}
+
+func TestVideoCreatorLookupSlideVoice(t *testing.T) {
+	fs := afero.NewMemMapFs()
+	creator := &VideoCreator{fs: fs}
+	slidesDir := testPath("test", "data", "slides")
+	slide := testPath("test", "data", "slides", "01-title.png")
+
+	require.NoError(t, afero.WriteFile(fs, testPath("test", "data", "slides", "01-title.txt"), []byte("---\nvoice: onyx\n---\nWelcome."), 0644))
+	require.NoError(t, afero.WriteFile(fs, testPath("test", "data", "slides", "01-title.es.txt"), []byte("---\nvoice: nova\n---\nBienvenidos."), 0644))
+
+	for lang, want := range map[string]string{"en": "onyx", "es": "nova", "fr": "onyx"} {
+		voice, err := creator.lookupSlideVoice(slidesDir, slide, "en", lang)
+		require.NoError(t, err)
+		assert.Equal(t, want, voice, lang)
+	}
+
+	service := NewAudioService(fs, nil, nil, &mockLogger{}).WithSpeechOptions(interfaces.SpeechOptions{Model: "tts-1-hd", Voice: "alloy"})
+	voiced, ok := withSlideVoice(service, "onyx").(*AudioService)
+	require.True(t, ok)
+	assert.Equal(t, interfaces.SpeechOptions{Model: "tts-1-hd", Voice: "onyx"}, voiced.speech)
+	assert.Equal(t, "alloy", service.speech.Voice)
+	assert.Same(t, service, withSlideVoice(service, ""))
+}
@@ -61,6 +61,12 @@ func NewSlideService(fs afero.Fs, logger interfaces.Logger) *SlideService {
 }
 
 func defaultCommandRunner(ctx context.Context, name string, args ...string) ([]byte, error) {
+	release, err := DefaultScheduler().AcquireFFmpeg(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+
 	cmd := exec.CommandContext(ctx, name, args...)
 	return cmd.CombinedOutput()
 }
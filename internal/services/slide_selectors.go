@@ -0,0 +1,92 @@
+package services
+
+import (
+	"fmt"
+
+	"gocreator/internal/config"
+)
+
+// resolveSlideSelectors resolves the slide selectors in cfg against the loaded slides and rewrites
+// them as indices, so later stages keep working on positions. Names and globs follow a slide when
+// slides are inserted; a selector that matches nothing is a validation error.
+func resolveSlideSelectors(cfg VideoCreatorConfig, slides []string) (VideoCreatorConfig, error) {
+	effects := make([]config.EffectConfig, len(cfg.Effects))
+	for i, effect := range cfg.Effects {
+		if effect.Slides != nil {
+			indices, err := config.SelectSlides(effect.Slides, slides)
+			if err != nil {
+				return cfg, &config.ValidationError{Field: fmt.Sprintf("effects[%d].slides", i), Value: effect.Slides, Err: err}
+			}
+			effect.Slides = indices
+		}
+		effects[i] = effect
+	}
+	cfg.Effects = effects
+
+	if cfg.MultiView != nil && cfg.MultiView.Enabled {
+		multiView := *cfg.MultiView
+		multiView.Layouts = make([]config.LayoutConfig, len(cfg.MultiView.Layouts))
+		for i, layout := range cfg.MultiView.Layouts {
+			if layout.Slides != nil {
+				indices, err := config.SelectSlides(layout.Slides, slides)
+				if err != nil {
+					return cfg, &config.ValidationError{Field: fmt.Sprintf("multi_view.layouts[%d].slides", i), Value: layout.Slides, Err: err}
+				}
+				layout.Slides = indices
+			}
+			multiView.Layouts[i] = layout
+		}
+		cfg.MultiView = &multiView
+	}
+
+	if cfg.Pip.Enabled {
+		overlays := make([]config.PipOverlayConfig, len(cfg.Pip.Overlays))
+		for i, overlay := range cfg.Pip.Overlays {
+			if overlay.Slides != nil {
+				indices, err := config.SelectSlides(overlay.Slides, slides)
+				if err != nil {
+					return cfg, &config.ValidationError{Field: fmt.Sprintf("pip.overlays[%d].slides", i), Value: overlay.Slides, Err: err}
+				}
+				overlay.Slides = indices
+			}
+			overlays[i] = overlay
+		}
+		cfg.Pip.Overlays = overlays
+	}
+
+	soundEffects := make([]config.SoundEffectConfig, len(cfg.Audio.SoundEffects))
+	for i, effect := range cfg.Audio.SoundEffects {
+		if effect.SlideSelector != "" {
+			index, err := config.SelectSlide(effect.SlideSelector, slides)
+			if err != nil {
+				return cfg, &config.ValidationError{Field: fmt.Sprintf("audio.sound_effects[%d].slide", i), Value: effect.SlideSelector, Err: err}
+			}
+			effect.Slide, effect.SlideSelector = index, ""
+		}
+		soundEffects[i] = effect
+	}
+	cfg.Audio.SoundEffects = soundEffects
+
+	markers := make([]config.ChapterMarker, len(cfg.Chapters.Markers))
+	for i, marker := range cfg.Chapters.Markers {
+		if marker.SlideSelector != "" {
+			index, err := config.SelectSlide(marker.SlideSelector, slides)
+			if err != nil {
+				return cfg, &config.ValidationError{Field: fmt.Sprintf("chapters.markers[%d].slide", i), Value: marker.SlideSelector, Err: err}
+			}
+			marker.Slide, marker.SlideSelector = index, ""
+		}
+		markers[i] = marker
+	}
+	cfg.Chapters.Markers = markers
+
+	if thumbnail := cfg.Metadata.Thumbnail; thumbnail.SlideSelector != "" {
+		index, err := config.SelectSlide(thumbnail.SlideSelector, slides)
+		if err != nil {
+			return cfg, &config.ValidationError{Field: "metadata.thumbnail.slide_index", Value: thumbnail.SlideSelector, Err: err}
+		}
+		cfg.Metadata.Thumbnail.SlideIndex, cfg.Metadata.Thumbnail.SlideSelector = index, ""
+	}
+
+	return cfg, nil
+}
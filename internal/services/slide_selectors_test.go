@@ -0,0 +1,51 @@
+package services
+
+import (
+	"testing"
+
+	"gocreator/internal/config"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestResolveSlideSelectors(t *testing.T) {
+	slides := []string{
+		testPath("data", "slides", "01-title.png"),
+		testPath("data", "cache", "pdf", "02-handout-page-0001.png"),
+		testPath("data", "cache", "pdf", "02-handout-page-0002.png"),
+		testPath("data", "slides", "03-demo.mp4"),
+	}
+	multiView := &config.MultiViewConfig{
+		Enabled: true,
+		Layouts: []config.LayoutConfig{{Type: "split-horizontal", Slides: "03-demo"}},
+	}
+	cfg := VideoCreatorConfig{
+		Effects:   []config.EffectConfig{{Type: "vignette", Slides: "02-handout-page-*"}, {Type: "film-grain"}},
+		MultiView: multiView,
+		Pip:       config.PipConfig{Enabled: true, Overlays: []config.PipOverlayConfig{{Slides: "01-title..02-handout-page-0001", Video: "cam.mp4"}}},
+		Audio:     config.AudioConfig{SoundEffects: []config.SoundEffectConfig{{SlideSelector: "03-demo", File: "click.wav"}}},
+		Chapters:  config.ChaptersConfig{Enabled: true, Markers: []config.ChapterMarker{{SlideSelector: "02-handout-page-0001", Title: "Handout"}, {Slide: 0, Title: "Start"}}},
+		Metadata:  config.MetadataConfig{Thumbnail: config.ThumbnailConfig{Enabled: true, SlideSelector: "03-demo"}},
+	}
+
+	resolved, err := resolveSlideSelectors(cfg, slides)
+	require.NoError(t, err)
+
+	assert.Equal(t, []int{1, 2}, resolved.Effects[0].Slides)
+	assert.Nil(t, resolved.Effects[1].Slides, "an effect without slides still applies to all of them")
+	assert.Equal(t, []int{3}, resolved.MultiView.Layouts[0].ParseSlides(len(slides)))
+	assert.Equal(t, "03-demo", multiView.Layouts[0].Slides, "the caller's multi-view config is not modified")
+	assert.Equal(t, []int{0, 1}, resolved.Pip.Overlays[0].ParseSlides(len(slides)))
+	assert.Equal(t, config.SoundEffectConfig{Slide: 3, File: "click.wav"}, resolved.Audio.SoundEffects[0])
+	assert.Equal(t, []config.ChapterMarker{{Slide: 1, Title: "Handout"}, {Slide: 0, Title: "Start"}}, resolved.Chapters.Markers)
+	assert.Equal(t, 3, resolved.Metadata.Thumbnail.SlideIndex)
+
+	cfg.Chapters.Markers[0].SlideSelector = "02-handout-page-0003"
+	_, err = resolveSlideSelectors(cfg, slides)
+	require.Error(t, err)
+	var validationErr *config.ValidationError
+	require.ErrorAs(t, err, &validationErr)
+	assert.Equal(t, "chapters.markers[0].slide", validationErr.Field)
+	assert.Contains(t, err.Error(), "matches no slide")
+}
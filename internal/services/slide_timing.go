@@ -0,0 +1,188 @@
+package services
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"math"
+	"path/filepath"
+	"strings"
+
+	"gocreator/internal/config"
+
+	"github.com/spf13/afero"
+)
+
+// maxNarrationStretch is how much narration is sped up to fit a maximum or explicit slide duration
+// before the remainder is cut.
+const maxNarrationStretch = 1.5
+
+// slideTiming is the resolved timing of one slide.
+type slideTiming struct {
+	speed     float64 // tempo applied to the narration
+	narration float64 // narration length after speed changes and trimming, in seconds
+	duration  float64 // on-screen length of an image slide; zero for video slides
+}
+
+// planSlideTiming applies timing settings to a slide with narration seconds of narration (zero when it
+// has none). Speed applies to every slide; explicit, default, minimum, and maximum durations only to images.
+// A pause after narration extends a narrated image slide past its minimum, maximum, or explicit duration.
+// An image slide without narration or a configured duration gets a zero duration.
+func planSlideTiming(timing config.TimingConfig, index int, isVideo bool, narration float64) slideTiming {
+	slideCfg := timing.ForSlide(index)
+	plan := slideTiming{speed: 1}
+	if slideCfg.Speed > 0 {
+		plan.speed = slideCfg.Speed
+	}
+	plan.narration = narration / plan.speed
+
+	if isVideo {
+		return plan
+	}
+
+	pause := 0.0
+	if narration > 0 {
+		pause = slideCfg.PauseAfter
+	}
+	switch {
+	case slideCfg.Duration > 0:
+		plan.duration = slideCfg.Duration
+	case narration == 0:
+		plan.duration, _ = timing.SilentDuration(index)
+	default:
+		plan.duration = plan.narration
+		if timing.MinSlideDuration > 0 {
+			plan.duration = math.Max(plan.duration, timing.MinSlideDuration)
+		}
+		if timing.MaxSlideDuration > 0 {
+			plan.duration = math.Min(plan.duration, timing.MaxSlideDuration)
+		}
+	}
+
+	if plan.narration > plan.duration {
+		plan.speed *= math.Min(plan.narration/plan.duration, maxNarrationStretch)
+		plan.narration = math.Min(narration/plan.speed, plan.duration)
+	}
+	plan.duration += pause
+	return plan
+}
+
+// SetTiming sets the slide timing configuration
+func (s *VideoService) SetTiming(timing config.TimingConfig) {
+	s.timing = timing
+}
+
+// prepareNarration returns the narration track each segment is rendered with. When timing settings
+// change a slide, its narration is re-timed, trimmed, or padded with silence (or generated as silence
+// when the slide has none) into a content-addressed file in segmentDir; other slides keep their audio.
+func (s *VideoService) prepareNarration(ctx context.Context, slides, audioPaths []string, segmentDir string) ([]string, error) {
+	if !s.timing.AdjustsSlides() {
+		return audioPaths, nil
+	}
+
+	prepared := make([]string, len(audioPaths))
+	errors := runBounded(ctx, len(slides), DefaultScheduler().FFmpegLimit(), func(idx int) error {
+		path, err := s.prepareSlideNarration(ctx, idx, slides[idx], audioPaths[idx], segmentDir)
+		if err != nil {
+			return &SlideError{Index: idx, Slide: slides[idx], Err: fmt.Errorf("failed to time narration for slide %d: %w", idx, err)}
+		}
+		prepared[idx] = path
+		return nil
+	})
+	if err := joinSlideErrors(ctx, errors); err != nil {
+		return nil, err
+	}
+	return prepared, nil
+}
+
+func (s *VideoService) prepareSlideNarration(ctx context.Context, idx int, slidePath, audioPath, segmentDir string) (string, error) {
+	narration := 0.0
+	if audioPath != "" {
+		var err error
+		if narration, err = s.getVideoDuration(ctx, audioPath); err != nil {
+			return "", fmt.Errorf("failed to get narration duration: %w", err)
+		}
+	}
+
+	isVideo, err := s.isVideoFile(ctx, slidePath)
+	if err != nil {
+		s.logger.Warn("Failed to check if file is video, treating as image", "path", slidePath, "error", err)
+		isVideo = false
+	}
+
+	plan := planSlideTiming(s.timing, idx, isVideo, narration)
+	if !isVideo && plan.duration == 0 {
+		return "", fmt.Errorf("slide has no narration and no configured duration")
+	}
+
+	length := plan.duration
+	if isVideo {
+		length = plan.narration
+		if audioPath == "" {
+			// A silent video slide still needs a track to mix with its own audio.
+			if length, err = s.getVideoDuration(ctx, slidePath); err != nil {
+				return "", fmt.Errorf("failed to get video duration: %w", err)
+			}
+		}
+	}
+	if audioPath != "" && plan.speed == 1 && length == narration {
+		return audioPath, nil
+	}
+
+	hasher := sha256.New()
+	if audioPath != "" {
+		audioData, err := afero.ReadFile(s.fs, audioPath)
+		if err != nil {
+			return "", fmt.Errorf("failed to read narration: %w", err)
+		}
+		hasher.Write(audioData)
+	}
+	fmt.Fprintf(hasher, "%.4f:%.4f:%.4f", plan.speed, plan.narration, length)
+	hash := hex.EncodeToString(hasher.Sum(nil))
+	outputPath := filepath.Join(segmentDir, fmt.Sprintf("narration_%s.wav", hash[:16]))
+
+	if outputComplete(s.fs, outputPath, hash) {
+		return outputPath, nil
+	}
+
+	var args []string
+	if audioPath == "" {
+		args = []string{"-y", "-f", "lavfi", "-i", "anullsrc=r=44100:cl=stereo"}
+	} else {
+		// -t cuts narration that is still too long and apad fills the rest of the slide with silence.
+		filters := append(atempoFilters(plan.speed), "apad")
+		args = []string{"-y", "-i", audioPath, "-af", strings.Join(filters, ",")}
+	}
+	args = append(args, "-t", fmt.Sprintf("%.3f", length), "-c:a", "pcm_s16le", outputPath)
+
+	s.logger.Debug("Timing narration", "slide", idx, "command", formatCommand("ffmpeg", args...))
+
+	discardPartialOutput(s.fs, outputPath)
+	result, err := s.commandExecutor.Run(ctx, "ffmpeg", args...)
+	if err != nil {
+		discardPartialOutput(s.fs, outputPath)
+		return "", fmt.Errorf("ffmpeg error: %w, stderr: %s", err, string(result.Stderr))
+	}
+	if err := markOutputComplete(s.fs, outputPath, hash); err != nil {
+		return "", err
+	}
+	return outputPath, nil
+}
+
+// atempoFilters splits a tempo change into atempo stages within the 0.5-2.0 range every ffmpeg accepts.
+func atempoFilters(speed float64) []string {
+	var filters []string
+	for speed > 2 {
+		filters = append(filters, "atempo=2.0")
+		speed /= 2
+	}
+	for speed < 0.5 {
+		filters = append(filters, "atempo=0.5")
+		speed /= 0.5
+	}
+	if speed != 1 {
+		filters = append(filters, fmt.Sprintf("atempo=%.4f", speed))
+	}
+	return filters
+}
@@ -0,0 +1,226 @@
+package services
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"gocreator/internal/config"
+
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPlanSlideTiming(t *testing.T) {
+	timing := config.TimingConfig{
+		PerSlide: []config.SlideTimingConfig{
+			{Slide: 1, Speed: 1.25},
+			{Slide: 2, Duration: 4},
+			{Slide: 3, Speed: 2},
+			{Slide: 4, PauseAfter: 1.5},
+			{Slide: 5, Duration: 4, PauseAfter: 1},
+		},
+		DefaultImageDuration: 3.0,
+		MinSlideDuration:     5,
+		MaxSlideDuration:     20,
+	}
+
+	tests := []struct {
+		name      string
+		index     int
+		isVideo   bool
+		narration float64
+		want      slideTiming
+	}{
+		{name: "within limits", index: 0, narration: 8, want: slideTiming{speed: 1, narration: 8, duration: 8}},
+		{name: "padded to minimum", index: 0, narration: 2, want: slideTiming{speed: 1, narration: 2, duration: 5}},
+		{name: "stretched to maximum", index: 0, narration: 25, want: slideTiming{speed: 1.25, narration: 20, duration: 20}},
+		{name: "stretched then cut at maximum", index: 0, narration: 40, want: slideTiming{speed: 1.5, narration: 20, duration: 20}},
+		{name: "per-slide speed", index: 1, narration: 10, want: slideTiming{speed: 1.25, narration: 8, duration: 8}},
+		{name: "explicit duration", index: 2, narration: 2, want: slideTiming{speed: 1, narration: 2, duration: 4}},
+		{name: "silent slide uses default", index: 0, narration: 0, want: slideTiming{speed: 1, duration: 3}},
+		{name: "silent slide with explicit duration", index: 2, narration: 0, want: slideTiming{speed: 1, duration: 4}},
+		{name: "pause after narration", index: 4, narration: 8, want: slideTiming{speed: 1, narration: 8, duration: 9.5}},
+		{name: "pause after maximum", index: 4, narration: 25, want: slideTiming{speed: 1.25, narration: 20, duration: 21.5}},
+		{name: "pause after explicit duration", index: 5, narration: 6, want: slideTiming{speed: 1.5, narration: 4, duration: 5}},
+		{name: "pause ignored without narration", index: 5, narration: 0, want: slideTiming{speed: 1, duration: 4}},
+		{name: "video slide only changes speed", index: 3, isVideo: true, narration: 30, want: slideTiming{speed: 2, narration: 15}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := planSlideTiming(timing, tt.index, tt.isVideo, tt.narration)
+			assert.InDelta(t, tt.want.speed, got.speed, 1e-9)
+			assert.InDelta(t, tt.want.narration, got.narration, 1e-9)
+			assert.InDelta(t, tt.want.duration, got.duration, 1e-9)
+		})
+	}
+
+	assert.Equal(t, 0.0, planSlideTiming(config.TimingConfig{}, 0, false, 0).duration)
+}
+
+func TestAtempoFilters(t *testing.T) {
+	assert.Empty(t, atempoFilters(1))
+	assert.Equal(t, []string{"atempo=1.2500"}, atempoFilters(1.25))
+	assert.Equal(t, []string{"atempo=2.0", "atempo=1.5000"}, atempoFilters(3))
+	assert.Equal(t, []string{"atempo=0.5", "atempo=0.8000"}, atempoFilters(0.4))
+}
+
+func TestVideoService_prepareNarration_PadsToMinimum(t *testing.T) {
+	fs := afero.NewMemMapFs()
+	slide := testPath("slides", "1.png")
+	audio := testPath("cache", "en", "audio", "0.mp3")
+	segmentDir := testPath("out", ".temp", "output-en")
+	require.NoError(t, writeTestFile(fs, audio, "narration"))
+
+	var timedPath string
+	executor := newFakeCommandExecutor(
+		expectedCommand{Name: "ffprobe", Contains: []string{"format=duration", audio}, Result: newCommandResult("2.0\n", "")},
+		expectedCommand{Name: "ffprobe", Contains: []string{"stream=codec_type,duration", slide}, Result: newCommandResult("codec_type=video\nduration=N/A\n", "")},
+		expectedCommand{
+			Name:     "ffmpeg",
+			Contains: []string{"-i " + audio, "-af apad", "-t 5.000", "pcm_s16le"},
+			Run: func(_ string, args []string) {
+				timedPath = args[len(args)-1]
+				_ = writeTestFile(fs, timedPath, "padded")
+			},
+		},
+	)
+	service := NewVideoServiceWithExecutor(fs, &mockLogger{}, executor)
+	service.SetTiming(config.TimingConfig{MinSlideDuration: 5})
+
+	prepared, err := service.prepareNarration(context.Background(), []string{slide}, []string{audio}, segmentDir)
+	require.NoError(t, err)
+	executor.AssertDone(t)
+	assert.Equal(t, []string{timedPath}, prepared)
+	assert.Equal(t, segmentDir, filepath.Dir(timedPath))
+
+	// The timed narration is content-addressed, so a second run reuses it.
+	executor = newFakeCommandExecutor(
+		expectedCommand{Name: "ffprobe", Contains: []string{audio}, Result: newCommandResult("2.0\n", "")},
+		expectedCommand{Name: "ffprobe", Contains: []string{slide}, Result: newCommandResult("codec_type=video\nduration=N/A\n", "")},
+	)
+	service = NewVideoServiceWithExecutor(fs, &mockLogger{}, executor)
+	service.SetTiming(config.TimingConfig{MinSlideDuration: 5})
+	prepared, err = service.prepareNarration(context.Background(), []string{slide}, []string{audio}, segmentDir)
+	require.NoError(t, err)
+	executor.AssertDone(t)
+	assert.Equal(t, []string{timedPath}, prepared)
+
+	// A file without its hash, left behind by a killed run, is timed again.
+	require.NoError(t, fs.Remove(timedPath+".hash"))
+	executor.expectations = []expectedCommand{
+		{Name: "ffprobe", Contains: []string{audio}, Result: newCommandResult("2.0\n", "")},
+		{Name: "ffprobe", Contains: []string{slide}, Result: newCommandResult("codec_type=video\nduration=N/A\n", "")},
+		{Name: "ffmpeg", Contains: []string{"-i " + audio, timedPath}},
+	}
+	prepared, err = service.prepareNarration(context.Background(), []string{slide}, []string{audio}, segmentDir)
+	require.NoError(t, err)
+	executor.AssertDone(t)
+	assert.Equal(t, []string{timedPath}, prepared)
+}
+
+func TestVideoService_prepareNarration_GeneratesSilenceForSilentSlides(t *testing.T) {
+	fs := afero.NewMemMapFs()
+	slide := testPath("slides", "title.png")
+	executor := newFakeCommandExecutor(
+		expectedCommand{Name: "ffprobe", Contains: []string{slide}, Result: newCommandResult("codec_type=video\nduration=N/A\n", "")},
+		expectedCommand{Name: "ffmpeg", Contains: []string{"-f lavfi -i anullsrc=r=44100:cl=stereo", "-t 3.500"}},
+	)
+	service := NewVideoServiceWithExecutor(fs, &mockLogger{}, executor)
+	service.SetTiming(config.TimingConfig{PerSlide: []config.SlideTimingConfig{{Slide: 0, Duration: 3.5}}})
+
+	prepared, err := service.prepareNarration(context.Background(), []string{slide}, []string{""}, testPath("out", ".temp"))
+	require.NoError(t, err)
+	executor.AssertDone(t)
+	assert.Contains(t, prepared[0], "narration_")
+
+	// Without a configured duration a silent image slide is an error.
+	executor = newFakeCommandExecutor(
+		expectedCommand{Name: "ffprobe", Contains: []string{slide}, Result: newCommandResult("codec_type=video\nduration=N/A\n", "")},
+	)
+	service = NewVideoServiceWithExecutor(fs, &mockLogger{}, executor)
+	service.SetTiming(config.TimingConfig{MinSlideDuration: 2})
+	_, err = service.prepareNarration(context.Background(), []string{slide}, []string{""}, testPath("out", ".temp"))
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "no narration and no configured duration")
+}
+
+func TestPostProcessService_computeTimelineDurations_FollowsTiming(t *testing.T) {
+	slides := []string{testPath("slides", "1.png"), testPath("slides", "2.png")}
+	audioPaths := []string{testPath("audio", "0.mp3"), ""}
+	executor := newFakeCommandExecutor(
+		expectedCommand{Name: "ffprobe", Contains: []string{"format=duration", audioPaths[0]}, Result: newCommandResult("2.0\n", "")},
+		expectedCommand{Name: "ffprobe", Contains: []string{slides[0]}, Result: newCommandResult("codec_type=video\nduration=0\n", "")},
+		expectedCommand{Name: "ffprobe", Contains: []string{slides[1]}, Result: newCommandResult("codec_type=video\nduration=0\n", "")},
+	)
+	service := NewPostProcessServiceWithExecutor(afero.NewMemMapFs(), &mockLogger{}, executor)
+
+	audioDurations, segmentDurations, err := service.computeTimelineDurations(context.Background(), slides, audioPaths, config.TimingConfig{
+		MinSlideDuration:     4,
+		DefaultImageDuration: 3.0,
+	})
+	require.NoError(t, err)
+	executor.AssertDone(t)
+	assert.Equal(t, []float64{2, 0}, audioDurations)
+	assert.Equal(t, []float64{4, 3}, segmentDurations)
+}
+
+func TestPostProcessService_generateSubtitles_StartsAtPaddedSlideStart(t *testing.T) {
+	fs := afero.NewMemMapFs()
+	service := NewPostProcessServiceWithExecutor(fs, &mockLogger{}, newFakeCommandExecutor())
+	outputDir := testPath("out")
+
+	paths, assPath, err := service.generateSubtitles(context.Background(), PostProcessRequest{
+		OutputDir: outputDir,
+		BaseName:  "output-en",
+		Lang:      "en",
+		Texts:     []string{"First slide", "Second slide"},
+		Subtitles: config.SubtitlesConfig{Enabled: true},
+	}, []slideTiming{{speed: 1, narration: 2}, {speed: 1, narration: 2}}, []float64{0, 5}, 0)
+	require.NoError(t, err)
+	require.Len(t, paths, 3)
+	assert.Equal(t, paths[2], assPath)
+
+	srt, err := afero.ReadFile(fs, paths[0])
+	require.NoError(t, err)
+	assert.Contains(t, string(srt), "00:00:05,000 --> 00:00:07,000")
+}
+
+func TestVideoCreator_resolveAudioForLanguage_AllowsSilentSlidesWithDuration(t *testing.T) {
+	fs := afero.NewMemMapFs()
+	slidesDir := testPath("data", "slides")
+	slides := []string{testPath("data", "slides", "01-title.png"), testPath("data", "slides", "02-intro.png")}
+	require.NoError(t, writeTestFile(fs, testPath("data", "slides", "02-intro.mp3"), "narration"))
+	creator := NewVideoCreator(fs, nil, nil, nil, nil, nil, &mockLogger{})
+
+	_, _, _, err := creator.resolveAudioForLanguage(context.Background(), nil, "en", "en", slidesDir, slides, []string{"", ""}, testPath("cache"), config.TimingConfig{})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "01-title")
+
+	audioPaths, prerecorded, generated, err := creator.resolveAudioForLanguage(context.Background(), nil, "en", "en", slidesDir, slides, []string{"", ""}, testPath("cache"), config.TimingConfig{
+		PerSlide: []config.SlideTimingConfig{{Slide: 0, Duration: 3}},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"", testPath("data", "slides", "02-intro.mp3")}, audioPaths)
+	assert.Equal(t, 1, prerecorded)
+	assert.Equal(t, 0, generated)
+}
+
+func TestVideoService_prepareNarration_SilentVideoSlideUsesClipLength(t *testing.T) {
+	fs := afero.NewMemMapFs()
+	slide := testPath("slides", "02-demo.mp4")
+	executor := newFakeCommandExecutor(
+		expectedCommand{Name: "ffprobe", Contains: []string{"stream=codec_type,duration", slide}, Result: newCommandResult("codec_type=video\nduration=12.0\n", "")},
+		expectedCommand{Name: "ffprobe", Contains: []string{"format=duration", slide}, Result: newCommandResult("12.0\n", "")},
+		expectedCommand{Name: "ffmpeg", Contains: []string{"anullsrc", "-t 12.000"}},
+	)
+	service := NewVideoServiceWithExecutor(fs, &mockLogger{}, executor)
+	service.SetTiming(config.TimingConfig{PerSlide: []config.SlideTimingConfig{{Slide: 0, Silent: true}}})
+
+	prepared, err := service.prepareNarration(context.Background(), []string{slide}, []string{""}, testPath("out", ".temp"))
+	require.NoError(t, err)
+	executor.AssertDone(t)
+	assert.Contains(t, prepared[0], "narration_")
+}
@@ -3,6 +3,7 @@ package services
 import (
 	"context"
 	"fmt"
+	"path/filepath"
 	"strings"
 
 	"gocreator/internal/config"
@@ -42,6 +43,7 @@ type SubtitleSegment struct {
 	StartTime float64
 	EndTime   float64
 	Text      string
+	Words     []interfaces.WordTimestamp // when each word of Text is spoken, if known
 }
 
 // GenerateSRT generates an SRT subtitle file
@@ -84,10 +86,13 @@ func (s *SubtitleService) GenerateVTT(segments []SubtitleSegment, outputPath str
 	return nil
 }
 
-// BurnSubtitles burns subtitles into video
+// BurnSubtitles burns subtitles into video. ASS files carry their own styling; other formats are
+// styled with force_style.
 func (s *SubtitleService) BurnSubtitles(ctx context.Context, videoPath, subtitlePath, outputPath string, cfg config.SubtitlesConfig) error {
-	style := s.buildSubtitleStyle(cfg.Style)
-	filter := fmt.Sprintf("subtitles='%s':force_style='%s'", escapeFFmpegFilterPath(subtitlePath), style)
+	filter := fmt.Sprintf("subtitles='%s'", escapeFFmpegFilterPath(subtitlePath))
+	if !strings.EqualFold(filepath.Ext(subtitlePath), ".ass") {
+		filter += fmt.Sprintf(":force_style='%s'", s.buildSubtitleStyle(cfg.Style))
+	}
 
 	args := []string{
 		"-y",
@@ -153,7 +158,13 @@ func (s *SubtitleService) buildSubtitleStyle(style config.SubtitleStyleConfig) s
 		parts = append(parts, fmt.Sprintf("MarginR=%d", style.MarginHorizontal))
 	}
 
-	// Alignment (1-9, numpad style)
+	parts = append(parts, fmt.Sprintf("Alignment=%d", subtitleAlignment(style)))
+
+	return strings.Join(parts, ",")
+}
+
+// subtitleAlignment returns the ASS alignment (1-9, numpad style) for a style.
+func subtitleAlignment(style config.SubtitleStyleConfig) int {
 	alignment := 2 // bottom center default
 	switch style.Position {
 	case "top":
@@ -169,9 +180,7 @@ func (s *SubtitleService) buildSubtitleStyle(style config.SubtitleStyleConfig) s
 	case "right":
 		alignment += 1
 	}
-	parts = append(parts, fmt.Sprintf("Alignment=%d", alignment))
-
-	return strings.Join(parts, ",")
+	return alignment
 }
 
 // CreateSegmentsFromTexts creates subtitle segments from text array
@@ -193,6 +202,70 @@ func (s *SubtitleService) CreateSegmentsFromTexts(texts []string, durations []fl
 	return segments
 }
 
+// CreateSegmentsFromWords groups timed words into cues. A cue ends after a sentence or before it
+// would exceed the line or duration limits, and is held for the minimum duration where the next
+// cue, or end when it is positive, leaves room.
+func (s *SubtitleService) CreateSegmentsFromWords(words []interfaces.WordTimestamp, timing config.SubtitleTimingConfig, end float64) []SubtitleSegment {
+	maxChars := 0
+	if timing.MaxCharsPerLine > 0 {
+		maxChars = timing.MaxCharsPerLine * max(1, timing.MaxLines)
+	}
+
+	var segments []SubtitleSegment
+	var cue []interfaces.WordTimestamp
+	cueLength := 0
+	flush := func() {
+		if len(cue) > 0 {
+			texts := make([]string, len(cue))
+			for i, word := range cue {
+				texts[i] = word.Word
+			}
+			segments = append(segments, SubtitleSegment{
+				Index:     len(segments) + 1,
+				StartTime: cue[0].Start,
+				EndTime:   cue[len(cue)-1].End,
+				Text:      strings.Join(texts, " "),
+				Words:     cue,
+			})
+		}
+		cue, cueLength = nil, 0
+	}
+
+	for _, word := range words {
+		if len(cue) > 0 {
+			tooLong := maxChars > 0 && cueLength+1+len(word.Word) > maxChars
+			tooSlow := timing.MaxDuration > 0 && word.End-cue[0].Start > timing.MaxDuration
+			if tooLong || tooSlow {
+				flush()
+			}
+		}
+		if len(cue) > 0 {
+			cueLength++
+		}
+		cue = append(cue, word)
+		cueLength += len(word.Word)
+		if isSentenceEnd(word.Word, len(word.Word)) {
+			flush()
+		}
+	}
+	flush()
+
+	for i := range segments {
+		limit := end
+		if i+1 < len(segments) {
+			limit = segments[i+1].StartTime
+		}
+		if held := segments[i].StartTime + timing.MinDuration; held > segments[i].EndTime {
+			if limit > 0 {
+				held = max(segments[i].EndTime, min(held, limit))
+			}
+			segments[i].EndTime = held
+		}
+	}
+
+	return segments
+}
+
 // SplitTextIntoLines splits text into multiple lines based on max chars per line
 func (s *SubtitleService) SplitTextIntoLines(text string, maxCharsPerLine int) []string {
 	if maxCharsPerLine <= 0 {
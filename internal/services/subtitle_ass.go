@@ -0,0 +1,226 @@
+package services
+
+import (
+	"fmt"
+	"math"
+	"strings"
+	"unicode/utf8"
+
+	"gocreator/internal/config"
+	"gocreator/internal/interfaces"
+
+	"github.com/spf13/afero"
+)
+
+// ASS scripts use the canvas ffmpeg gives converted SRT files, so font sizes, outlines and margins
+// look the same as they did with force_style.
+const (
+	assPlayResX = 384
+	assPlayResY = 288
+)
+
+// wordPopEffect scales each word up from 80% as it appears.
+const wordPopEffect = `{\fscx80\fscy80\t(0,120,\fscx100\fscy100)}`
+
+// GenerateASS generates an Advanced SubStation Alpha subtitle file styled from cfg.Style. In karaoke
+// mode each word is highlighted as it is spoken; in word-pop mode words are shown one at a time.
+// Segments without word timings have them estimated from word length.
+func (s *SubtitleService) GenerateASS(segments []SubtitleSegment, outputPath string, cfg config.SubtitlesConfig) error {
+	mode := cfg.ResolveMode()
+
+	var content strings.Builder
+	content.WriteString("[Script Info]\n")
+	content.WriteString("ScriptType: v4.00+\n")
+	content.WriteString(fmt.Sprintf("PlayResX: %d\n", assPlayResX))
+	content.WriteString(fmt.Sprintf("PlayResY: %d\n", assPlayResY))
+	content.WriteString("ScaledBorderAndShadow: yes\n\n")
+
+	content.WriteString("[V4+ Styles]\n")
+	content.WriteString("Format: Name, Fontname, Fontsize, PrimaryColour, SecondaryColour, OutlineColour, BackColour, Bold, Italic, Underline, StrikeOut, ScaleX, ScaleY, Spacing, Angle, BorderStyle, Outline, Shadow, Alignment, MarginL, MarginR, MarginV, Encoding\n")
+	content.WriteString(buildASSStyle(cfg.Style, mode) + "\n\n")
+
+	content.WriteString("[Events]\n")
+	content.WriteString("Format: Layer, Start, End, Style, Name, MarginL, MarginR, MarginV, Effect, Text\n")
+	for _, seg := range segments {
+		for _, event := range assEvents(seg, mode) {
+			content.WriteString(fmt.Sprintf("Dialogue: 0,%s,%s,Default,,0,0,0,,%s\n",
+				formatASSTime(event.StartTime),
+				formatASSTime(event.EndTime),
+				event.Text))
+		}
+	}
+
+	if err := afero.WriteFile(s.fs, outputPath, []byte(content.String()), 0644); err != nil {
+		return fmt.Errorf("failed to write ASS file: %w", err)
+	}
+
+	s.logger.Info("Generated ASS file", "path", outputPath)
+	return nil
+}
+
+// buildASSStyle maps the subtitle style to the Default style line. Karaoke highlights words in the
+// primary colour and shows words not yet spoken in the secondary one. A background opacity draws an
+// opaque box, which libass paints in the outline colour and pads by the outline width.
+func buildASSStyle(style config.SubtitleStyleConfig, mode string) string {
+	font := style.Font
+	if font == "" {
+		font = "Arial"
+	}
+	fontSize := style.FontSize
+	if fontSize <= 0 {
+		fontSize = 24
+	}
+	color := style.Color
+	if color == "" {
+		color = "white"
+	}
+
+	primary, secondary := color, color
+	if mode == config.SubtitleModeKaraoke {
+		primary = style.HighlightColor
+		if primary == "" {
+			primary = "yellow"
+		}
+	}
+
+	borderStyle, outline, shadow := 1, style.OutlineWidth, style.ShadowOffset
+	outlineColour := assColour(style.OutlineColor, 1, "black")
+	backColour := assColour(style.ShadowColor, 1, "black")
+	if style.BackgroundOpacity > 0 {
+		borderStyle, outline, shadow = 3, style.BackgroundPadding, 0
+		outlineColour = assColour(style.BackgroundColor, style.BackgroundOpacity, "black")
+		backColour = outlineColour
+	}
+
+	return fmt.Sprintf("Style: Default,%s,%d,%s,%s,%s,%s,%d,%d,0,0,100,100,0,0,%d,%d,%d,%d,%d,%d,%d,1",
+		font,
+		fontSize,
+		assColour(primary, 1, "white"),
+		assColour(secondary, 1, "white"),
+		outlineColour,
+		backColour,
+		assBool(style.Bold),
+		assBool(style.Italic),
+		borderStyle,
+		max(0, outline),
+		max(0, shadow),
+		subtitleAlignment(style),
+		max(0, style.MarginHorizontal),
+		max(0, style.MarginHorizontal),
+		max(0, style.MarginVertical))
+}
+
+// assEvents returns the dialogue lines of a segment in the given mode, with ASS-escaped text.
+func assEvents(seg SubtitleSegment, mode string) []SubtitleSegment {
+	if mode != config.SubtitleModeKaraoke && mode != config.SubtitleModeWordPop {
+		return []SubtitleSegment{{StartTime: seg.StartTime, EndTime: seg.EndTime, Text: escapeASSText(seg.Text)}}
+	}
+
+	var lines [][]string
+	var fields []string
+	for _, line := range strings.Split(seg.Text, "\n") {
+		if words := strings.Fields(line); len(words) > 0 {
+			lines = append(lines, words)
+			fields = append(fields, words...)
+		}
+	}
+	if len(fields) == 0 {
+		return nil
+	}
+	words := seg.Words
+	if len(words) != len(fields) {
+		words = estimateWordTimings(fields, seg.StartTime, seg.EndTime)
+	}
+
+	if mode == config.SubtitleModeWordPop {
+		events := make([]SubtitleSegment, 0, len(words))
+		for i, word := range words {
+			end := seg.EndTime
+			if i+1 < len(words) {
+				end = words[i+1].Start
+			}
+			if end <= word.Start {
+				continue
+			}
+			events = append(events, SubtitleSegment{StartTime: word.Start, EndTime: end, Text: wordPopEffect + escapeASSText(fields[i])})
+		}
+		return events
+	}
+
+	// Karaoke durations are rounded on the cumulative offset so they never drift from the audio.
+	centiseconds := func(t float64) int {
+		return int(math.Round((max(t, seg.StartTime) - seg.StartTime) * 100))
+	}
+	var text strings.Builder
+	if gap := centiseconds(words[0].Start); gap > 0 {
+		text.WriteString(fmt.Sprintf(`{\k%d}`, gap))
+	}
+	index := 0
+	for l, line := range lines {
+		if l > 0 {
+			text.WriteString(`\N`)
+		}
+		for w, field := range line {
+			if w > 0 {
+				text.WriteString(" ")
+			}
+			until := words[index].End
+			if index+1 < len(words) {
+				until = words[index+1].Start
+			}
+			text.WriteString(fmt.Sprintf(`{\k%d}%s`, max(0, centiseconds(until)-centiseconds(words[index].Start)), escapeASSText(field)))
+			index++
+		}
+	}
+	return []SubtitleSegment{{StartTime: seg.StartTime, EndTime: seg.EndTime, Text: text.String()}}
+}
+
+// estimateWordTimings spreads words over [start, end] in proportion to their length.
+func estimateWordTimings(fields []string, start, end float64) []interfaces.WordTimestamp {
+	total := 0
+	for _, field := range fields {
+		total += utf8.RuneCountInString(field)
+	}
+	words := make([]interfaces.WordTimestamp, len(fields))
+	at, span := start, max(0, end-start)
+	for i, field := range fields {
+		length := span / float64(len(fields))
+		if total > 0 {
+			length = span * float64(utf8.RuneCountInString(field)) / float64(total)
+		}
+		words[i] = interfaces.WordTimestamp{Word: field, Start: at, End: at + length}
+		at += length
+	}
+	return words
+}
+
+// escapeASSText keeps braces from being read as override blocks and turns line breaks into \N.
+func escapeASSText(text string) string {
+	return strings.NewReplacer("{", `\{`, "}", `\}`, "\r\n", `\N`, "\n", `\N`).Replace(text)
+}
+
+// assColour formats a colour as &HAABBGGRR, where opacity 1 is fully opaque.
+func assColour(color string, opacity float64, fallback string) string {
+	if color == "" {
+		color = fallback
+	}
+	alpha := int(math.Round((1 - min(1, max(0, opacity))) * 255))
+	return fmt.Sprintf("&H%02X%s", alpha, colorToASS(color))
+}
+
+func assBool(value bool) int {
+	if value {
+		return -1
+	}
+	return 0
+}
+
+func formatASSTime(seconds float64) string {
+	total := int(math.Round(max(0, seconds) * 100))
+	h := total / 360000
+	m := total / 6000 % 60
+	s := total / 100 % 60
+	cs := total % 100
+
+	return fmt.Sprintf("%d:%02d:%02d.%02d", h, m, s, cs)
+}
@@ -0,0 +1,100 @@
+package services
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"gocreator/internal/config"
+	"gocreator/internal/interfaces"
+
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func generateTestASS(t *testing.T, segments []SubtitleSegment, cfg config.SubtitlesConfig) (style string, events []string) {
+	t.Helper()
+	fs := afero.NewMemMapFs()
+	service := NewSubtitleService(fs, &mockLogger{})
+	path := testPath("out", "output-en.ass")
+
+	require.NoError(t, service.GenerateASS(segments, path, cfg))
+	data, err := afero.ReadFile(fs, path)
+	require.NoError(t, err)
+
+	for _, line := range strings.Split(string(data), "\n") {
+		switch {
+		case strings.HasPrefix(line, "Style: "):
+			style = line
+		case strings.HasPrefix(line, "Dialogue: "):
+			events = append(events, line)
+		}
+	}
+	return style, events
+}
+
+func TestSubtitleService_GenerateASS_MapsStyle(t *testing.T) {
+	cfg := config.DefaultSubtitlesConfig()
+	cfg.Style.Bold = true
+	cfg.Style.Position = "top"
+	cfg.Style.Alignment = "left"
+
+	style, _ := generateTestASS(t, nil, cfg)
+	assert.Equal(t, "Style: Default,Arial,24,&H00FFFFFF,&H00FFFFFF,&H80000000,&H80000000,-1,0,0,0,100,100,0,0,3,5,0,7,10,10,20,1", style)
+
+	// Without a background the outline and shadow are drawn instead of a box
+	cfg.Style.BackgroundOpacity = 0
+	cfg.Style.Color = "#336699"
+	cfg.Mode = config.SubtitleModeKaraoke
+	style, _ = generateTestASS(t, nil, cfg)
+	assert.Equal(t, "Style: Default,Arial,24,&H0000FFFF,&H00996633,&H00000000,&H00000000,-1,0,0,0,100,100,0,0,1,2,2,7,10,10,20,1", style)
+}
+
+func TestSubtitleService_GenerateASS_Modes(t *testing.T) {
+	segments := []SubtitleSegment{{
+		Index:     1,
+		StartTime: 1.0,
+		EndTime:   2.5,
+		Text:      "Hello {big}\nworld",
+		Words: []interfaces.WordTimestamp{
+			{Word: "Hello", Start: 1.2, End: 1.5},
+			{Word: "{big}", Start: 1.6, End: 1.9},
+			{Word: "world", Start: 1.9, End: 2.3},
+		},
+	}}
+
+	_, events := generateTestASS(t, segments, config.SubtitlesConfig{})
+	assert.Equal(t, []string{`Dialogue: 0,0:00:01.00,0:00:02.50,Default,,0,0,0,,Hello \{big\}\Nworld`}, events)
+
+	_, events = generateTestASS(t, segments, config.SubtitlesConfig{Mode: config.SubtitleModeKaraoke})
+	assert.Equal(t, []string{`Dialogue: 0,0:00:01.00,0:00:02.50,Default,,0,0,0,,{\k20}{\k40}Hello {\k30}\{big\}\N{\k40}world`}, events)
+
+	_, events = generateTestASS(t, segments, config.SubtitlesConfig{Mode: config.SubtitleModeWordPop})
+	assert.Equal(t, []string{
+		`Dialogue: 0,0:00:01.20,0:00:01.60,Default,,0,0,0,,` + wordPopEffect + `Hello`,
+		`Dialogue: 0,0:00:01.60,0:00:01.90,Default,,0,0,0,,` + wordPopEffect + `\{big\}`,
+		`Dialogue: 0,0:00:01.90,0:00:02.50,Default,,0,0,0,,` + wordPopEffect + `world`,
+	}, events)
+}
+
+func TestSubtitleService_GenerateASS_EstimatesUntimedWords(t *testing.T) {
+	segments := []SubtitleSegment{{Index: 1, StartTime: 0, EndTime: 3, Text: "ab abcd"}}
+
+	_, events := generateTestASS(t, segments, config.SubtitlesConfig{Mode: config.SubtitleModeKaraoke})
+	assert.Equal(t, []string{`Dialogue: 0,0:00:00.00,0:00:03.00,Default,,0,0,0,,{\k100}ab {\k200}abcd`}, events)
+}
+
+func TestSubtitleService_BurnSubtitles_UsesASSStyling(t *testing.T) {
+	executor := newFakeCommandExecutor(
+		expectedCommand{Name: "ffmpeg", Contains: []string{"subtitles='" + escapeFFmpegFilterPath(testPath("out", "a.ass")) + "'"}},
+		expectedCommand{Name: "ffmpeg", Contains: []string{"force_style="}},
+	)
+	service := NewSubtitleServiceWithExecutor(afero.NewMemMapFs(), &mockLogger{}, executor)
+	cfg := config.DefaultSubtitlesConfig()
+
+	require.NoError(t, service.BurnSubtitles(context.Background(), "in.mp4", testPath("out", "a.ass"), "out.mp4", cfg))
+	assert.NotContains(t, strings.Join(executor.calls[0].Args, " "), "force_style")
+	require.NoError(t, service.BurnSubtitles(context.Background(), "in.mp4", testPath("out", "a.srt"), "out.mp4", cfg))
+	executor.AssertDone(t)
+}
@@ -0,0 +1,220 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"gocreator/internal/config"
+	"gocreator/internal/interfaces"
+
+	"github.com/spf13/afero"
+)
+
+// defaultTranscriberArgs are used when transcription.args is empty.
+var defaultTranscriberArgs = []string{"{audio}", "{output}"}
+
+// TranscriptionService turns prerecorded narration into text, caching transcripts by audio hash.
+type TranscriptionService struct {
+	fs       afero.Fs
+	logger   interfaces.Logger
+	provider interfaces.TranscriptionProvider
+	cacheDir string
+	locks    sync.Map // cache key -> *sync.Mutex, so languages sharing a recording transcribe it once
+}
+
+// NewTranscriptionService creates a transcription service that caches transcripts in cacheDir.
+func NewTranscriptionService(fs afero.Fs, provider interfaces.TranscriptionProvider, logger interfaces.Logger, cacheDir string) *TranscriptionService {
+	return &TranscriptionService{
+		fs:       fs,
+		logger:   logger,
+		provider: provider,
+		cacheDir: cacheDir,
+	}
+}
+
+// Transcribe returns the text spoken in audioPath. lang is the language of the recording.
+func (s *TranscriptionService) Transcribe(ctx context.Context, audioPath, lang string) (string, error) {
+	audio, err := afero.ReadFile(s.fs, audioPath)
+	if err != nil {
+		return "", fmt.Errorf("failed to read narration for transcription: %w", err)
+	}
+
+	key := fmt.Sprintf("%x", sha256.Sum256([]byte(fmt.Sprintf("%x|%s|%s|%s", sha256.Sum256(audio), s.provider.Name(), s.provider.Model(), lang))))
+	lock, _ := s.locks.LoadOrStore(key, &sync.Mutex{})
+	lock.(*sync.Mutex).Lock()
+	defer lock.(*sync.Mutex).Unlock()
+
+	cachePath := ""
+	if s.cacheDir != "" {
+		cachePath = filepath.Join(s.cacheDir, key+".txt")
+		if data, err := afero.ReadFile(s.fs, cachePath); err == nil {
+			recordCacheLookup(ctx, CacheStageTranscription, true)
+			return string(data), nil
+		}
+		recordCacheLookup(ctx, CacheStageTranscription, false)
+	}
+
+	s.logger.Info("Transcribing narration", "audio", audioPath, "provider", s.provider.Name(), "lang", lang)
+	text, err := s.provider.Transcribe(ctx, audioPath, lang)
+	if err != nil {
+		return "", fmt.Errorf("failed to transcribe %s: %w", audioPath, err)
+	}
+	text = strings.TrimSpace(text)
+	if text == "" {
+		return "", fmt.Errorf("transcription of %s is empty", audioPath)
+	}
+
+	if cachePath != "" {
+		if err := s.fs.MkdirAll(s.cacheDir, 0755); err != nil {
+			return "", fmt.Errorf("failed to create transcription cache directory: %w", err)
+		}
+		if err := afero.WriteFile(s.fs, cachePath, []byte(text), 0644); err != nil {
+			return "", fmt.Errorf("failed to write transcription cache: %w", err)
+		}
+	}
+	return text, nil
+}
+
+// OpenAITranscriptionProvider transcribes speech through the OpenAI audio transcription API.
+type OpenAITranscriptionProvider struct {
+	fs     afero.Fs
+	client interfaces.TranscriptionClient
+	model  string
+}
+
+// NewOpenAITranscriptionProvider creates a provider backed by an OpenAI transcription client.
+func NewOpenAITranscriptionProvider(fs afero.Fs, client interfaces.TranscriptionClient, model string) *OpenAITranscriptionProvider {
+	return &OpenAITranscriptionProvider{fs: fs, client: client, model: model}
+}
+
+// Name returns the provider name.
+func (p *OpenAITranscriptionProvider) Name() string {
+	return config.TranscriptionProviderOpenAI
+}
+
+// Model returns the transcription model.
+func (p *OpenAITranscriptionProvider) Model() string {
+	return p.model
+}
+
+// Transcribe uploads the recording and returns its transcript.
+func (p *OpenAITranscriptionProvider) Transcribe(ctx context.Context, audioPath, lang string) (string, error) {
+	if p.client == nil {
+		return "", fmt.Errorf("%s transcription provider has no client", p.Name())
+	}
+
+	audio, err := afero.ReadFile(p.fs, audioPath)
+	if err != nil {
+		return "", fmt.Errorf("failed to read narration for transcription: %w", err)
+	}
+
+	release, err := DefaultScheduler().AcquireAPI(ctx)
+	if err != nil {
+		return "", err
+	}
+	defer release()
+
+	ctx, counted := countAPIAttempts(ctx, APICallTranscription)
+	defer counted()
+	return p.client.TranscribeAudio(ctx, audio, filepath.Base(audioPath), p.model, lang)
+}
+
+// CommandTranscriptionProvider transcribes speech by running a local whisper-compatible command.
+type CommandTranscriptionProvider struct {
+	fs       afero.Fs
+	executor interfaces.CommandExecutor
+	binary   string
+	args     []string
+}
+
+// NewCommandTranscriptionProvider creates a provider that runs binary with the args templates.
+func NewCommandTranscriptionProvider(fs afero.Fs, executor interfaces.CommandExecutor, binary string, args []string) *CommandTranscriptionProvider {
+	if executor == nil {
+		executor = newCommandExecutor()
+	}
+	return &CommandTranscriptionProvider{fs: fs, executor: executor, binary: binary, args: args}
+}
+
+// Name returns the provider name.
+func (p *CommandTranscriptionProvider) Name() string {
+	return config.TranscriptionProviderCommand
+}
+
+// Model identifies the transcriber command, so changing it invalidates cached transcripts.
+func (p *CommandTranscriptionProvider) Model() string {
+	return formatCommand(p.binary, p.args...)
+}
+
+// Transcribe runs the command. It reads the transcript from {output} when the arguments use it, or
+// from stdout otherwise.
+func (p *CommandTranscriptionProvider) Transcribe(ctx context.Context, audioPath, lang string) (string, error) {
+	workDir, err := afero.TempDir(p.fs, "", "gocreator-transcribe-")
+	if err != nil {
+		return "", fmt.Errorf("failed to create transcription work directory: %w", err)
+	}
+	defer func() { _ = p.fs.RemoveAll(workDir) }()
+
+	outputPath := filepath.Join(workDir, "transcript.txt")
+	templates := p.args
+	if len(templates) == 0 {
+		templates = defaultTranscriberArgs
+	}
+	replacer := strings.NewReplacer(
+		"{audio}", audioPath,
+		"{lang}", lang,
+		"{output}", outputPath,
+	)
+	usesOutput := false
+	args := make([]string, len(templates))
+	for i, arg := range templates {
+		usesOutput = usesOutput || strings.Contains(arg, "{output}")
+		args[i] = replacer.Replace(arg)
+	}
+
+	result, err := p.executor.Run(ctx, p.binary, args...)
+	if err != nil {
+		return "", fmt.Errorf("transcriber error: %w, stderr: %s", err, string(result.Stderr))
+	}
+
+	data := result.Stdout
+	if usesOutput {
+		if data, err = afero.ReadFile(p.fs, outputPath); err != nil {
+			return "", fmt.Errorf("transcriber did not write %s: %w", outputPath, err)
+		}
+	}
+	return parseTranscript(data), nil
+}
+
+// parseTranscript reads plain text, or whisper-style JSON with a text field or text segments.
+func parseTranscript(data []byte) string {
+	trimmed := bytes.TrimSpace(data)
+	if len(trimmed) == 0 || trimmed[0] != '{' {
+		return string(trimmed)
+	}
+
+	var document struct {
+		Text     string `json:"text"`
+		Segments []struct {
+			Text string `json:"text"`
+		} `json:"segments"`
+	}
+	if err := json.Unmarshal(trimmed, &document); err != nil {
+		return string(trimmed)
+	}
+	if text := strings.TrimSpace(document.Text); text != "" {
+		return text
+	}
+	parts := make([]string, 0, len(document.Segments))
+	for _, segment := range document.Segments {
+		if text := strings.TrimSpace(segment.Text); text != "" {
+			parts = append(parts, text)
+		}
+	}
+	return strings.Join(parts, " ")
+}
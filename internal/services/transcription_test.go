@@ -0,0 +1,161 @@
+package services
+
+import (
+	"context"
+	"testing"
+
+	"gocreator/internal/config"
+	"gocreator/internal/mocks"
+
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeTranscriptionClient struct {
+	audio, filename, model, lang string
+	text                         string
+}
+
+func (c *fakeTranscriptionClient) TranscribeAudio(_ context.Context, audio []byte, filename, model, lang string) (string, error) {
+	c.audio, c.filename, c.model, c.lang = string(audio), filename, model, lang
+	return c.text, nil
+}
+
+type fakeTranscriptionProvider struct {
+	transcripts map[string]string
+	calls       []string
+}
+
+func (p *fakeTranscriptionProvider) Name() string  { return "fake" }
+func (p *fakeTranscriptionProvider) Model() string { return "" }
+
+func (p *fakeTranscriptionProvider) Transcribe(_ context.Context, audioPath, lang string) (string, error) {
+	p.calls = append(p.calls, audioPath+"|"+lang)
+	return p.transcripts[audioPath], nil
+}
+
+func TestParseTranscript(t *testing.T) {
+	assert.Equal(t, "Hello there.", parseTranscript([]byte("  Hello there.\n")))
+	assert.Equal(t, "Hello there.", parseTranscript([]byte(`{"text":" Hello there.","segments":[]}`)))
+	assert.Equal(t, "Hello there.", parseTranscript([]byte(`{"segments":[{"text":" Hello"},{"text":" there."}]}`)))
+}
+
+func TestOpenAITranscriptionProvider_Transcribe(t *testing.T) {
+	fs := afero.NewMemMapFs()
+	audioPath := testPath("data", "slides", "01-intro.wav")
+	require.NoError(t, writeTestFile(fs, audioPath, "recorded audio"))
+	client := &fakeTranscriptionClient{text: "Welcome to the talk."}
+
+	text, err := NewOpenAITranscriptionProvider(fs, client, "whisper-1").Transcribe(context.Background(), audioPath, "en")
+	require.NoError(t, err)
+	assert.Equal(t, "Welcome to the talk.", text)
+	assert.Equal(t, fakeTranscriptionClient{audio: "recorded audio", filename: "01-intro.wav", model: "whisper-1", lang: "en", text: text}, *client)
+}
+
+func TestTranscriptionService_Transcribe_CachesByAudioHash(t *testing.T) {
+	fs := afero.NewMemMapFs()
+	audioPath := testPath("data", "slides", "01-intro.wav")
+	cacheDir := testPath("data", "cache", "transcriptions")
+	require.NoError(t, writeTestFile(fs, audioPath, "recorded audio"))
+	writeTranscript := func(text string) func(string, []string) {
+		return func(_ string, args []string) {
+			require.NoError(t, writeTestFile(fs, args[len(args)-1], text))
+		}
+	}
+
+	executor := newFakeCommandExecutor(expectedCommand{
+		Name:     "whisper-cli",
+		Contains: []string{audioPath, "--language en"},
+		Run:      writeTranscript(`{"text":" Welcome to the talk."}`),
+	})
+	provider := NewCommandTranscriptionProvider(fs, executor, "whisper-cli", []string{"{audio}", "--language", "{lang}", "--json", "{output}"})
+	service := NewTranscriptionService(fs, provider, &mockLogger{}, cacheDir)
+
+	text, err := service.Transcribe(context.Background(), audioPath, "en")
+	require.NoError(t, err)
+	executor.AssertDone(t)
+	assert.Equal(t, "Welcome to the talk.", text)
+
+	// The same recording is served from the cache
+	cached, err := service.Transcribe(context.Background(), audioPath, "en")
+	require.NoError(t, err)
+	assert.Equal(t, text, cached)
+
+	// A new recording is transcribed again
+	require.NoError(t, writeTestFile(fs, audioPath, "re-recorded audio"))
+	executor.expectations = []expectedCommand{{Name: "whisper-cli", Run: writeTranscript("Welcome back.")}}
+	text, err = service.Transcribe(context.Background(), audioPath, "en")
+	require.NoError(t, err)
+	executor.AssertDone(t)
+	assert.Equal(t, "Welcome back.", text)
+}
+
+func TestVideoCreatorResolveTexts_TranscribesPrerecordedNarration(t *testing.T) {
+	fs := afero.NewMemMapFs()
+	logger := &mockLogger{}
+	slidesDir := testPath("test", "data", "slides")
+	slides := []string{
+		testPath("test", "data", "slides", "01-intro.png"),
+		testPath("test", "data", "slides", "02-demo.png"),
+	}
+	introAudio := testPath("test", "data", "slides", "01-intro.wav")
+	require.NoError(t, writeTestFile(fs, introAudio, "recorded intro"))
+	require.NoError(t, writeTestFile(fs, testPath("test", "data", "slides", "02-demo.txt"), "Demo"))
+	require.NoError(t, writeTestFile(fs, testPath("test", "data", "slides", "02-demo.fr.txt"), "Démo"))
+
+	client := new(mocks.MockOpenAIClient)
+	client.On("ChatCompletion", mock.Anything, mock.Anything).Return("Bienvenue", nil).Once()
+	provider := &fakeTranscriptionProvider{transcripts: map[string]string{introAudio: "Welcome"}}
+	creator := &VideoCreator{
+		fs:                 fs,
+		translationService: NewTranslationService(client, logger),
+		logger:             logger,
+	}
+	creator.SetTranscriptionService(NewTranscriptionService(fs, provider, logger, ""), true)
+
+	texts, _, err := creator.resolveTextsForLanguage(context.Background(), "en", "en", slidesDir, slides, config.TimingConfig{})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"Welcome", "Demo"}, texts)
+
+	sidecar, err := afero.ReadFile(fs, testPath("test", "data", "slides", "01-intro.en.txt"))
+	require.NoError(t, err)
+	assert.Equal(t, "Welcome\n", string(sidecar))
+
+	// Other languages translate the saved transcript
+	texts, translated, err := creator.resolveTextsForLanguage(context.Background(), "en", "fr", slidesDir, slides, config.TimingConfig{})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"Bienvenue", "Démo"}, texts)
+	assert.Equal(t, []int{0}, translated)
+	assert.Equal(t, []string{introAudio + "|en"}, provider.calls)
+	client.AssertExpectations(t)
+}
+
+func TestVideoCreatorResolveTexts_TranscribesSourceNarrationForTranslation(t *testing.T) {
+	fs := afero.NewMemMapFs()
+	logger := &mockLogger{}
+	slidesDir := testPath("test", "data", "slides")
+	slides := []string{testPath("test", "data", "slides", "01-intro.png")}
+	introAudio := testPath("test", "data", "slides", "01-intro.en.m4a")
+	require.NoError(t, writeTestFile(fs, introAudio, "recorded intro"))
+
+	client := new(mocks.MockOpenAIClient)
+	client.On("ChatCompletion", mock.Anything, mock.Anything).Return("Bienvenue", nil).Once()
+	provider := &fakeTranscriptionProvider{transcripts: map[string]string{introAudio: "Welcome"}}
+	creator := &VideoCreator{
+		fs:                 fs,
+		translationService: NewTranslationService(client, logger),
+		logger:             logger,
+	}
+	creator.SetTranscriptionService(NewTranscriptionService(fs, provider, logger, ""), false)
+
+	texts, _, err := creator.resolveTextsForLanguage(context.Background(), "en", "fr", slidesDir, slides, config.TimingConfig{})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"Bienvenue"}, texts)
+	assert.Equal(t, []string{introAudio + "|en"}, provider.calls)
+
+	exists, err := afero.Exists(fs, testPath("test", "data", "slides", "01-intro.en.txt"))
+	require.NoError(t, err)
+	assert.False(t, exists)
+}
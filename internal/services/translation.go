@@ -6,37 +6,47 @@ import (
 	"encoding/hex"
 	"fmt"
 	"path/filepath"
+	"strings"
 	"sync"
 
+	"gocreator/internal/config"
 	"gocreator/internal/interfaces"
 
-	"github.com/openai/openai-go/v3"
 	"github.com/spf13/afero"
 )
 
 // TranslationService handles text translation with caching
 type TranslationService struct {
-	client       interfaces.OpenAIClient
-	logger       interfaces.Logger
-	fs           afero.Fs
-	memoryCache  map[string]string
-	cacheMutex   sync.RWMutex
-	cacheDir     string
+	client      interfaces.OpenAIClient
+	provider    interfaces.TranslationProvider
+	glossary    *Glossary
+	strict      bool
+	deckMode    bool
+	logger      interfaces.Logger
+	fs          afero.Fs
+	memoryCache map[string]string
+	cacheMutex  sync.RWMutex
+	cacheDir    string
 }
 
 // NewTranslationService creates a new translation service
 func NewTranslationService(client interfaces.OpenAIClient, logger interfaces.Logger) *TranslationService {
-	return &TranslationService{
-		client:      client,
-		logger:      logger,
-		memoryCache: make(map[string]string),
-	}
+	service := NewTranslationServiceWithProvider(newDefaultChatTranslationProvider(client), logger, nil, "")
+	service.client = client
+	return service
 }
 
 // NewTranslationServiceWithCache creates a new translation service with disk cache support
 func NewTranslationServiceWithCache(client interfaces.OpenAIClient, logger interfaces.Logger, fs afero.Fs, cacheDir string) *TranslationService {
+	service := NewTranslationServiceWithProvider(newDefaultChatTranslationProvider(client), logger, fs, cacheDir)
+	service.client = client
+	return service
+}
+
+// NewTranslationServiceWithProvider creates a translation service backed by a specific provider
+func NewTranslationServiceWithProvider(provider interfaces.TranslationProvider, logger interfaces.Logger, fs afero.Fs, cacheDir string) *TranslationService {
 	return &TranslationService{
-		client:      client,
+		provider:    provider,
 		logger:      logger,
 		fs:          fs,
 		memoryCache: make(map[string]string),
@@ -44,9 +54,34 @@ func NewTranslationServiceWithCache(client interfaces.OpenAIClient, logger inter
 	}
 }
 
-// getCacheKey generates a cache key from text and target language
+// SetGlossary enforces glossary terms on translations. When strict is true, a translation that
+// drops a protected term fails instead of logging a warning.
+func (s *TranslationService) SetGlossary(glossary *Glossary, strict bool) {
+	s.glossary = glossary
+	s.strict = strict
+}
+
+// SetDeckMode enables deck-level batch translation, which sends all texts of a language in one
+// request so the provider sees the whole presentation.
+func (s *TranslationService) SetDeckMode(enabled bool) {
+	s.deckMode = enabled
+}
+
+func newDefaultChatTranslationProvider(client interfaces.OpenAIClient) interfaces.TranslationProvider {
+	return NewChatTranslationProvider(config.TranslationProviderOpenAI, client, config.DefaultTranslationModel)
+}
+
+// getCacheKey generates a cache key from text, target language, translation backend, and the
+// glossary entries that apply to the text. The default OpenAI backend without glossary matches
+// keeps the original key so existing caches stay valid.
 func (s *TranslationService) getCacheKey(text, targetLang string) string {
 	data := fmt.Sprintf("%s|%s", text, targetLang)
+	if s.provider != nil && !(s.provider.Name() == config.TranslationProviderOpenAI && s.provider.Model() == config.DefaultTranslationModel) {
+		data = fmt.Sprintf("%s|%s|%s|%s", s.provider.Name(), s.provider.Model(), text, targetLang)
+	}
+	if fingerprint := s.glossary.rulesFor(text, targetLang).fingerprint(); fingerprint != "" {
+		data = fmt.Sprintf("%s|glossary:%s", data, fingerprint)
+	}
 	hash := sha256.Sum256([]byte(data))
 	return hex.EncodeToString(hash[:])
 }
@@ -101,29 +136,38 @@ func (s *TranslationService) setInDiskCache(key, value string) {
 
 // Translate translates text to target language with caching
 func (s *TranslationService) Translate(ctx context.Context, text, targetLang string) (string, error) {
-	// Check memory cache first
 	cacheKey := s.getCacheKey(text, targetLang)
-	if cached, ok := s.getFromMemoryCache(cacheKey); ok {
-		s.logger.Info("Translation cache hit (memory)", "key", cacheKey)
+	if cached, ok := s.getCached(ctx, cacheKey); ok {
 		return cached, nil
 	}
 
-	// Check disk cache
-	if cached, ok := s.getFromDiskCache(cacheKey); ok {
-		// Store in memory for faster future access
-		s.setInMemoryCache(cacheKey, cached)
-		return cached, nil
+	if s.provider == nil {
+		return "", fmt.Errorf("translation failed: no translation provider configured")
 	}
 
-	// No cache, call API
-	messages := []openai.ChatCompletionMessageParamUnion{
-		openai.UserMessage(fmt.Sprintf("Translate '%s' to %s and don't return anything else than the translation.", text, targetLang)),
+	// No cache, call the provider
+	release, err := DefaultScheduler().AcquireAPI(ctx)
+	if err != nil {
+		return "", fmt.Errorf("translation failed: %w", err)
+	}
+	defer release()
+
+	rules := s.glossary.rulesFor(text, targetLang)
+	protected, markup := protectNarrationMarkup(text)
+	var translated string
+	if glossaryProvider, ok := s.provider.(glossaryTranslationProvider); ok && !rules.empty() {
+		translated, err = glossaryProvider.TranslateWithInstructions(ctx, protected, targetLang, rules.instructions())
+	} else {
+		translated, err = s.provider.Translate(ctx, protected, targetLang)
 	}
-
-	translated, err := s.client.ChatCompletion(ctx, messages)
 	if err != nil {
 		return "", fmt.Errorf("translation failed: %w", err)
 	}
+	translated = s.restoreMarkup(translated, markup, targetLang)
+
+	if err := s.checkGlossary(rules, translated, targetLang); err != nil {
+		return "", err
+	}
 
 	// Cache the result
 	s.setInMemoryCache(cacheKey, translated)
@@ -132,26 +176,66 @@ func (s *TranslationService) Translate(ctx context.Context, text, targetLang str
 	return translated, nil
 }
 
-// TranslateBatch translates multiple texts in parallel
+// getCached looks up a translation in the memory cache, then on disk
+func (s *TranslationService) getCached(ctx context.Context, cacheKey string) (string, bool) {
+	if cached, ok := s.getFromMemoryCache(cacheKey); ok {
+		s.logger.Info("Translation cache hit (memory)", "key", cacheKey)
+		recordCacheLookup(ctx, CacheStageTranslation, true)
+		return cached, true
+	}
+
+	if cached, ok := s.getFromDiskCache(cacheKey); ok {
+		// Store in memory for faster future access
+		s.setInMemoryCache(cacheKey, cached)
+		recordCacheLookup(ctx, CacheStageTranslation, true)
+		return cached, true
+	}
+
+	recordCacheLookup(ctx, CacheStageTranslation, false)
+	return "", false
+}
+
+// restoreMarkup puts narration markup back into a translation, warning about markup it dropped
+func (s *TranslationService) restoreMarkup(translated string, markup []string, targetLang string) string {
+	restored, missing := restoreNarrationMarkup(translated, markup)
+	if len(missing) > 0 {
+		s.logger.Warn("Translation dropped narration markup", "lang", targetLang, "markup", strings.Join(missing, " "))
+	}
+	return restored
+}
+
+// checkGlossary reports glossary terms missing from a translation, failing in strict mode
+func (s *TranslationService) checkGlossary(rules glossaryRules, translated, targetLang string) error {
+	missing := rules.missing(translated)
+	if len(missing) == 0 {
+		return nil
+	}
+	if s.strict {
+		return fmt.Errorf("translation to %s is missing glossary terms: %s", targetLang, strings.Join(missing, ", "))
+	}
+	s.logger.Warn("Translation is missing glossary terms", "lang", targetLang, "terms", strings.Join(missing, ", "))
+	return nil
+}
+
+// TranslateBatch translates multiple texts, either in one deck-level request or in parallel
 func (s *TranslationService) TranslateBatch(ctx context.Context, texts []string, targetLang string) ([]string, error) {
+	if s.deckMode {
+		return s.translateDeck(ctx, texts, targetLang)
+	}
+	return s.translateEach(ctx, texts, targetLang)
+}
+
+// translateEach translates multiple texts in parallel, one request per text
+func (s *TranslationService) translateEach(ctx context.Context, texts []string, targetLang string) ([]string, error) {
 	results := make([]string, len(texts))
-	errors := make([]error, len(texts))
-	var wg sync.WaitGroup
-
-	for i, text := range texts {
-		wg.Add(1)
-		go func(idx int, txt string) {
-			defer wg.Done()
-			translated, err := s.Translate(ctx, txt, targetLang)
-			if err != nil {
-				errors[idx] = err
-				return
-			}
-			results[idx] = translated
-		}(i, text)
-	}
-
-	wg.Wait()
+	errors := runBounded(ctx, len(texts), DefaultScheduler().APILimit(), func(idx int) error {
+		translated, err := s.Translate(ctx, texts[idx], targetLang)
+		if err != nil {
+			return err
+		}
+		results[idx] = translated
+		return nil
+	})
 
 	// Check for any errors
 	for i, err := range errors {
@@ -0,0 +1,106 @@
+package services
+
+import (
+	"context"
+	"fmt"
+)
+
+// maxDeckTranslationBatch bounds how many texts go into a single deck-level request.
+const maxDeckTranslationBatch = 40
+
+// deckTranslationProvider is implemented by providers that can translate a whole deck at once.
+type deckTranslationProvider interface {
+	TranslateDeck(ctx context.Context, texts []string, targetLang, instructions string) ([]string, error)
+}
+
+// translateDeck translates uncached texts in deck-level requests so the provider sees every
+// slide of the presentation, falling back to per-slide requests when a response is unusable.
+func (s *TranslationService) translateDeck(ctx context.Context, texts []string, targetLang string) ([]string, error) {
+	deckProvider, ok := s.provider.(deckTranslationProvider)
+	if !ok {
+		return s.translateEach(ctx, texts, targetLang)
+	}
+
+	results := make([]string, len(texts))
+	pending := make([]int, 0, len(texts))
+	for i, text := range texts {
+		if cached, ok := s.getCached(ctx, s.getCacheKey(text, targetLang)); ok {
+			results[i] = cached
+			continue
+		}
+		pending = append(pending, i)
+	}
+
+	for start := 0; start < len(pending); start += maxDeckTranslationBatch {
+		end := min(start+maxDeckTranslationBatch, len(pending))
+		if err := s.translateDeckChunk(ctx, deckProvider, texts, pending[start:end], targetLang, results); err != nil {
+			return nil, err
+		}
+	}
+
+	return results, nil
+}
+
+func (s *TranslationService) translateDeckChunk(
+	ctx context.Context,
+	deckProvider deckTranslationProvider,
+	texts []string,
+	indexes []int,
+	targetLang string,
+	results []string,
+) error {
+	chunk := make([]string, len(indexes))
+	markup := make([][]string, len(indexes))
+	rules := make([]glossaryRules, len(indexes))
+	var combined glossaryRules
+	for i, idx := range indexes {
+		chunk[i], markup[i] = protectNarrationMarkup(texts[idx])
+		rules[i] = s.glossary.rulesFor(texts[idx], targetLang)
+		combined = combined.merge(rules[i])
+	}
+
+	release, err := DefaultScheduler().AcquireAPI(ctx)
+	if err != nil {
+		return fmt.Errorf("translation failed: %w", err)
+	}
+	translated, err := deckProvider.TranslateDeck(ctx, chunk, targetLang, combined.instructions())
+	release()
+
+	var fallback []int
+	if err == nil && len(translated) != len(chunk) {
+		err = fmt.Errorf("expected %d translations, got %d", len(chunk), len(translated))
+	}
+	if err != nil {
+		s.logger.Warn("Deck translation failed, falling back to per-slide translation", "lang", targetLang, "error", err)
+		fallback = indexes
+	} else {
+		for i, idx := range indexes {
+			translated[i] = s.restoreMarkup(translated[i], markup[i], targetLang)
+			if err := s.checkGlossary(rules[i], translated[i], targetLang); err != nil {
+				fallback = append(fallback, idx)
+				continue
+			}
+			cacheKey := s.getCacheKey(texts[idx], targetLang)
+			s.setInMemoryCache(cacheKey, translated[i])
+			s.setInDiskCache(cacheKey, translated[i])
+			results[idx] = translated[i]
+		}
+	}
+
+	if len(fallback) == 0 {
+		return nil
+	}
+
+	fallbackTexts := make([]string, len(fallback))
+	for i, idx := range fallback {
+		fallbackTexts[i] = texts[idx]
+	}
+	fallbackResults, err := s.translateEach(ctx, fallbackTexts, targetLang)
+	if err != nil {
+		return err
+	}
+	for i, idx := range fallback {
+		results[idx] = fallbackResults[i]
+	}
+	return nil
+}
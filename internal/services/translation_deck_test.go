@@ -0,0 +1,103 @@
+package services
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"gocreator/internal/mocks"
+
+	"github.com/openai/openai-go/v3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func deckPrompt() interface{} {
+	return mock.MatchedBy(func(messages []openai.ChatCompletionMessageParamUnion) bool {
+		return strings.Contains(messages[0].OfUser.Content.OfString.Value, "consecutive slide narrations")
+	})
+}
+
+func slidePrompt(text string) interface{} {
+	return mock.MatchedBy(func(messages []openai.ChatCompletionMessageParamUnion) bool {
+		return strings.HasPrefix(messages[0].OfUser.Content.OfString.Value, "Translate '"+text+"'")
+	})
+}
+
+func TestTranslationService_DeckModeUsesSingleRequest(t *testing.T) {
+	mockClient := new(mocks.MockOpenAIClient)
+	service := NewTranslationService(mockClient, &mockLogger{})
+	service.SetDeckMode(true)
+
+	mockClient.On("ChatCompletion", mock.Anything, mock.MatchedBy(func(messages []openai.ChatCompletionMessageParamUnion) bool {
+		prompt := messages[0].OfUser.Content.OfString.Value
+		return assert.Contains(t, prompt, "3 consecutive slide narrations") &&
+			assert.Contains(t, prompt, `["Hello","Pipelines","Goodbye"]`)
+	})).Return("```json\n[\"Hola\", \"Tuberías\", \"Adiós\"]\n```", nil).Once()
+
+	translated, err := service.TranslateBatch(context.Background(), []string{"Hello", "Pipelines", "Goodbye"}, "es")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"Hola", "Tuberías", "Adiós"}, translated)
+
+	// Deck results populate the per-slide cache.
+	cached, err := service.Translate(context.Background(), "Pipelines", "es")
+	require.NoError(t, err)
+	assert.Equal(t, "Tuberías", cached)
+	mockClient.AssertExpectations(t)
+}
+
+func TestTranslationService_DeckModeFallsBackOnCountMismatch(t *testing.T) {
+	mockClient := new(mocks.MockOpenAIClient)
+	service := NewTranslationService(mockClient, &mockLogger{})
+	service.SetDeckMode(true)
+
+	mockClient.On("ChatCompletion", mock.Anything, deckPrompt()).Return(`["Hola"]`, nil).Once()
+	mockClient.On("ChatCompletion", mock.Anything, slidePrompt("Hello")).Return("Hola", nil).Once()
+	mockClient.On("ChatCompletion", mock.Anything, slidePrompt("Goodbye")).Return("Adiós", nil).Once()
+
+	translated, err := service.TranslateBatch(context.Background(), []string{"Hello", "Goodbye"}, "es")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"Hola", "Adiós"}, translated)
+	mockClient.AssertExpectations(t)
+}
+
+func TestTranslationService_DeckModeSkipsCachedTexts(t *testing.T) {
+	mockClient := new(mocks.MockOpenAIClient)
+	service := NewTranslationService(mockClient, &mockLogger{})
+	service.SetDeckMode(true)
+	service.setInMemoryCache(service.getCacheKey("Hello", "es"), "Hola")
+
+	mockClient.On("ChatCompletion", mock.Anything, mock.MatchedBy(func(messages []openai.ChatCompletionMessageParamUnion) bool {
+		return strings.HasSuffix(messages[0].OfUser.Content.OfString.Value, `["Goodbye","Thanks"]`)
+	})).Return(`["Adiós","Gracias"]`, nil).Once()
+
+	translated, err := service.TranslateBatch(context.Background(), []string{"Hello", "Goodbye", "Thanks"}, "es")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"Hola", "Adiós", "Gracias"}, translated)
+	mockClient.AssertExpectations(t)
+}
+
+func TestTranslationService_DeckModeRetriesStrictGlossaryMisses(t *testing.T) {
+	mockClient := new(mocks.MockOpenAIClient)
+	service := NewTranslationService(mockClient, &mockLogger{})
+	service.SetDeckMode(true)
+	service.SetGlossary(testGlossary(), true)
+
+	mockClient.On("ChatCompletion", mock.Anything, deckPrompt()).Return(`["Bonjour","Le créateur"]`, nil).Once()
+	mockClient.On("ChatCompletion", mock.Anything, slidePrompt("GoCreator")).Return("GoCreator", nil).Once()
+
+	translated, err := service.TranslateBatch(context.Background(), []string{"Hello", "GoCreator"}, "fr")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"Bonjour", "GoCreator"}, translated)
+	mockClient.AssertExpectations(t)
+}
+
+func TestParseDeckTranslation(t *testing.T) {
+	translated, err := parseDeckTranslation("Here you go:\n[\"a\", \"b\"]")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"a", "b"}, translated)
+
+	_, err = parseDeckTranslation("a, b")
+	assert.Error(t, err)
+}
@@ -0,0 +1,247 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"gocreator/internal/config"
+	"gocreator/internal/interfaces"
+
+	"github.com/openai/openai-go/v3"
+)
+
+// ErrManualTranslationRequired is returned by the manual translation provider, which never
+// translates and expects every target language to ship its own text sidecars.
+var ErrManualTranslationRequired = errors.New("machine translation is disabled (translation.provider: manual)")
+
+// glossaryTranslationProvider is implemented by providers that can follow glossary instructions.
+type glossaryTranslationProvider interface {
+	TranslateWithInstructions(ctx context.Context, text, targetLang, instructions string) (string, error)
+}
+
+// ChatTranslationProvider translates text through an OpenAI-compatible chat completion API.
+type ChatTranslationProvider struct {
+	name   string
+	model  string
+	client interfaces.OpenAIClient
+}
+
+// NewChatTranslationProvider creates a chat-based translation provider.
+func NewChatTranslationProvider(name string, client interfaces.OpenAIClient, model string) *ChatTranslationProvider {
+	return &ChatTranslationProvider{
+		name:   name,
+		model:  model,
+		client: client,
+	}
+}
+
+// Name returns the provider name.
+func (p *ChatTranslationProvider) Name() string {
+	return p.name
+}
+
+// Model returns the chat model used for translation.
+func (p *ChatTranslationProvider) Model() string {
+	return p.model
+}
+
+// Translate translates text to the target language.
+func (p *ChatTranslationProvider) Translate(ctx context.Context, text, targetLang string) (string, error) {
+	return p.TranslateWithInstructions(ctx, text, targetLang, "")
+}
+
+// TranslateWithInstructions translates text and appends extra instructions, such as glossary
+// rules, to the prompt.
+func (p *ChatTranslationProvider) TranslateWithInstructions(ctx context.Context, text, targetLang, instructions string) (string, error) {
+	if p.client == nil {
+		return "", fmt.Errorf("%s translation provider has no client", p.name)
+	}
+
+	prompt := fmt.Sprintf("Translate '%s' to %s and don't return anything else than the translation.", text, targetLang)
+	if instructions != "" {
+		prompt += " " + instructions
+	}
+	messages := []openai.ChatCompletionMessageParamUnion{
+		openai.UserMessage(prompt),
+	}
+
+	ctx, counted := countAPIAttempts(ctx, APICallTranslation)
+	defer counted()
+	if chatClient, ok := p.client.(interfaces.ChatCompletionClient); ok && p.model != "" {
+		return chatClient.ChatCompletionWithModel(ctx, p.model, messages)
+	}
+
+	return p.client.ChatCompletion(ctx, messages)
+}
+
+// TranslateDeck translates consecutive slide narrations in a single request, exchanging them as
+// JSON arrays so the model keeps terminology consistent across the deck.
+func (p *ChatTranslationProvider) TranslateDeck(ctx context.Context, texts []string, targetLang, instructions string) ([]string, error) {
+	if p.client == nil {
+		return nil, fmt.Errorf("%s translation provider has no client", p.name)
+	}
+
+	input, err := json.Marshal(texts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode deck translation request: %w", err)
+	}
+
+	prompt := fmt.Sprintf(
+		"The following JSON array contains %d consecutive slide narrations from one presentation. "+
+			"Translate each string to %s, keeping terminology consistent across slides. "+
+			"Return only a JSON array of %d translated strings in the same order.",
+		len(texts), targetLang, len(texts),
+	)
+	if instructions != "" {
+		prompt += " " + instructions
+	}
+	messages := []openai.ChatCompletionMessageParamUnion{
+		openai.UserMessage(prompt + "\n\n" + string(input)),
+	}
+
+	ctx, counted := countAPIAttempts(ctx, APICallTranslation)
+	var response string
+	if chatClient, ok := p.client.(interfaces.ChatCompletionClient); ok && p.model != "" {
+		response, err = chatClient.ChatCompletionWithModel(ctx, p.model, messages)
+	} else {
+		response, err = p.client.ChatCompletion(ctx, messages)
+	}
+	counted()
+	if err != nil {
+		return nil, err
+	}
+
+	return parseDeckTranslation(response)
+}
+
+// parseDeckTranslation extracts the JSON array from a chat response, tolerating code fences.
+func parseDeckTranslation(response string) ([]string, error) {
+	response = strings.TrimSpace(response)
+	start := strings.Index(response, "[")
+	end := strings.LastIndex(response, "]")
+	if start < 0 || end < start {
+		return nil, fmt.Errorf("deck translation response is not a JSON array")
+	}
+
+	var translated []string
+	if err := json.Unmarshal([]byte(response[start:end+1]), &translated); err != nil {
+		return nil, fmt.Errorf("invalid deck translation response: %w", err)
+	}
+	return translated, nil
+}
+
+// LibreTranslateProvider translates text with a LibreTranslate-compatible HTTP API.
+type LibreTranslateProvider struct {
+	baseURL    string
+	apiKey     string
+	httpClient *http.Client
+}
+
+// NewLibreTranslateProvider creates a LibreTranslate provider for the given endpoint.
+func NewLibreTranslateProvider(baseURL, apiKey string, httpClient *http.Client) *LibreTranslateProvider {
+	if httpClient == nil {
+		httpClient = &http.Client{Timeout: 60 * time.Second}
+	}
+
+	return &LibreTranslateProvider{
+		baseURL:    strings.TrimRight(baseURL, "/"),
+		apiKey:     apiKey,
+		httpClient: httpClient,
+	}
+}
+
+// Name returns the provider name.
+func (p *LibreTranslateProvider) Name() string {
+	return config.TranslationProviderLibreTranslate
+}
+
+// Model returns the endpoint, which identifies the translation engine for caching.
+func (p *LibreTranslateProvider) Model() string {
+	return p.baseURL
+}
+
+// Translate translates text to the target language.
+func (p *LibreTranslateProvider) Translate(ctx context.Context, text, targetLang string) (string, error) {
+	payload := map[string]string{
+		"q":      text,
+		"source": "auto",
+		"target": targetLang,
+		"format": "text",
+	}
+	if p.apiKey != "" {
+		payload["api_key"] = p.apiKey
+	}
+
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return "", fmt.Errorf("failed to encode translation request: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, p.baseURL+"/translate", bytes.NewReader(body))
+	if err != nil {
+		return "", fmt.Errorf("failed to create translation request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	recordAPICall(ctx, APICallTranslation)
+	resp, err := p.httpClient.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("libretranslate request failed: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return "", fmt.Errorf("failed to read libretranslate response: %w", err)
+	}
+
+	var result struct {
+		TranslatedText string `json:"translatedText"`
+		Error          string `json:"error"`
+	}
+	if err := json.Unmarshal(data, &result); err != nil {
+		return "", fmt.Errorf("invalid libretranslate response (status %d): %w", resp.StatusCode, err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		if result.Error == "" {
+			result.Error = strings.TrimSpace(string(data))
+		}
+		return "", fmt.Errorf("libretranslate returned status %d: %s", resp.StatusCode, result.Error)
+	}
+	if result.Error != "" {
+		return "", fmt.Errorf("libretranslate error: %s", result.Error)
+	}
+
+	return result.TranslatedText, nil
+}
+
+// ManualTranslationProvider refuses to translate so that missing sidecars are reported.
+type ManualTranslationProvider struct{}
+
+// NewManualTranslationProvider creates a manual translation provider.
+func NewManualTranslationProvider() *ManualTranslationProvider {
+	return &ManualTranslationProvider{}
+}
+
+// Name returns the provider name.
+func (p *ManualTranslationProvider) Name() string {
+	return config.TranslationProviderManual
+}
+
+// Model returns an empty model since no engine is involved.
+func (p *ManualTranslationProvider) Model() string {
+	return ""
+}
+
+// Translate always fails with ErrManualTranslationRequired.
+func (p *ManualTranslationProvider) Translate(_ context.Context, _ string, targetLang string) (string, error) {
+	return "", fmt.Errorf("no %s text available: %w", targetLang, ErrManualTranslationRequired)
+}
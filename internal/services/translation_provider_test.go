@@ -0,0 +1,118 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"gocreator/internal/config"
+	"gocreator/internal/mocks"
+
+	"github.com/openai/openai-go/v3"
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+type modelAwareChatClient struct {
+	mocks.MockOpenAIClient
+}
+
+func (m *modelAwareChatClient) ChatCompletionWithModel(ctx context.Context, model string, messages []openai.ChatCompletionMessageParamUnion) (string, error) {
+	args := m.Called(ctx, model, messages)
+	return args.String(0), args.Error(1)
+}
+
+func TestChatTranslationProvider_UsesConfiguredModel(t *testing.T) {
+	client := new(modelAwareChatClient)
+	client.On("ChatCompletionWithModel", mock.Anything, "llama3.1", mock.AnythingOfType("[]openai.ChatCompletionMessageParamUnion")).
+		Return("Bonjour", nil).Once()
+
+	provider := NewChatTranslationProvider(config.TranslationProviderOpenAICompatible, client, "llama3.1")
+	translated, err := provider.Translate(context.Background(), "Hello", "fr")
+
+	require.NoError(t, err)
+	assert.Equal(t, "Bonjour", translated)
+	client.AssertExpectations(t)
+}
+
+func TestLibreTranslateProvider_Translate(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/translate", r.URL.Path)
+
+		var payload map[string]string
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&payload))
+		assert.Equal(t, "Hello", payload["q"])
+		assert.Equal(t, "de", payload["target"])
+		assert.Equal(t, "secret", payload["api_key"])
+
+		_, _ = w.Write([]byte(`{"translatedText":"Hallo"}`))
+	}))
+	defer server.Close()
+
+	provider := NewLibreTranslateProvider(server.URL+"/", "secret", server.Client())
+	translated, err := provider.Translate(context.Background(), "Hello", "de")
+
+	require.NoError(t, err)
+	assert.Equal(t, "Hallo", translated)
+	assert.Equal(t, server.URL, provider.Model())
+}
+
+func TestLibreTranslateProvider_ReportsErrors(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte(`{"error":"zz is not supported"}`))
+	}))
+	defer server.Close()
+
+	provider := NewLibreTranslateProvider(server.URL, "", server.Client())
+	_, err := provider.Translate(context.Background(), "Hello", "zz")
+
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "status 400")
+	assert.Contains(t, err.Error(), "zz is not supported")
+}
+
+func TestTranslationService_CacheKeyIncludesProvider(t *testing.T) {
+	logger := &mockLogger{}
+	client := new(mocks.MockOpenAIClient)
+
+	legacy := NewTranslationService(client, logger)
+	local := NewTranslationServiceWithProvider(NewChatTranslationProvider(config.TranslationProviderOpenAICompatible, client, "llama3.1"), logger, nil, "")
+	otherModel := NewTranslationServiceWithProvider(NewChatTranslationProvider(config.TranslationProviderOpenAI, client, "gpt-4o"), logger, nil, "")
+
+	legacyKey := legacy.getCacheKey("Hello", "fr")
+	assert.NotEqual(t, legacyKey, local.getCacheKey("Hello", "fr"))
+	assert.NotEqual(t, legacyKey, otherModel.getCacheKey("Hello", "fr"))
+	assert.NotEqual(t, local.getCacheKey("Hello", "fr"), otherModel.getCacheKey("Hello", "fr"))
+}
+
+func TestVideoCreatorResolveTexts_ManualProviderListsMissingSidecars(t *testing.T) {
+	fs := afero.NewMemMapFs()
+	logger := &mockLogger{}
+	slidesDir := testPath("test", "data", "slides")
+	slides := []string{
+		testPath("test", "data", "slides", "01-intro.png"),
+		testPath("test", "data", "slides", "02-demo.png"),
+	}
+
+	require.NoError(t, writeTestFile(fs, testPath("test", "data", "slides", "01-intro.txt"), "Hello"))
+	require.NoError(t, writeTestFile(fs, testPath("test", "data", "slides", "02-demo.txt"), "Demo"))
+	require.NoError(t, writeTestFile(fs, testPath("test", "data", "slides", "01-intro.fr.txt"), "Bonjour"))
+
+	creator := &VideoCreator{
+		fs:                 fs,
+		translationService: NewTranslationServiceWithProvider(NewManualTranslationProvider(), logger, nil, ""),
+		logger:             logger,
+	}
+
+	_, _, err := creator.resolveTextsForLanguage(context.Background(), "en", "fr", slidesDir, slides, config.TimingConfig{})
+	require.Error(t, err)
+	assert.True(t, errors.Is(err, ErrManualTranslationRequired))
+	assert.Contains(t, err.Error(), "02-demo.fr.txt")
+	assert.NotContains(t, err.Error(), "01-intro.fr.txt")
+}
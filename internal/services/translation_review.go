@@ -0,0 +1,367 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"encoding/xml"
+	"fmt"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/spf13/afero"
+)
+
+// TranslationEntry is one slide narration in one target language, prepared for human review.
+type TranslationEntry struct {
+	Slide   string // narration base name, e.g. 01-intro
+	Lang    string
+	Source  string
+	Target  string
+	Machine bool // true when the target text came from machine translation rather than a sidecar
+}
+
+// CollectTranslations loads the slides and resolves the narration text of every non-input output
+// language, translating missing sidecars without generating audio or video.
+func (vc *VideoCreator) CollectTranslations(ctx context.Context, cfg VideoCreatorConfig) ([]TranslationEntry, error) {
+	slidesDir := filepath.Join(cfg.RootDir, "data", "slides")
+	slides, err := vc.slideService.LoadSlides(ctx, slidesDir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load slides: %w", err)
+	}
+	if len(slides) == 0 {
+		return nil, fmt.Errorf("no slides found in %s", slidesDir)
+	}
+	timing, err := vc.resolveSilentSlides(slidesDir, slides, cfg.Timing)
+	if err != nil {
+		return nil, err
+	}
+
+	var entries []TranslationEntry
+	for _, lang := range cfg.OutputLangs {
+		if lang == cfg.InputLang {
+			continue
+		}
+
+		texts, translatedIndexes, err := vc.resolveTextsForLanguage(ctx, cfg.InputLang, lang, slidesDir, slides, timing)
+		if err != nil {
+			return nil, fmt.Errorf("failed to resolve texts for %s: %w", lang, err)
+		}
+
+		machine := make(map[int]bool, len(translatedIndexes))
+		for _, idx := range translatedIndexes {
+			machine[idx] = true
+		}
+
+		for idx, slidePath := range slides {
+			if texts[idx] == "" {
+				continue
+			}
+			source, _, err := vc.resolveSourceText(ctx, slidesDir, slidePath, cfg.InputLang)
+			if err != nil {
+				return nil, err
+			}
+			entries = append(entries, TranslationEntry{
+				Slide:   slideNarrationBaseCandidates(slidePath)[0],
+				Lang:    lang,
+				Source:  source,
+				Target:  texts[idx],
+				Machine: machine[idx],
+			})
+		}
+	}
+
+	return entries, nil
+}
+
+// WriteTranslationSidecars writes entries as basename.<lang>.txt sidecars in slidesDir.
+// Unless overwrite is set, only machine translations are written so existing sidecars stay untouched.
+// Entries with an empty target would blank the narration, so they are skipped and returned.
+func WriteTranslationSidecars(fs afero.Fs, slidesDir string, entries []TranslationEntry, overwrite bool) ([]string, []TranslationEntry, error) {
+	if err := fs.MkdirAll(slidesDir, 0755); err != nil {
+		return nil, nil, fmt.Errorf("failed to create slides directory: %w", err)
+	}
+
+	written := make([]string, 0, len(entries))
+	var skipped []TranslationEntry
+	for _, entry := range entries {
+		if !overwrite && !entry.Machine {
+			continue
+		}
+		if entry.Slide == "" || entry.Lang == "" || strings.ContainsAny(entry.Slide, `/\`) {
+			return written, skipped, fmt.Errorf("invalid translation entry for slide %q and language %q", entry.Slide, entry.Lang)
+		}
+		if strings.TrimSpace(entry.Target) == "" {
+			skipped = append(skipped, entry)
+			continue
+		}
+
+		path := filepath.Join(slidesDir, fmt.Sprintf("%s.%s.txt", entry.Slide, entry.Lang))
+		if err := afero.WriteFile(fs, path, []byte(strings.TrimSpace(entry.Target)+"\n"), 0644); err != nil {
+			return written, skipped, fmt.Errorf("failed to write sidecar %s: %w", path, err)
+		}
+		written = append(written, path)
+	}
+
+	return written, skipped, nil
+}
+
+// WriteTranslationBundle writes entries to a review bundle. The format follows the file
+// extension: .xlf/.xliff (XLIFF 1.2), .po (gettext), or .csv.
+func WriteTranslationBundle(fs afero.Fs, path, sourceLang string, entries []TranslationEntry) error {
+	var data []byte
+	var err error
+
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".xlf", ".xliff":
+		data, err = encodeXLIFF(sourceLang, entries)
+	case ".po":
+		data = encodePO(entries)
+	case ".csv":
+		data, err = encodeTranslationCSV(entries)
+	default:
+		return fmt.Errorf("unsupported translation bundle format %q (use .xlf, .po, or .csv)", filepath.Ext(path))
+	}
+	if err != nil {
+		return err
+	}
+
+	if dir := filepath.Dir(path); dir != "" {
+		if err := fs.MkdirAll(dir, 0755); err != nil {
+			return fmt.Errorf("failed to create bundle directory: %w", err)
+		}
+	}
+	if err := afero.WriteFile(fs, path, data, 0644); err != nil {
+		return fmt.Errorf("failed to write translation bundle: %w", err)
+	}
+	return nil
+}
+
+// ReadTranslationBundle reads reviewed entries from an XLIFF, PO, or CSV bundle. The entries are
+// human-reviewed, so none of them is marked as a machine translation.
+func ReadTranslationBundle(fs afero.Fs, path string) ([]TranslationEntry, error) {
+	data, err := afero.ReadFile(fs, path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read translation bundle: %w", err)
+	}
+
+	var entries []TranslationEntry
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".xlf", ".xliff":
+		entries, err = decodeXLIFF(data)
+	case ".po":
+		entries, err = decodePO(data)
+	case ".csv":
+		entries, err = decodeTranslationCSV(data)
+	default:
+		return nil, fmt.Errorf("unsupported translation bundle format %q (use .xlf, .po, or .csv)", filepath.Ext(path))
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse translation bundle %s: %w", path, err)
+	}
+	return entries, nil
+}
+
+type xliffDocument struct {
+	XMLName xml.Name    `xml:"urn:oasis:names:tc:xliff:document:1.2 xliff"`
+	Version string      `xml:"version,attr"`
+	Files   []xliffFile `xml:"file"`
+}
+
+type xliffFile struct {
+	Original       string           `xml:"original,attr"`
+	SourceLanguage string           `xml:"source-language,attr"`
+	TargetLanguage string           `xml:"target-language,attr"`
+	Datatype       string           `xml:"datatype,attr"`
+	Units          []xliffTransUnit `xml:"body>trans-unit"`
+}
+
+type xliffTransUnit struct {
+	ID     string `xml:"id,attr"`
+	Source string `xml:"source"`
+	Target string `xml:"target"`
+}
+
+func encodeXLIFF(sourceLang string, entries []TranslationEntry) ([]byte, error) {
+	doc := xliffDocument{Version: "1.2"}
+	files := make(map[string]int)
+	for _, lang := range entryLanguages(entries) {
+		files[lang] = len(doc.Files)
+		doc.Files = append(doc.Files, xliffFile{
+			Original:       "slides",
+			SourceLanguage: sourceLang,
+			TargetLanguage: lang,
+			Datatype:       "plaintext",
+		})
+	}
+	for _, entry := range entries {
+		file := &doc.Files[files[entry.Lang]]
+		file.Units = append(file.Units, xliffTransUnit{ID: entry.Slide, Source: entry.Source, Target: entry.Target})
+	}
+
+	data, err := xml.MarshalIndent(doc, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode XLIFF: %w", err)
+	}
+	return append([]byte(xml.Header), append(data, '\n')...), nil
+}
+
+func decodeXLIFF(data []byte) ([]TranslationEntry, error) {
+	var doc xliffDocument
+	if err := xml.Unmarshal(data, &doc); err != nil {
+		return nil, err
+	}
+
+	var entries []TranslationEntry
+	for _, file := range doc.Files {
+		if file.TargetLanguage == "" {
+			return nil, fmt.Errorf("file element is missing target-language")
+		}
+		for _, unit := range file.Units {
+			entries = append(entries, TranslationEntry{Slide: unit.ID, Lang: file.TargetLanguage, Source: unit.Source, Target: unit.Target})
+		}
+	}
+	return entries, nil
+}
+
+// encodePO writes a gettext catalog; msgctxt holds "<lang>:<slide>" so several languages fit in one file.
+func encodePO(entries []TranslationEntry) []byte {
+	var b bytes.Buffer
+	b.WriteString("msgid \"\"\nmsgstr \"\"\n\"Content-Type: text/plain; charset=UTF-8\\n\"\n")
+	for _, entry := range entries {
+		fmt.Fprintf(&b, "\nmsgctxt %s\nmsgid %s\nmsgstr %s\n",
+			strconv.Quote(entry.Lang+":"+entry.Slide), strconv.Quote(entry.Source), strconv.Quote(entry.Target))
+	}
+	return b.Bytes()
+}
+
+func decodePO(data []byte) ([]TranslationEntry, error) {
+	var entries []TranslationEntry
+	var current map[string]string
+	var field string
+
+	flush := func() error {
+		if current == nil {
+			return nil
+		}
+		ctx, ok := current["msgctxt"]
+		if ok {
+			lang, slide, found := strings.Cut(ctx, ":")
+			if !found {
+				return fmt.Errorf("msgctxt %q must be <lang>:<slide>", ctx)
+			}
+			entries = append(entries, TranslationEntry{Slide: slide, Lang: lang, Source: current["msgid"], Target: current["msgstr"]})
+		}
+		current = nil
+		return nil
+	}
+
+	for lineNumber, line := range strings.Split(string(data), "\n") {
+		line = strings.TrimSpace(line)
+		switch {
+		case line == "" || strings.HasPrefix(line, "#"):
+			continue
+		case strings.HasPrefix(line, `"`):
+			if current == nil || field == "" {
+				return nil, fmt.Errorf("line %d: unexpected string continuation", lineNumber+1)
+			}
+			value, err := strconv.Unquote(line)
+			if err != nil {
+				return nil, fmt.Errorf("line %d: %w", lineNumber+1, err)
+			}
+			current[field] += value
+		default:
+			keyword, rest, _ := strings.Cut(line, " ")
+			if keyword != "msgctxt" && keyword != "msgid" && keyword != "msgstr" {
+				return nil, fmt.Errorf("line %d: unsupported keyword %q", lineNumber+1, keyword)
+			}
+			// A new entry starts at msgctxt, or at msgid when the current entry already has one.
+			if keyword == "msgctxt" || (keyword == "msgid" && current != nil && hasKey(current, "msgid")) {
+				if err := flush(); err != nil {
+					return nil, err
+				}
+			}
+			if current == nil {
+				current = make(map[string]string)
+			}
+			value, err := strconv.Unquote(strings.TrimSpace(rest))
+			if err != nil {
+				return nil, fmt.Errorf("line %d: %w", lineNumber+1, err)
+			}
+			field = keyword
+			current[field] = value
+		}
+	}
+
+	if err := flush(); err != nil {
+		return nil, err
+	}
+	return entries, nil
+}
+
+func hasKey(values map[string]string, key string) bool {
+	_, ok := values[key]
+	return ok
+}
+
+func encodeTranslationCSV(entries []TranslationEntry) ([]byte, error) {
+	var b bytes.Buffer
+	writer := csv.NewWriter(&b)
+	records := [][]string{{"slide", "lang", "source", "target"}}
+	for _, entry := range entries {
+		records = append(records, []string{entry.Slide, entry.Lang, entry.Source, entry.Target})
+	}
+	if err := writer.WriteAll(records); err != nil {
+		return nil, fmt.Errorf("failed to encode CSV: %w", err)
+	}
+	return b.Bytes(), nil
+}
+
+func decodeTranslationCSV(data []byte) ([]TranslationEntry, error) {
+	records, err := csv.NewReader(bytes.NewReader(data)).ReadAll()
+	if err != nil {
+		return nil, err
+	}
+	if len(records) == 0 {
+		return nil, nil
+	}
+
+	columns := make(map[string]int)
+	for i, name := range records[0] {
+		columns[strings.ToLower(strings.TrimSpace(name))] = i
+	}
+	for _, required := range []string{"slide", "lang", "target"} {
+		if _, ok := columns[required]; !ok {
+			return nil, fmt.Errorf("missing %q column", required)
+		}
+	}
+
+	entries := make([]TranslationEntry, 0, len(records)-1)
+	for _, record := range records[1:] {
+		entry := TranslationEntry{
+			Slide:  record[columns["slide"]],
+			Lang:   record[columns["lang"]],
+			Target: record[columns["target"]],
+		}
+		if idx, ok := columns["source"]; ok {
+			entry.Source = record[idx]
+		}
+		entries = append(entries, entry)
+	}
+	return entries, nil
+}
+
+func entryLanguages(entries []TranslationEntry) []string {
+	seen := make(map[string]bool)
+	var langs []string
+	for _, entry := range entries {
+		if !seen[entry.Lang] {
+			seen[entry.Lang] = true
+			langs = append(langs, entry.Lang)
+		}
+	}
+	sort.Strings(langs)
+	return langs
+}
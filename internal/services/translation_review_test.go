@@ -0,0 +1,141 @@
+package services
+
+import (
+	"context"
+	"testing"
+
+	"gocreator/internal/mocks"
+
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestVideoCreatorCollectTranslations(t *testing.T) {
+	fs := afero.NewMemMapFs()
+	mockTranslation := new(mocks.MockTranslator)
+	mockSlide := new(mocks.MockSlideLoader)
+
+	slidesDir := testPath("test", "data", "slides")
+	slides := []string{
+		testPath("test", "data", "slides", "01-intro.png"),
+		testPath("test", "data", "slides", "02-demo.png"),
+		testPath("test", "data", "slides", "03-outro.png"),
+	}
+
+	require.NoError(t, writeTestFile(fs, testPath("test", "data", "slides", "01-intro.txt"), "Hello"))
+	require.NoError(t, writeTestFile(fs, testPath("test", "data", "slides", "02-demo.txt"), "Demo"))
+	require.NoError(t, writeTestFile(fs, testPath("test", "data", "slides", "02-demo.es.txt"), "Demostración"))
+	require.NoError(t, writeTestFile(fs, testPath("test", "data", "slides", "03-outro.es.mp3"), "audio"))
+
+	mockSlide.On("LoadSlides", mock.Anything, slidesDir).Return(slides, nil).Once()
+	mockTranslation.On("TranslateBatch", mock.Anything, []string{"Hello"}, "es").Return([]string{"Hola"}, nil).Once()
+
+	creator := NewVideoCreator(fs, nil, mockTranslation, nil, nil, mockSlide, &mockLogger{})
+	entries, err := creator.CollectTranslations(context.Background(), VideoCreatorConfig{
+		RootDir:     testPath("test"),
+		InputLang:   "en",
+		OutputLangs: []string{"en", "es"},
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, []TranslationEntry{
+		{Slide: "01-intro", Lang: "es", Source: "Hello", Target: "Hola", Machine: true},
+		{Slide: "02-demo", Lang: "es", Source: "Demo", Target: "Demostración"},
+	}, entries)
+	mockSlide.AssertExpectations(t)
+	mockTranslation.AssertExpectations(t)
+}
+
+func TestWriteTranslationSidecars(t *testing.T) {
+	fs := afero.NewMemMapFs()
+	slidesDir := testPath("test", "data", "slides")
+	entries := []TranslationEntry{
+		{Slide: "01-intro", Lang: "es", Target: "Hola", Machine: true},
+		{Slide: "02-demo", Lang: "es", Target: "Demostración"},
+	}
+
+	written, skipped, err := WriteTranslationSidecars(fs, slidesDir, entries, false)
+	require.NoError(t, err)
+	assert.Equal(t, []string{testPath("test", "data", "slides", "01-intro.es.txt")}, written)
+	assert.Empty(t, skipped)
+
+	data, err := afero.ReadFile(fs, written[0])
+	require.NoError(t, err)
+	assert.Equal(t, "Hola\n", string(data))
+
+	_, _, err = WriteTranslationSidecars(fs, slidesDir, []TranslationEntry{{Slide: "../evil", Lang: "es", Machine: true}}, true)
+	assert.Error(t, err)
+}
+
+func TestWriteTranslationSidecars_SkipsEmptyTargets(t *testing.T) {
+	fs := afero.NewMemMapFs()
+	slidesDir := testPath("test", "data", "slides")
+	existing := testPath("test", "data", "slides", "02-demo.es.txt")
+	require.NoError(t, writeTestFile(fs, existing, "Demostración\n"))
+
+	written, skipped, err := WriteTranslationSidecars(fs, slidesDir, []TranslationEntry{
+		{Slide: "01-intro", Lang: "es", Target: "Hola"},
+		{Slide: "02-demo", Lang: "es", Target: "  "},
+	}, true)
+	require.NoError(t, err)
+	assert.Equal(t, []string{testPath("test", "data", "slides", "01-intro.es.txt")}, written)
+	assert.Equal(t, []TranslationEntry{{Slide: "02-demo", Lang: "es", Target: "  "}}, skipped)
+
+	data, err := afero.ReadFile(fs, existing)
+	require.NoError(t, err)
+	assert.Equal(t, "Demostración\n", string(data))
+}
+
+func TestTranslationBundle_RoundTrip(t *testing.T) {
+	entries := []TranslationEntry{
+		{Slide: "01-intro", Lang: "de", Source: "Hello, \"world\"", Target: "Hallo, „Welt“"},
+		{Slide: "01-intro", Lang: "fr", Source: "Hello, \"world\"", Target: "Bonjour\nle monde"},
+		{Slide: "02-demo", Lang: "fr", Source: "Demo <b>", Target: "Démo <b>"},
+	}
+
+	for _, name := range []string{"review.xlf", "review.po", "review.csv"} {
+		t.Run(name, func(t *testing.T) {
+			fs := afero.NewMemMapFs()
+			path := testPath("bundles", name)
+
+			require.NoError(t, WriteTranslationBundle(fs, path, "en", entries))
+			imported, err := ReadTranslationBundle(fs, path)
+			require.NoError(t, err)
+
+			require.Len(t, imported, len(entries))
+			for i, entry := range imported {
+				assert.Equal(t, entries[i].Slide, entry.Slide)
+				assert.Equal(t, entries[i].Lang, entry.Lang)
+				assert.Equal(t, entries[i].Source, entry.Source)
+				assert.Equal(t, entries[i].Target, entry.Target)
+				assert.False(t, entry.Machine)
+			}
+		})
+	}
+}
+
+func TestWriteTranslationBundle_XLIFFGroupsLanguages(t *testing.T) {
+	fs := afero.NewMemMapFs()
+	path := testPath("bundles", "review.xlf")
+
+	require.NoError(t, WriteTranslationBundle(fs, path, "en", []TranslationEntry{
+		{Slide: "01-intro", Lang: "fr", Source: "Hello", Target: "Bonjour"},
+	}))
+
+	data, err := afero.ReadFile(fs, path)
+	require.NoError(t, err)
+	assert.Contains(t, string(data), `<xliff xmlns="urn:oasis:names:tc:xliff:document:1.2" version="1.2">`)
+	assert.Contains(t, string(data), `source-language="en" target-language="fr"`)
+	assert.Contains(t, string(data), `<trans-unit id="01-intro">`)
+}
+
+func TestReadTranslationBundle_RejectsUnknownFormat(t *testing.T) {
+	fs := afero.NewMemMapFs()
+	require.NoError(t, writeTestFile(fs, testPath("bundles", "review.json"), "{}"))
+
+	_, err := ReadTranslationBundle(fs, testPath("bundles", "review.json"))
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "unsupported translation bundle format")
+}
@@ -40,6 +40,15 @@ func TestTranslationService_Translate(t *testing.T) {
 			expectedResult: "",
 			expectError:    true,
 		},
+		{
+			name:           "narration markup is restored",
+			inputText:      "Hello [pause 1s] {GIF|jiff}",
+			targetLang:     "es",
+			mockResponse:   "Hola ⟦0⟧ ⟦1⟧",
+			mockError:      nil,
+			expectedResult: "Hola [pause 1s] {GIF|jiff}",
+			expectError:    false,
+		},
 		{
 			name:           "empty text",
 			inputText:      "",
@@ -0,0 +1,308 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+
+	"gocreator/internal/config"
+	"gocreator/internal/interfaces"
+
+	"github.com/spf13/afero"
+)
+
+// TTSProviderRegistry resolves speech engines by provider name.
+type TTSProviderRegistry struct {
+	mu        sync.RWMutex
+	providers map[string]interfaces.TTSProvider
+}
+
+// NewTTSProviderRegistry creates a registry with the OpenAI provider and the configured local engines.
+func NewTTSProviderRegistry(fs afero.Fs, client interfaces.OpenAIClient, cfg config.VoiceConfig) *TTSProviderRegistry {
+	return NewTTSProviderRegistryWithExecutor(fs, client, cfg, nil)
+}
+
+// NewTTSProviderRegistryWithExecutor creates a provider registry with an injected command executor.
+func NewTTSProviderRegistryWithExecutor(fs afero.Fs, client interfaces.OpenAIClient, cfg config.VoiceConfig, executor interfaces.CommandExecutor) *TTSProviderRegistry {
+	if executor == nil {
+		executor = newCommandExecutor()
+	}
+
+	registry := &TTSProviderRegistry{
+		providers: make(map[string]interfaces.TTSProvider),
+	}
+	if client != nil {
+		registry.Register(NewOpenAITTSProvider(client))
+	}
+	registry.Register(NewPiperTTSProvider(fs, executor, cfg.Piper))
+	registry.Register(NewEspeakTTSProvider(fs, executor, cfg.Espeak))
+	if strings.TrimSpace(cfg.Command.Binary) != "" {
+		registry.Register(NewCommandTTSProvider(config.TTSProviderCommand, fs, executor, cfg.Command, nil))
+	}
+
+	return registry
+}
+
+// Register adds or replaces a provider under its own name.
+func (r *TTSProviderRegistry) Register(provider interfaces.TTSProvider) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.providers[strings.ToLower(provider.Name())] = provider
+}
+
+// Get returns the provider registered under name.
+func (r *TTSProviderRegistry) Get(name string) (interfaces.TTSProvider, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	normalized := strings.ToLower(strings.TrimSpace(name))
+	if normalized == "" {
+		normalized = config.TTSProviderOpenAI
+	}
+	if provider, ok := r.providers[normalized]; ok {
+		return provider, nil
+	}
+
+	return nil, fmt.Errorf("unknown TTS provider %q (available: %s)", name, strings.Join(r.namesLocked(), ", "))
+}
+
+// Names returns the registered provider names in sorted order.
+func (r *TTSProviderRegistry) Names() []string {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.namesLocked()
+}
+
+func (r *TTSProviderRegistry) namesLocked() []string {
+	names := make([]string, 0, len(r.providers))
+	for name := range r.providers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// OpenAITTSProvider synthesizes speech through the OpenAI speech API.
+type OpenAITTSProvider struct {
+	client interfaces.OpenAIClient
+}
+
+// NewOpenAITTSProvider creates a provider backed by an OpenAI client.
+func NewOpenAITTSProvider(client interfaces.OpenAIClient) *OpenAITTSProvider {
+	return &OpenAITTSProvider{client: client}
+}
+
+// Name returns the provider name.
+func (p *OpenAITTSProvider) Name() string {
+	return config.TTSProviderOpenAI
+}
+
+// Synthesize generates speech, using per-request options when the client supports them.
+func (p *OpenAITTSProvider) Synthesize(ctx context.Context, text string, options interfaces.SpeechOptions) (io.ReadCloser, error) {
+	release, err := DefaultScheduler().AcquireAPI(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, counted := countAPIAttempts(ctx, APICallTTS)
+	defer counted()
+	var body io.ReadCloser
+	if client, ok := p.client.(interfaces.SpeechSynthesisClient); ok && !isZeroSpeechOptions(options) {
+		body, err = client.GenerateSpeechWithOptions(ctx, text, options)
+	} else {
+		body, err = p.client.GenerateSpeech(ctx, text)
+	}
+	if err != nil || body == nil {
+		release()
+		return body, err
+	}
+
+	// Keep the API slot until the streamed response has been consumed.
+	return &releasingReadCloser{ReadCloser: body, release: release}, nil
+}
+
+// releasingReadCloser releases a scheduler slot when the wrapped body is closed.
+type releasingReadCloser struct {
+	io.ReadCloser
+	release func()
+}
+
+func (r *releasingReadCloser) Close() error {
+	defer r.release()
+	return r.ReadCloser.Close()
+}
+
+// CommandTTSProvider synthesizes speech by running a local engine that writes an audio file.
+type CommandTTSProvider struct {
+	name            string
+	fs              afero.Fs
+	commandExecutor interfaces.CommandExecutor
+	binary          string
+	args            []string
+	format          string
+	ssml            bool
+}
+
+// NewPiperTTSProvider creates a provider for the Piper neural TTS engine. Piper reads the text
+// from standard input.
+func NewPiperTTSProvider(fs afero.Fs, executor interfaces.CommandExecutor, cfg config.LocalTTSConfig) *CommandTTSProvider {
+	return NewCommandTTSProvider(config.TTSProviderPiper, fs, executor, withDefaultBinary(cfg, "piper"), []string{
+		"--model", "{voice}",
+		"--output_file", "{output}",
+		"--length_scale", "{length_scale}",
+	})
+}
+
+// NewEspeakTTSProvider creates a provider for the espeak-ng formant synthesizer.
+// With SSML enabled it is started with -m so it interprets the markup.
+func NewEspeakTTSProvider(fs afero.Fs, executor interfaces.CommandExecutor, cfg config.LocalTTSConfig) *CommandTTSProvider {
+	args := []string{
+		"-v", "{voice}",
+		"-s", "{wpm}",
+		"-w", "{output}",
+		"-f", "{text_file}",
+	}
+	if cfg.SSML {
+		args = append([]string{"-m"}, args...)
+	}
+	return NewCommandTTSProvider(config.TTSProviderEspeak, fs, executor, withDefaultBinary(cfg, "espeak-ng"), args)
+}
+
+// NewCommandTTSProvider creates a provider that runs cfg.Binary with templated arguments.
+// Configured arguments take precedence over defaultArgs.
+func NewCommandTTSProvider(name string, fs afero.Fs, executor interfaces.CommandExecutor, cfg config.LocalTTSConfig, defaultArgs []string) *CommandTTSProvider {
+	if executor == nil {
+		executor = newCommandExecutor()
+	}
+
+	args := defaultArgs
+	if len(cfg.Args) > 0 {
+		args = cfg.Args
+	}
+
+	format := strings.TrimPrefix(strings.ToLower(strings.TrimSpace(cfg.Format)), ".")
+	if format == "" {
+		format = "wav"
+	}
+
+	return &CommandTTSProvider{
+		name:            name,
+		fs:              fs,
+		commandExecutor: executor,
+		binary:          cfg.Binary,
+		args:            append([]string(nil), args...),
+		format:          format,
+		ssml:            cfg.SSML,
+	}
+}
+
+// Name returns the provider name.
+func (p *CommandTTSProvider) Name() string {
+	return p.name
+}
+
+// Synthesize runs the engine and returns the audio it wrote.
+func (p *CommandTTSProvider) Synthesize(ctx context.Context, text string, options interfaces.SpeechOptions) (io.ReadCloser, error) {
+	body, _, err := p.SynthesizeWithTimestamps(ctx, text, options)
+	return body, err
+}
+
+// SynthesizeWithTimestamps runs the engine and returns the audio it wrote, with the word timestamps
+// it wrote to {timestamps} when the arguments use that placeholder.
+func (p *CommandTTSProvider) SynthesizeWithTimestamps(ctx context.Context, text string, options interfaces.SpeechOptions) (io.ReadCloser, []interfaces.WordTimestamp, error) {
+	if p.usesPlaceholder("{voice}") && strings.TrimSpace(options.Voice) == "" {
+		return nil, nil, fmt.Errorf("%s TTS requires a voice (set voice.%s.voice)", p.name, p.name)
+	}
+
+	workDir, err := afero.TempDir(p.fs, "", "gocreator-tts-")
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to create %s TTS work directory: %w", p.name, err)
+	}
+	defer func() { _ = p.fs.RemoveAll(workDir) }()
+
+	textPath := filepath.Join(workDir, "input.txt")
+	outputPath := filepath.Join(workDir, "speech."+p.format)
+	timestampsPath := filepath.Join(workDir, "timestamps.json")
+	if err := afero.WriteFile(p.fs, textPath, []byte(text), 0644); err != nil {
+		return nil, nil, fmt.Errorf("failed to write %s TTS input: %w", p.name, err)
+	}
+
+	speed := options.Speed
+	if speed <= 0 {
+		speed = 1.0
+	}
+	replacer := strings.NewReplacer(
+		"{text}", text,
+		"{text_file}", textPath,
+		"{output}", outputPath,
+		"{timestamps}", timestampsPath,
+		"{voice}", options.Voice,
+		"{speed}", fmt.Sprintf("%.3f", speed),
+		"{wpm}", fmt.Sprintf("%d", int(175*speed)),
+		"{length_scale}", fmt.Sprintf("%.3f", 1/speed),
+	)
+	args := make([]string, len(p.args))
+	for i, arg := range p.args {
+		args[i] = replacer.Replace(arg)
+	}
+
+	// Engines whose arguments do not take the text read it from standard input
+	var result interfaces.CommandResult
+	if p.usesPlaceholder("{text}") || p.usesPlaceholder("{text_file}") {
+		result, err = p.commandExecutor.Run(ctx, p.binary, args...)
+	} else if executor, ok := p.commandExecutor.(interfaces.StdinCommandExecutor); ok {
+		result, err = executor.RunWithStdin(ctx, []byte(text), p.binary, args...)
+	} else {
+		return nil, nil, fmt.Errorf("%s TTS reads text from stdin, which the command executor does not support", p.name)
+	}
+	if err != nil {
+		return nil, nil, fmt.Errorf("%s TTS error: %w, stderr: %s", p.name, err, string(result.Stderr))
+	}
+
+	data, err := afero.ReadFile(p.fs, outputPath)
+	if err != nil {
+		return nil, nil, fmt.Errorf("%s TTS did not write %s: %w", p.name, outputPath, err)
+	}
+	if len(data) == 0 {
+		return nil, nil, fmt.Errorf("%s TTS produced empty audio", p.name)
+	}
+
+	var words []interfaces.WordTimestamp
+	if p.usesPlaceholder("{timestamps}") {
+		timestamps, err := afero.ReadFile(p.fs, timestampsPath)
+		if err != nil {
+			return nil, nil, fmt.Errorf("%s TTS did not write %s: %w", p.name, timestampsPath, err)
+		}
+		if words, err = parseWordTimestamps(timestamps); err != nil {
+			return nil, nil, fmt.Errorf("%s TTS timestamps: %w", p.name, err)
+		}
+	}
+
+	return io.NopCloser(bytes.NewReader(data)), words, nil
+}
+
+// SupportsSSML reports whether the engine is configured to read SSML.
+func (p *CommandTTSProvider) SupportsSSML() bool {
+	return p.ssml
+}
+
+func (p *CommandTTSProvider) usesPlaceholder(placeholder string) bool {
+	for _, arg := range p.args {
+		if strings.Contains(arg, placeholder) {
+			return true
+		}
+	}
+	return false
+}
+
+func withDefaultBinary(cfg config.LocalTTSConfig, binary string) config.LocalTTSConfig {
+	if strings.TrimSpace(cfg.Binary) == "" {
+		cfg.Binary = binary
+	}
+	return cfg
+}
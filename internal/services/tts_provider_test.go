@@ -0,0 +1,164 @@
+package services
+
+import (
+	"context"
+	"io"
+	"testing"
+
+	"gocreator/internal/config"
+	"gocreator/internal/interfaces"
+	"gocreator/internal/mocks"
+
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTTSProviderRegistry_Get(t *testing.T) {
+	fs := afero.NewMemMapFs()
+	registry := NewTTSProviderRegistryWithExecutor(fs, new(mocks.MockOpenAIClient), config.VoiceConfig{}, newFakeCommandExecutor())
+
+	provider, err := registry.Get("")
+	require.NoError(t, err)
+	assert.Equal(t, config.TTSProviderOpenAI, provider.Name())
+
+	provider, err = registry.Get("Piper")
+	require.NoError(t, err)
+	assert.Equal(t, config.TTSProviderPiper, provider.Name())
+
+	_, err = registry.Get(config.TTSProviderCommand)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "available: espeak, openai, piper")
+}
+
+func TestCommandTTSProvider_EspeakWritesAudio(t *testing.T) {
+	fs := afero.NewMemMapFs()
+	executor := newFakeCommandExecutor(expectedCommand{
+		Name:     "espeak-ng",
+		Contains: []string{"-v fr", "-s 262", "-w ", "-f "},
+		Run: func(_ string, args []string) {
+			require.NoError(t, afero.WriteFile(fs, args[5], []byte("RIFF-audio"), 0644))
+		},
+	})
+	provider := NewEspeakTTSProvider(fs, executor, config.LocalTTSConfig{})
+
+	body, err := provider.Synthesize(context.Background(), "Bonjour", interfaces.SpeechOptions{Voice: "fr", Speed: 1.5})
+	require.NoError(t, err)
+	defer func() { _ = body.Close() }()
+
+	data, err := io.ReadAll(body)
+	require.NoError(t, err)
+	assert.Equal(t, "RIFF-audio", string(data))
+	executor.AssertDone(t)
+}
+
+func TestCommandTTSProvider_CustomArgsAndMissingOutput(t *testing.T) {
+	fs := afero.NewMemMapFs()
+	executor := newFakeCommandExecutor(expectedCommand{
+		Name:     "say-it",
+		Contains: []string{"--text Hello there", "--rate 1.000"},
+	})
+	provider := NewCommandTTSProvider(config.TTSProviderCommand, fs, executor, config.LocalTTSConfig{
+		Binary: "say-it",
+		Args:   []string{"--text", "{text}", "--rate", "{speed}", "--out", "{output}"},
+	}, nil)
+
+	_, err := provider.Synthesize(context.Background(), "Hello there", interfaces.SpeechOptions{})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "did not write")
+	executor.AssertDone(t)
+}
+
+func TestCommandTTSProvider_PiperRequiresVoice(t *testing.T) {
+	provider := NewPiperTTSProvider(afero.NewMemMapFs(), newFakeCommandExecutor(), config.LocalTTSConfig{})
+
+	_, err := provider.Synthesize(context.Background(), "Hello", interfaces.SpeechOptions{})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "voice.piper.voice")
+}
+
+func TestCommandTTSProvider_PiperReadsTextFromStdin(t *testing.T) {
+	fs := afero.NewMemMapFs()
+	executor := newFakeCommandExecutor(expectedCommand{
+		Name:     "piper",
+		Contains: []string{"--model en_US-lessac-medium.onnx", "--output_file", "--length_scale 0.800"},
+		Stdin:    "Hello there",
+		Run: func(_ string, args []string) {
+			_ = afero.WriteFile(fs, args[3], []byte("wav"), 0644)
+		},
+	})
+	provider := NewPiperTTSProvider(fs, executor, config.LocalTTSConfig{})
+
+	body, err := provider.Synthesize(context.Background(), "Hello there", interfaces.SpeechOptions{Voice: "en_US-lessac-medium.onnx", Speed: 1.25})
+	require.NoError(t, err)
+	defer func() { _ = body.Close() }()
+	executor.AssertDone(t)
+
+	calls := executor.Calls()
+	require.Len(t, calls, 1)
+	assert.Equal(t, "Hello there", calls[0].Stdin)
+	assert.NotContains(t, calls[0].Args, "Hello there")
+}
+
+func TestAudioService_SpeechHashIncludesProvider(t *testing.T) {
+	fs := afero.NewMemMapFs()
+	logger := &mockLogger{}
+	registry := NewTTSProviderRegistryWithExecutor(fs, new(mocks.MockOpenAIClient), config.VoiceConfig{}, newFakeCommandExecutor())
+	service := NewAudioServiceWithProviders(fs, registry, NewTextService(fs, logger), logger)
+	options := interfaces.SpeechOptions{Voice: "en", Speed: 1.0}
+
+	openAIService, err := service.WithProvider(config.TTSProviderOpenAI)
+	require.NoError(t, err)
+	espeakService, err := service.WithProvider(config.TTSProviderEspeak)
+	require.NoError(t, err)
+
+	openAIHash := openAIService.WithSpeechOptions(options).computeSpeechHash("Hello")
+	espeakHash := espeakService.WithSpeechOptions(options).computeSpeechHash("Hello")
+
+	assert.NotEqual(t, openAIHash, espeakHash)
+	assert.Equal(t, openAIHash, NewAudioService(fs, new(mocks.MockOpenAIClient), NewTextService(fs, logger), logger).WithSpeechOptions(options).computeSpeechHash("Hello"))
+}
+
+func TestResolveSpeechOptions_UsesProviderSpecificVoice(t *testing.T) {
+	cfg := config.VoiceConfig{
+		Provider: config.TTSProviderOpenAI,
+		Model:    "tts-1",
+		Voice:    "alloy",
+		Speed:    1.0,
+		Piper:    config.LocalTTSConfig{Voice: "en_US-lessac-medium.onnx"},
+		PerLanguage: map[string]config.VoiceSetup{
+			"de": {Provider: config.TTSProviderPiper},
+			"fr": {Provider: config.TTSProviderEspeak, Speed: 1.2},
+		},
+	}
+
+	assert.Equal(t, interfaces.SpeechOptions{Model: "tts-1", Voice: "alloy", Speed: 1.0}, resolveSpeechOptions(cfg, "en"))
+	assert.Equal(t, interfaces.SpeechOptions{Voice: "en_US-lessac-medium.onnx", Speed: 1.0}, resolveSpeechOptions(cfg, "de"))
+	assert.Equal(t, interfaces.SpeechOptions{Voice: "fr", Speed: 1.2}, resolveSpeechOptions(cfg, "fr"))
+}
+
+func TestAudioService_Generate_StoresEngineWordTimestamps(t *testing.T) {
+	fs := afero.NewMemMapFs()
+	logger := &mockLogger{}
+	outputPath := testPath("cache", "en", "audio", "0.mp3")
+	executor := newFakeCommandExecutor(expectedCommand{
+		Name:     "tts-with-marks",
+		Contains: []string{"--marks "},
+		Run: func(_ string, args []string) {
+			require.NoError(t, afero.WriteFile(fs, args[1], []byte("RIFF-audio"), 0644))
+			require.NoError(t, afero.WriteFile(fs, args[3], []byte(`[{"word":"Hello","start":0.1,"end":0.5}]`), 0644))
+		},
+	})
+	registry := NewTTSProviderRegistryWithExecutor(fs, new(mocks.MockOpenAIClient), config.VoiceConfig{
+		Command: config.LocalTTSConfig{Binary: "tts-with-marks", Args: []string{"--out", "{output}", "--marks", "{timestamps}", "--text", "{text}"}},
+	}, executor)
+	service, err := NewAudioServiceWithExecutor(fs, registry, NewTextService(fs, logger), logger, executor).WithProvider(config.TTSProviderCommand)
+	require.NoError(t, err)
+
+	require.NoError(t, service.Generate(context.Background(), "Hello", outputPath))
+	executor.AssertDone(t)
+
+	data, err := afero.ReadFile(fs, wordTimestampsPath(outputPath))
+	require.NoError(t, err)
+	assert.JSONEq(t, `[{"word":"Hello","start":0.1,"end":0.5}]`, string(data))
+}
@@ -4,9 +4,12 @@ import (
 	"context"
 	"crypto/sha256"
 	"encoding/hex"
+	"encoding/json"
 	"fmt"
+	"io"
 	"path/filepath"
 	"regexp"
+	"sort"
 	"strconv"
 	"strings"
 	"sync"
@@ -23,12 +26,18 @@ type VideoService struct {
 	logger           interfaces.Logger
 	commandExecutor  interfaces.CommandExecutor
 	transition       TransitionConfig
+	slideTransitions map[int]TransitionConfig
 	mediaAlignment   string
 	effects          []config.EffectConfig
 	effectService    *EffectService
 	overlayService   *OverlayService
 	multiViewService *MultiViewService
 	multiViewConfig  *config.MultiViewConfig
+	pipConfig        *config.PipConfig
+	timing           config.TimingConfig
+	encoding         config.EncodingConfig
+	pictureCacheDir  string
+	pictureLocks     sync.Map // picture path -> *sync.Mutex, so languages sharing a picture encode it once
 }
 
 // NewVideoService creates a new video service
@@ -50,7 +59,7 @@ func NewVideoServiceWithExecutor(fs afero.Fs, logger interfaces.Logger, executor
 		mediaAlignment:   config.MediaAlignmentVideo,
 		effectService:    NewEffectServiceWithExecutor(fs, logger, executor),
 		overlayService:   NewOverlayService(),
-		multiViewService: NewMultiViewService(fs, logger),
+		multiViewService: NewMultiViewServiceWithExecutor(fs, logger, executor),
 	}
 }
 
@@ -59,6 +68,33 @@ func (s *VideoService) SetTransition(transition TransitionConfig) {
 	s.transition = transition
 }
 
+// SetSlideTransitions sets the transitions into specific slides, keyed by slide index. They override
+// the global transition for that cut; a "none" entry makes it a hard cut.
+func (s *VideoService) SetSlideTransitions(transitions map[int]TransitionConfig) {
+	s.slideTransitions = transitions
+}
+
+// transitionInto returns the transition from the previous slide into the slide at index.
+func (s *VideoService) transitionInto(index int) TransitionConfig {
+	if transition, ok := s.slideTransitions[index]; ok {
+		return transition
+	}
+	return s.transition
+}
+
+// hasTransitions reports whether any cut between slides uses a transition.
+func (s *VideoService) hasTransitions() bool {
+	if s.transition.IsEnabled() {
+		return true
+	}
+	for _, transition := range s.slideTransitions {
+		if transition.IsEnabled() {
+			return true
+		}
+	}
+	return false
+}
+
 // SetMediaAlignment sets how video slides are aligned against narration.
 func (s *VideoService) SetMediaAlignment(alignment string) error {
 	normalized, err := normalizeMediaAlignment(alignment)
@@ -81,11 +117,23 @@ func (s *VideoService) SetEffects(effects []config.EffectConfig) {
 	copy(s.effects, effects)
 }
 
+// SetEncoding sets the encoding settings for segments the video service composes itself and for
+// shared pictures
+func (s *VideoService) SetEncoding(encoding config.EncodingConfig) {
+	s.encoding = encoding
+	s.multiViewService.SetEncoding(encoding)
+}
+
 // SetMultiView sets the multi-view configuration
 func (s *VideoService) SetMultiView(multiViewConfig *config.MultiViewConfig) {
 	s.multiViewConfig = multiViewConfig
 }
 
+// SetPip sets the picture-in-picture overlay configuration
+func (s *VideoService) SetPip(pipConfig *config.PipConfig) {
+	s.pipConfig = pipConfig
+}
+
 // GenerateFromSlides generates videos from slides and audio
 func (s *VideoService) GenerateFromSlides(ctx context.Context, slides, audioPaths []string, outputPath string) error {
 	if len(slides) != len(audioPaths) {
@@ -97,7 +145,7 @@ func (s *VideoService) GenerateFromSlides(ctx context.Context, slides, audioPath
 	}
 
 	// Get dimensions from first slide
-	width, height, err := s.getMediaDimensions(slides[0])
+	width, height, err := s.getMediaDimensions(ctx, slides[0])
 	if err != nil {
 		return fmt.Errorf("failed to get media dimensions: %w", err)
 	}
@@ -122,42 +170,48 @@ func (s *VideoService) GenerateFromSlides(ctx context.Context, slides, audioPath
 		return fmt.Errorf("failed to create temp directory: %w", err)
 	}
 
-	// Generate individual videos
-	videoFiles := make([]string, len(slides))
-	effectsBySlide := s.resolveEffectsForSlides(slides)
-	errors := make([]error, len(slides))
-	var wg sync.WaitGroup
-
-	for i := 0; i < len(slides); i++ {
-		wg.Add(1)
-		go func(idx int) {
-			defer wg.Done()
-
-			videoPath := filepath.Join(tempDir, fmt.Sprintf("video_%d.mp4", idx))
-			videoFiles[idx] = videoPath
+	// Segments are kept per output, so languages rendering in parallel do not overwrite each other's
+	segmentDir := filepath.Join(tempDir, strings.TrimSuffix(filepath.Base(outputPath), filepath.Ext(outputPath)))
+	if err := s.fs.MkdirAll(segmentDir, 0755); err != nil {
+		return fmt.Errorf("failed to create segment directory: %w", err)
+	}
 
-			if err := s.generateSingleVideo(ctx, slides[idx], audioPaths[idx], videoPath, width, height, effectsBySlide[idx]); err != nil {
-				errors[idx] = fmt.Errorf("failed to generate video %d: %w", idx, err)
-			}
-		}(i)
+	// Apply slide timing to the narration tracks
+	audioPaths, err = s.prepareNarration(ctx, slides, audioPaths, segmentDir)
+	if err != nil {
+		return err
 	}
 
-	wg.Wait()
+	// Generate individual videos
+	videoFiles := make([]string, len(slides))
+	effectsBySlide := s.resolveEffectsForSlides(slides)
+	errors := runBounded(ctx, len(slides), DefaultScheduler().FFmpegLimit(), func(idx int) error {
+		videoPath := filepath.Join(segmentDir, fmt.Sprintf("video_%d.mp4", idx))
+		videoFiles[idx] = videoPath
 
-	// Check for errors
-	for _, err := range errors {
-		if err != nil {
-			return err
+		if err := s.generateSingleVideo(ctx, slides[idx], audioPaths[idx], videoPath, width, height, effectsBySlide[idx]); err != nil {
+			return &SlideError{Index: idx, Slide: slides[idx], Err: fmt.Errorf("failed to generate video %d: %w", idx, err)}
 		}
+		return nil
+	})
+
+	// Report every failed segment, not just the first
+	if err := joinSlideErrors(ctx, errors); err != nil {
+		return err
 	}
 
 	// Apply multi-view layouts if configured
-	if err := s.applyMultiViewLayouts(ctx, videoFiles, tempDir, width, height); err != nil {
+	if err := s.applyMultiViewLayouts(ctx, videoFiles, segmentDir, width, height); err != nil {
 		return fmt.Errorf("failed to apply multi-view layouts: %w", err)
 	}
 
+	// Apply picture-in-picture overlays if configured
+	if err := s.applyPipOverlays(ctx, videoFiles, segmentDir, width, height); err != nil {
+		return fmt.Errorf("failed to apply pip overlays: %w", err)
+	}
+
 	// Concatenate videos
-	if err := s.concatenateVideos(videoFiles, outputPath); err != nil {
+	if err := s.concatenateVideos(ctx, videoFiles, outputPath); err != nil {
 		return fmt.Errorf("failed to concatenate videos: %w", err)
 	}
 
@@ -181,6 +235,7 @@ func (s *VideoService) generateSingleVideo(
 		if err != nil {
 			s.logger.Warn("Failed to check segment cache", "error", err)
 		}
+		recordCacheLookup(ctx, CacheStageSegment, cached)
 		if cached {
 			s.logger.Info("Using cached video segment", "path", outputPath)
 			return nil
@@ -188,7 +243,7 @@ func (s *VideoService) generateSingleVideo(
 	}
 
 	// Check if the slide is actually a video.
-	isVideo, err := s.isVideoFile(slidePath)
+	isVideo, err := s.isVideoFile(ctx, slidePath)
 	if err != nil {
 		s.logger.Warn("Failed to check if file is video, treating as image", "path", slidePath, "error", err)
 		isVideo = false
@@ -203,6 +258,7 @@ func (s *VideoService) generateSingleVideo(
 		if err != nil {
 			s.logger.Warn("Failed to check segment cache", "error", err)
 		}
+		recordCacheLookup(ctx, CacheStageSegment, cached)
 		if cached {
 			s.logger.Info("Using cached video segment", "path", outputPath)
 			return nil
@@ -210,7 +266,7 @@ func (s *VideoService) generateSingleVideo(
 	}
 
 	// Get slide/video dimensions
-	iw, ih, err := s.getMediaDimensions(slidePath)
+	iw, ih, err := s.getMediaDimensions(ctx, slidePath)
 	if err != nil {
 		return err
 	}
@@ -229,12 +285,12 @@ func (s *VideoService) generateSingleVideo(
 	if isVideo {
 		s.logger.Debug("Processing video input", "path", slidePath)
 
-		videoDuration, err := s.getVideoDuration(slidePath)
+		videoDuration, err := s.getVideoDuration(ctx, slidePath)
 		if err != nil {
 			return fmt.Errorf("failed to get video duration: %w", err)
 		}
 
-		audioDuration, err := s.getVideoDuration(audioPath)
+		audioDuration, err := s.getVideoDuration(ctx, audioPath)
 		if err != nil {
 			return fmt.Errorf("failed to get audio duration: %w", err)
 		}
@@ -246,7 +302,7 @@ func (s *VideoService) generateSingleVideo(
 				"video_path", slidePath)
 		}
 
-		hasEmbeddedAudio, err := s.hasAudioStream(slidePath)
+		hasEmbeddedAudio, err := s.hasAudioStream(ctx, slidePath)
 		if err != nil {
 			return fmt.Errorf("failed to inspect embedded audio for %s: %w", slidePath, err)
 		}
@@ -259,8 +315,8 @@ func (s *VideoService) generateSingleVideo(
 	} else {
 		s.logger.Debug("Processing image input", "path", slidePath)
 
-		if len(resolvedEffects) > 0 {
-			audioDuration, err := s.getVideoDuration(audioPath)
+		if len(resolvedEffects) > 0 || s.pictureCacheDir != "" {
+			audioDuration, err := s.getVideoDuration(ctx, audioPath)
 			if err != nil {
 				return fmt.Errorf("failed to get audio duration: %w", err)
 			}
@@ -277,16 +333,24 @@ func (s *VideoService) generateSingleVideo(
 		}
 	}
 
-	args, err := s.buildSingleVideoArgs(input)
-	if err != nil {
-		return err
-	}
+	if !isVideo && s.pictureCacheDir != "" {
+		if err := s.renderWithSharedPicture(ctx, input); err != nil {
+			return err
+		}
+	} else {
+		args, err := s.buildSingleVideoArgs(input)
+		if err != nil {
+			return err
+		}
 
-	s.logger.Debug("Running ffmpeg", "command", formatCommand("ffmpeg", args...))
+		s.logger.Debug("Running ffmpeg", "command", formatCommand("ffmpeg", args...))
 
-	result, err := s.commandExecutor.Run(ctx, "ffmpeg", args...)
-	if err != nil {
-		return fmt.Errorf("ffmpeg error: %w, stderr: %s", err, string(result.Stderr))
+		discardPartialOutput(s.fs, outputPath)
+		result, err := s.commandExecutor.Run(ctx, "ffmpeg", args...)
+		if err != nil {
+			discardPartialOutput(s.fs, outputPath)
+			return fmt.Errorf("ffmpeg error: %w, stderr: %s", err, string(result.Stderr))
+		}
 	}
 
 	// Save segment hash for future cache hits
@@ -388,27 +452,30 @@ func (s *VideoService) buildSingleVideoArgs(input videoRenderInput) ([]string, e
 	return args, nil
 }
 
-func (s *VideoService) concatenateVideos(videoFiles []string, outputPath string) error {
+func (s *VideoService) concatenateVideos(ctx context.Context, videoFiles []string, outputPath string) error {
 	// Check final video cache first
 	cached, err := s.checkFinalVideoCache(videoFiles, outputPath)
 	if err != nil {
 		s.logger.Warn("Failed to check final video cache", "error", err)
 	}
+	recordCacheLookup(ctx, CacheStageFinalVideo, cached)
 	if cached {
 		s.logger.Info("Using cached final video", "path", outputPath)
 		return nil
 	}
 
+	discardPartialOutput(s.fs, outputPath)
+
 	// If transitions are disabled or only one video, use simple concatenation
-	if !s.transition.IsEnabled() || len(videoFiles) == 1 {
-		if err := s.concatenateVideosSimple(videoFiles, outputPath); err != nil {
-			return err
-		}
+	if !s.hasTransitions() || len(videoFiles) == 1 {
+		err = s.concatenateVideosSimple(ctx, videoFiles, outputPath)
 	} else {
 		// Use transitions with xfade filter
-		if err := s.concatenateVideosWithTransitions(videoFiles, outputPath); err != nil {
-			return err
-		}
+		err = s.concatenateVideosWithTransitions(ctx, videoFiles, outputPath)
+	}
+	if err != nil {
+		discardPartialOutput(s.fs, outputPath)
+		return err
 	}
 
 	// Save final video hash for future cache hits
@@ -421,7 +488,7 @@ func (s *VideoService) concatenateVideos(videoFiles []string, outputPath string)
 }
 
 // concatenateVideosSimple concatenates videos without transitions
-func (s *VideoService) concatenateVideosSimple(videoFiles []string, outputPath string) error {
+func (s *VideoService) concatenateVideosSimple(ctx context.Context, videoFiles []string, outputPath string) error {
 	args := []string{"-y"}
 
 	for _, video := range videoFiles {
@@ -439,7 +506,7 @@ func (s *VideoService) concatenateVideosSimple(videoFiles []string, outputPath s
 
 	s.logger.Debug("Concatenating videos (no transitions)", "command", formatCommand("ffmpeg", args...))
 
-	result, err := s.commandExecutor.Run(context.Background(), "ffmpeg", args...)
+	result, err := s.commandExecutor.Run(ctx, "ffmpeg", args...)
 	if err != nil {
 		return fmt.Errorf("ffmpeg concat error: %w, stderr: %s", err, string(result.Stderr))
 	}
@@ -448,7 +515,7 @@ func (s *VideoService) concatenateVideosSimple(videoFiles []string, outputPath s
 }
 
 // concatenateVideosWithTransitions concatenates videos with transition effects
-func (s *VideoService) concatenateVideosWithTransitions(videoFiles []string, outputPath string) error {
+func (s *VideoService) concatenateVideosWithTransitions(ctx context.Context, videoFiles []string, outputPath string) error {
 	// Guard: This function requires at least 2 videos for transitions
 	if len(videoFiles) < 2 {
 		return fmt.Errorf("concatenateVideosWithTransitions requires at least 2 videos, got %d", len(videoFiles))
@@ -465,26 +532,15 @@ func (s *VideoService) concatenateVideosWithTransitions(videoFiles []string, out
 	var filterComplex strings.Builder
 	var audioMix strings.Builder
 
-	transitionName := s.transition.GetFFmpegTransitionName()
-	transitionDuration := s.transition.Duration
-
 	// Get duration of each video segment for offset calculation
 	durations := make([]float64, len(videoFiles))
 	for i, video := range videoFiles {
-		duration, err := s.getVideoDuration(video)
+		duration, err := s.getVideoDuration(ctx, video)
 		if err != nil {
 			s.logger.Warn("Failed to get video duration, using default", "video", video, "error", err)
 			duration = 5.0 // Default fallback
 		}
 		durations[i] = duration
-
-		// Warn if transition duration exceeds video duration
-		if transitionDuration >= duration {
-			s.logger.Warn("Transition duration meets or exceeds video duration, may cause unexpected behavior",
-				"video", video,
-				"video_duration", duration,
-				"transition_duration", transitionDuration)
-		}
 	}
 
 	// Generate xfade transitions between consecutive videos
@@ -494,17 +550,32 @@ func (s *VideoService) concatenateVideosWithTransitions(videoFiles []string, out
 	for i := 0; i < len(videoFiles)-1; i++ {
 		nextVideoLabel := fmt.Sprintf("[%d:v]", i+1)
 		outputLabel := fmt.Sprintf("[v%d]", i)
+		transition := s.transitionInto(i + 1)
+
+		if !transition.IsEnabled() {
+			// A hard cut into this slide
+			offset += durations[i]
+			filterComplex.WriteString(fmt.Sprintf("%s%sconcat=n=2:v=1:a=0%s", currentVideoLabel, nextVideoLabel, outputLabel))
+		} else {
+			// Warn if transition duration exceeds video duration
+			if transition.Duration >= durations[i] {
+				s.logger.Warn("Transition duration meets or exceeds video duration, may cause unexpected behavior",
+					"video", videoFiles[i],
+					"video_duration", durations[i],
+					"transition_duration", transition.Duration)
+			}
 
-		// Calculate offset: accumulated duration minus transition duration
-		offset += durations[i] - transitionDuration
+			// Calculate offset: accumulated duration minus transition duration
+			offset += durations[i] - transition.Duration
 
-		// Add xfade filter
-		filterComplex.WriteString(fmt.Sprintf(
-			"%s%sxfade=transition=%s:duration=%.2f:offset=%.2f%s",
-			currentVideoLabel, nextVideoLabel,
-			transitionName, transitionDuration, offset,
-			outputLabel,
-		))
+			// Add xfade filter
+			filterComplex.WriteString(fmt.Sprintf(
+				"%s%sxfade=transition=%s:duration=%.2f:offset=%.2f%s",
+				currentVideoLabel, nextVideoLabel,
+				transition.GetFFmpegTransitionName(), transition.Duration, offset,
+				outputLabel,
+			))
+		}
 
 		if i < len(videoFiles)-2 {
 			filterComplex.WriteString(";")
@@ -529,11 +600,12 @@ func (s *VideoService) concatenateVideosWithTransitions(videoFiles []string, out
 	args = append(args, "-map", finalVideoLabel, "-map", "[outa]", outputPath)
 
 	s.logger.Debug("Concatenating videos with transitions",
-		"transition", transitionName,
-		"duration", transitionDuration,
+		"transition", s.transition.Type,
+		"duration", s.transition.Duration,
+		"slide_transitions", len(s.slideTransitions),
 		"command", formatCommand("ffmpeg", args...))
 
-	result, err := s.commandExecutor.Run(context.Background(), "ffmpeg", args...)
+	result, err := s.commandExecutor.Run(ctx, "ffmpeg", args...)
 	if err != nil {
 		return fmt.Errorf("ffmpeg concat with transitions error: %w, stderr: %s", err, string(result.Stderr))
 	}
@@ -541,8 +613,8 @@ func (s *VideoService) concatenateVideosWithTransitions(videoFiles []string, out
 	return nil
 }
 
-func (s *VideoService) getMediaDimensions(mediaPath string) (int, int, error) {
-	result, err := s.commandExecutor.Run(context.Background(), "ffmpeg", "-i", mediaPath, "-vf", "scale", "-vframes", "1", "-f", "null", "-")
+func (s *VideoService) getMediaDimensions(ctx context.Context, mediaPath string) (int, int, error) {
+	result, err := s.commandExecutor.Run(ctx, "ffmpeg", "-i", mediaPath, "-vf", "scale", "-vframes", "1", "-f", "null", "-")
 	if err != nil {
 		return 0, 0, fmt.Errorf("ffmpeg dimension check failed: %w", err)
 	}
@@ -563,8 +635,8 @@ func (s *VideoService) getMediaDimensions(mediaPath string) (int, int, error) {
 }
 
 // isVideoFile checks if a file is a video (not a static image)
-func (s *VideoService) isVideoFile(filePath string) (bool, error) {
-	result, err := s.commandExecutor.Run(context.Background(), "ffprobe", "-v", "error", "-select_streams", "v:0",
+func (s *VideoService) isVideoFile(ctx context.Context, filePath string) (bool, error) {
+	result, err := s.commandExecutor.Run(ctx, "ffprobe", "-v", "error", "-select_streams", "v:0",
 		"-show_entries", "stream=codec_type,duration", "-of", "default=noprint_wrappers=1", filePath)
 	if err != nil {
 		return false, fmt.Errorf("ffprobe check failed: %w", err)
@@ -594,8 +666,8 @@ func (s *VideoService) isVideoFile(filePath string) (bool, error) {
 	return hasVideoCodec && duration > 0, nil
 }
 
-func (s *VideoService) hasAudioStream(filePath string) (bool, error) {
-	result, err := s.commandExecutor.Run(context.Background(), "ffprobe", "-v", "error",
+func (s *VideoService) hasAudioStream(ctx context.Context, filePath string) (bool, error) {
+	result, err := s.commandExecutor.Run(ctx, "ffprobe", "-v", "error",
 		"-show_entries", "stream=codec_type", "-of", "default=noprint_wrappers=1:nokey=1", filePath)
 	if err != nil {
 		return false, fmt.Errorf("ffprobe audio stream check failed: %w", err)
@@ -611,8 +683,8 @@ func (s *VideoService) hasAudioStream(filePath string) (bool, error) {
 }
 
 // getVideoDuration gets the duration of a video file in seconds
-func (s *VideoService) getVideoDuration(videoPath string) (float64, error) {
-	result, err := s.commandExecutor.Run(context.Background(), "ffprobe", "-v", "error", "-show_entries",
+func (s *VideoService) getVideoDuration(ctx context.Context, videoPath string) (float64, error) {
+	result, err := s.commandExecutor.Run(ctx, "ffprobe", "-v", "error", "-show_entries",
 		"format=duration", "-of", "default=noprint_wrappers=1:nokey=1", videoPath)
 	if err != nil {
 		return 0, fmt.Errorf("ffprobe duration check failed: %w", err)
@@ -761,6 +833,17 @@ func (s *VideoService) computeFinalVideoHash(videoFiles []string) (string, error
 	if _, err := fmt.Fprintf(hasher, "%s:%.2f", s.transition.Type, s.transition.Duration); err != nil {
 		return "", fmt.Errorf("failed to write transition config to hash: %w", err)
 	}
+	indexes := make([]int, 0, len(s.slideTransitions))
+	for index := range s.slideTransitions {
+		indexes = append(indexes, index)
+	}
+	sort.Ints(indexes)
+	for _, index := range indexes {
+		transition := s.slideTransitions[index]
+		if _, err := fmt.Fprintf(hasher, "|%d=%s:%.2f", index, transition.Type, transition.Duration); err != nil {
+			return "", fmt.Errorf("failed to write transition config to hash: %w", err)
+		}
+	}
 
 	return hex.EncodeToString(hasher.Sum(nil)), nil
 }
@@ -820,46 +903,71 @@ func (s *VideoService) applyMultiViewLayouts(ctx context.Context, videoFiles []s
 
 	s.logger.Info("Applying multi-view layouts", "layouts", len(s.multiViewConfig.Layouts))
 
-	// Build a map of which slides have multi-view layouts
+	// Build a map of which slides have multi-view layouts. Sources are synchronized once per layout,
+	// however many slides use it.
 	multiViewMap := make(map[int]config.LayoutConfig)
+	syncedLayouts := make(map[int]*syncedLayout)
 	for _, layout := range s.multiViewConfig.Layouts {
+		synced := &syncedLayout{layout: layout}
 		slideIndices := layout.ParseSlides(len(videoFiles))
 		for _, idx := range slideIndices {
 			multiViewMap[idx] = layout
+			syncedLayouts[idx] = synced
 			s.logger.Debug("Multi-view layout for slide", "slide", idx, "type", layout.Type)
 		}
 	}
 
 	// Apply layouts
-	var wg sync.WaitGroup
-	errors := make([]error, len(videoFiles))
+	errors := runBounded(ctx, len(videoFiles), DefaultScheduler().FFmpegLimit(), func(slideIdx int) error {
+		layoutCfg, ok := multiViewMap[slideIdx]
+		if !ok {
+			return nil
+		}
 
-	for idx, layout := range multiViewMap {
-		wg.Add(1)
-		go func(slideIdx int, layoutCfg config.LayoutConfig) {
-			defer wg.Done()
+		// Compose the layout on top of the narrated segment
+		segmentPath := videoFiles[slideIdx]
+		multiViewPath := filepath.Join(tempDir, fmt.Sprintf("multiview_%d.mp4", slideIdx))
 
-			// Generate multi-view video
-			multiViewPath := filepath.Join(tempDir, fmt.Sprintf("multiview_%d.mp4", slideIdx))
+		hash, err := s.computeMultiViewHash(segmentPath, layoutCfg, width, height)
+		if err != nil {
+			s.logger.Warn("Failed to compute multi-view cache hash", "slide", slideIdx, "error", err)
+		}
+		cached := hash != "" && s.hashMatches(multiViewPath, hash)
+		recordCacheLookup(ctx, CacheStageSegment, cached)
+
+		if cached {
+			s.logger.Info("Using cached multi-view segment", "slide", slideIdx, "path", multiViewPath)
+		} else {
+			// The narrated segment already follows the slide's media alignment
+			duration, err := s.getVideoDuration(ctx, segmentPath)
+			if err != nil {
+				return fmt.Errorf("failed to get duration of slide %d: %w", slideIdx, err)
+			}
 
 			if err := s.multiViewService.GenerateMultiViewVideo(
 				ctx,
-				layoutCfg,
+				s.multiViewService.syncLayout(ctx, syncedLayouts[slideIdx]),
+				segmentPath,
+				duration,
 				multiViewPath,
 				width,
 				height,
 			); err != nil {
-				errors[slideIdx] = fmt.Errorf("failed to generate multi-view for slide %d: %w", slideIdx, err)
-				return
+				return fmt.Errorf("failed to generate multi-view for slide %d: %w", slideIdx, err)
 			}
 
-			// Replace the original video with the multi-view version
-			videoFiles[slideIdx] = multiViewPath
-			s.logger.Info("Multi-view applied", "slide", slideIdx, "type", layoutCfg.Type)
-		}(idx, layout)
-	}
+			if hash != "" {
+				if err := afero.WriteFile(s.fs, multiViewPath+".hash", []byte(hash), 0644); err != nil {
+					s.logger.Warn("Failed to save multi-view segment hash", "error", err)
+				}
+			}
+		}
 
-	wg.Wait()
+		// Replace the original video with the multi-view version
+		videoFiles[slideIdx] = multiViewPath
+		s.logger.Info("Multi-view applied", "slide", slideIdx, "type", layoutCfg.Type)
+		return nil
+	})
 
 	// Check for errors
 	for _, err := range errors {
@@ -874,3 +982,50 @@ func (s *VideoService) applyMultiViewLayouts(ctx context.Context, videoFiles []s
 
 	return nil
 }
+
+// computeMultiViewHash computes a cache key for a multi-view segment from the narrated segment, the
+// layout, its source files, and the encoding settings.
+func (s *VideoService) computeMultiViewHash(segmentPath string, layout config.LayoutConfig, width, height int) (string, error) {
+	hasher := sha256.New()
+
+	if err := hashFileContents(s.fs, hasher, segmentPath); err != nil {
+		return "", fmt.Errorf("failed to read segment file: %w", err)
+	}
+
+	for _, source := range s.multiViewService.getInputFiles(layout) {
+		if source == config.MultiViewSlideSource {
+			continue
+		}
+		if err := hashFileContents(s.fs, hasher, source); err != nil {
+			return "", fmt.Errorf("failed to read multi-view source %s: %w", source, err)
+		}
+	}
+
+	layoutData, err := json.Marshal(layout)
+	if err != nil {
+		return "", fmt.Errorf("failed to serialize multi-view layout for cache: %w", err)
+	}
+	hasher.Write(layoutData)
+	encodingData, err := json.Marshal(s.multiViewService.encoding)
+	if err != nil {
+		return "", fmt.Errorf("failed to serialize encoding settings for cache: %w", err)
+	}
+	hasher.Write(encodingData)
+	if _, err := fmt.Fprintf(hasher, "%dx%d", width, height); err != nil {
+		return "", fmt.Errorf("failed to write dimensions to hash: %w", err)
+	}
+
+	return hex.EncodeToString(hasher.Sum(nil)), nil
+}
+
+// hashFileContents streams the file at path into hasher without loading it into memory.
+func hashFileContents(fs afero.Fs, hasher io.Writer, path string) error {
+	file, err := fs.Open(path)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = file.Close() }()
+
+	_, err = io.Copy(hasher, file)
+	return err
+}
@@ -1,6 +1,7 @@
 package services
 
 import (
+	"context"
 	"testing"
 
 	"gocreator/internal/config"
@@ -318,7 +319,71 @@ func TestVideoService_concatenateVideosWithTransitions_GuardSingleVideo(t *testi
 	videoFiles := []string{video1}
 
 	// Should return error when called with single video
-	err := service.concatenateVideosWithTransitions(videoFiles, outputPath)
+	err := service.concatenateVideosWithTransitions(context.Background(), videoFiles, outputPath)
 	require.Error(t, err)
 	assert.Contains(t, err.Error(), "requires at least 2 videos")
 }
+
+func TestVideoService_concatenateVideos_DiscardsPartialOutputOnCancel(t *testing.T) {
+	fs := afero.NewMemMapFs()
+	outputPath := "/test/final.mp4"
+	video1 := "/test/video1.mp4"
+
+	require.NoError(t, afero.WriteFile(fs, video1, []byte("video1 data"), 0644))
+	require.NoError(t, afero.WriteFile(fs, outputPath, []byte("previous render"), 0644))
+	require.NoError(t, afero.WriteFile(fs, outputPath+".hash", []byte("stale"), 0644))
+
+	executor := newFakeCommandExecutor(expectedCommand{
+		Name:     "ffmpeg",
+		Contains: []string{outputPath},
+		Err:      context.Canceled,
+		Run: func(name string, args []string) {
+			_ = afero.WriteFile(fs, outputPath, []byte("half-written"), 0644)
+		},
+	})
+	service := NewVideoServiceWithExecutor(fs, &mockLogger{}, executor)
+
+	err := service.concatenateVideos(context.Background(), []string{video1}, outputPath)
+	require.ErrorIs(t, err, context.Canceled)
+	executor.AssertDone(t)
+
+	exists, err := afero.Exists(fs, outputPath)
+	require.NoError(t, err)
+	assert.False(t, exists, "partial output must be removed")
+
+	exists, err = afero.Exists(fs, outputPath+".hash")
+	require.NoError(t, err)
+	assert.False(t, exists, "stale hash must be removed")
+}
+
+func TestVideoService_concatenateVideos_UsesSlideTransitions(t *testing.T) {
+	fs := afero.NewMemMapFs()
+	videoFiles := []string{"/test/video0.mp4", "/test/video1.mp4", "/test/video2.mp4"}
+	outputPath := "/test/final.mp4"
+	for _, video := range videoFiles {
+		require.NoError(t, afero.WriteFile(fs, video, []byte(video), 0644))
+	}
+
+	executor := newFakeCommandExecutor(
+		expectedCommand{Name: "ffprobe", Contains: []string{videoFiles[0]}, Result: newCommandResult("3.0\n", "")},
+		expectedCommand{Name: "ffprobe", Contains: []string{videoFiles[1]}, Result: newCommandResult("5.0\n", "")},
+		expectedCommand{Name: "ffprobe", Contains: []string{videoFiles[2]}, Result: newCommandResult("4.0\n", "")},
+		expectedCommand{Name: "ffmpeg", Contains: []string{
+			"[0:v][1:v]concat=n=2:v=1:a=0[v0];[v0][2:v]xfade=transition=wipeleft:duration=1.00:offset=7.00[v1]",
+			"-map [v1]",
+		}},
+	)
+	service := NewVideoServiceWithExecutor(fs, &mockLogger{}, executor)
+	service.SetSlideTransitions(map[int]TransitionConfig{2: {Type: TransitionWipeleft, Duration: 1}})
+
+	require.NoError(t, service.concatenateVideos(context.Background(), videoFiles, outputPath))
+	executor.AssertDone(t)
+
+	// Slide transitions are part of the final video cache key.
+	hash, err := service.computeFinalVideoHash(videoFiles)
+	require.NoError(t, err)
+	service.SetSlideTransitions(nil)
+	plainHash, err := service.computeFinalVideoHash(videoFiles)
+	require.NoError(t, err)
+	assert.NotEqual(t, hash, plainHash)
+}
@@ -0,0 +1,190 @@
+package services
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"math"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/spf13/afero"
+)
+
+// sharedPictureStep is the length granularity of shared pictures without effects. Such a picture
+// is rendered up to the next multiple of the step, so narrations of similar length reuse it.
+const sharedPictureStep = 5.0
+
+// defaultPictureFrameRate is the frame rate of shared pictures when encoding.video.fps is not set,
+// matching what ffmpeg uses for a looped image.
+const defaultPictureFrameRate = 25
+
+// SetSharedPictureCache switches image slides to the shared-picture render strategy: their silent
+// picture is encoded once into dir, and every language muxes its narration onto a copy of it.
+func (s *VideoService) SetSharedPictureCache(dir string) {
+	s.pictureCacheDir = dir
+}
+
+// renderWithSharedPicture renders an image slide segment by muxing the narration onto the shared
+// picture without re-encoding it. The picture is at least as long as the narration and has a key
+// frame every second, so -shortest ends the segment with the narration, to the frame.
+func (s *VideoService) renderWithSharedPicture(ctx context.Context, input videoRenderInput) error {
+	picturePath, err := s.ensureSharedPicture(ctx, input)
+	if err != nil {
+		return err
+	}
+
+	args := []string{
+		"-y",
+		"-i", picturePath,
+		"-i", input.audioPath,
+		"-map", "0:v:0",
+		"-map", "1:a:0",
+		"-c:v", "copy",
+		"-c:a", "mp3", "-b:a", "192k",
+		"-shortest",
+		"-t", fmt.Sprintf("%.3f", input.audioDuration),
+		input.outputPath,
+	}
+
+	s.logger.Debug("Muxing narration onto shared picture", "command", formatCommand("ffmpeg", args...))
+
+	discardPartialOutput(s.fs, input.outputPath)
+	result, err := s.commandExecutor.Run(ctx, "ffmpeg", args...)
+	if err != nil {
+		discardPartialOutput(s.fs, input.outputPath)
+		return fmt.Errorf("ffmpeg error: %w, stderr: %s", err, string(result.Stderr))
+	}
+	return nil
+}
+
+// ensureSharedPicture returns the content-addressed silent picture for the slide, encoding it when no
+// language has done so yet. A picture only counts as cached once its hash file is written after
+// the encode completes.
+func (s *VideoService) ensureSharedPicture(ctx context.Context, input videoRenderInput) (string, error) {
+	picture := input
+	picture.audioPath = ""
+	picture.audioDuration = sharedPictureLength(input)
+
+	hash, err := s.computePictureHash(picture)
+	if err != nil {
+		return "", err
+	}
+	picture.outputPath = filepath.Join(s.pictureCacheDir, fmt.Sprintf("picture_%s.mp4", hash[:16]))
+
+	lock, _ := s.pictureLocks.LoadOrStore(picture.outputPath, &sync.Mutex{})
+	lock.(*sync.Mutex).Lock()
+	defer lock.(*sync.Mutex).Unlock()
+
+	if outputComplete(s.fs, picture.outputPath, hash) {
+		recordCacheLookup(ctx, CacheStagePicture, true)
+		return picture.outputPath, nil
+	}
+	recordCacheLookup(ctx, CacheStagePicture, false)
+
+	if err := s.fs.MkdirAll(s.pictureCacheDir, 0755); err != nil {
+		return "", fmt.Errorf("failed to create picture cache directory: %w", err)
+	}
+
+	args, err := s.buildPictureArgs(picture)
+	if err != nil {
+		return "", err
+	}
+
+	s.logger.Debug("Rendering shared picture", "slide", input.slidePath, "command", formatCommand("ffmpeg", args...))
+
+	discardPartialOutput(s.fs, picture.outputPath)
+	result, err := s.commandExecutor.Run(ctx, "ffmpeg", args...)
+	if err != nil {
+		discardPartialOutput(s.fs, picture.outputPath)
+		return "", fmt.Errorf("ffmpeg error: %w, stderr: %s", err, string(result.Stderr))
+	}
+	if err := markOutputComplete(s.fs, picture.outputPath, hash); err != nil {
+		return "", err
+	}
+	return picture.outputPath, nil
+}
+
+// sharedPictureLength returns how long the shared picture for a segment runs, never shorter than its
+// narration. Effects animate over the whole slide, so a picture with effects is only shared by
+// segments of the same length.
+func sharedPictureLength(input videoRenderInput) float64 {
+	if len(input.effects) > 0 {
+		// The tolerance keeps float noise such as 12.3*100 = 1230.0000000000002 from adding a step.
+		return math.Ceil(input.audioDuration*100-1e-6) / 100
+	}
+	return math.Max(math.Ceil(input.audioDuration/sharedPictureStep), 1) * sharedPictureStep
+}
+
+// computePictureHash computes the cache key of a shared picture from the slide, its size and length,
+// its effects, and the encoding settings.
+func (s *VideoService) computePictureHash(picture videoRenderInput) (string, error) {
+	slideData, err := afero.ReadFile(s.fs, picture.slidePath)
+	if err != nil {
+		return "", fmt.Errorf("failed to read slide file: %w", err)
+	}
+
+	hasher := sha256.New()
+	hasher.Write(slideData)
+	if _, err := fmt.Fprintf(hasher, "%dx%d:%.2f", picture.targetWidth, picture.targetHeight, picture.audioDuration); err != nil {
+		return "", fmt.Errorf("failed to write picture settings to hash: %w", err)
+	}
+	if len(picture.effects) > 0 {
+		effectSignature, err := serializeEffectsForCache(picture.effects)
+		if err != nil {
+			return "", err
+		}
+		if _, err := hasher.Write([]byte(effectSignature)); err != nil {
+			return "", fmt.Errorf("failed to write effects to hash: %w", err)
+		}
+	}
+	encodingData, err := json.Marshal(s.encoding)
+	if err != nil {
+		return "", fmt.Errorf("failed to serialize encoding settings for cache: %w", err)
+	}
+	hasher.Write(encodingData)
+
+	return hex.EncodeToString(hasher.Sum(nil)), nil
+}
+
+// buildPictureArgs builds the ffmpeg arguments that encode a still slide, with its effects, into a
+// silent picture of picture.audioDuration seconds with the configured encoding settings.
+func (s *VideoService) buildPictureArgs(picture videoRenderInput) ([]string, error) {
+	frameRate := s.encoding.Video.FPS
+	if frameRate <= 0 {
+		frameRate = defaultPictureFrameRate
+	}
+	args := []string{"-y", "-loop", "1", "-framerate", strconv.Itoa(frameRate), "-i", picture.slidePath}
+
+	if len(picture.effects) > 0 {
+		filterSegments, videoMap, err := s.buildVideoEffectsGraph(picture)
+		if err != nil {
+			return nil, err
+		}
+		if len(filterSegments) > 0 {
+			args = append(args, "-filter_complex", strings.Join(filterSegments, ";"))
+		}
+		args = append(args, "-map", videoMap)
+	} else if picture.targetWidth != picture.inputWidth || picture.targetHeight != picture.inputHeight {
+		scaleFilter := fmt.Sprintf("scale=%d:%d:force_original_aspect_ratio=decrease", picture.targetWidth, picture.targetHeight)
+		padFilter := fmt.Sprintf("pad=%d:%d:(ow-iw)/2:(oh-ih)/2,setsar=1", picture.targetWidth, picture.targetHeight)
+		args = append(args, "-vf", fmt.Sprintf("%s,%s", scaleFilter, padFilter))
+	}
+
+	args = append(args, NewEncodingService(s.encoding).BuildVideoArgs()...)
+	if codec := s.encoding.Video.Codec; codec == "" || codec == "libx264" {
+		args = append(args, "-tune", "stillimage")
+	}
+	// A key frame every second lets the mux cut the copied picture at any frame.
+	args = append(args,
+		"-g", strconv.Itoa(frameRate),
+		"-an",
+		"-t", fmt.Sprintf("%.2f", picture.audioDuration),
+		picture.outputPath,
+	)
+	return args, nil
+}
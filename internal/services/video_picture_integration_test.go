@@ -0,0 +1,55 @@
+//go:build integration
+
+package services
+
+import (
+	"context"
+	"image/color"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestVideoService_GenerateSingleVideo_SharedPictureMatchesNarrationLength(t *testing.T) {
+	requireFFmpegTools(t)
+
+	tempDir := t.TempDir()
+	slidePath := filepath.Join(tempDir, "slide.png")
+	require.NoError(t, writeSolidPNG(slidePath, 320, 240, color.RGBA{R: 20, G: 80, B: 180, A: 255}))
+
+	service := NewVideoService(afero.NewOsFs(), &mockLogger{})
+	service.SetSharedPictureCache(filepath.Join(tempDir, "pictures"))
+
+	// Both narrations share one 5 second picture and must still end on their own length.
+	for _, narration := range []float64{2.3, 3.71} {
+		audioPath := filepath.Join(tempDir, strconv.FormatFloat(narration, 'f', -1, 64)+".wav")
+		outputPath := filepath.Join(tempDir, strconv.FormatFloat(narration, 'f', -1, 64)+".mp4")
+		runExternalCommand(t, "ffmpeg", "-y", "-f", "lavfi", "-i", "anullsrc=r=44100:cl=mono", "-t", strconv.FormatFloat(narration, 'f', -1, 64), "-c:a", "pcm_s16le", audioPath)
+
+		require.NoError(t, service.generateSingleVideo(context.Background(), slidePath, audioPath, outputPath, 320, 240, nil))
+
+		assert.InDelta(t, narration, probeVideoStreamDuration(t, outputPath), 1.0/defaultPictureFrameRate+0.001, "video length of %s", outputPath)
+		duration, err := service.getVideoDuration(context.Background(), outputPath)
+		require.NoError(t, err)
+		assert.InDelta(t, narration, duration, 1.0/defaultPictureFrameRate+0.05, "length of %s", outputPath)
+	}
+
+	pictures, err := filepath.Glob(filepath.Join(tempDir, "pictures", "*.mp4"))
+	require.NoError(t, err)
+	assert.Len(t, pictures, 1)
+}
+
+func probeVideoStreamDuration(t *testing.T, path string) float64 {
+	t.Helper()
+	output, err := exec.Command("ffprobe", "-v", "error", "-select_streams", "v:0", "-show_entries", "stream=duration", "-of", "default=noprint_wrappers=1:nokey=1", path).Output()
+	require.NoError(t, err)
+	duration, err := strconv.ParseFloat(strings.TrimSpace(string(output)), 64)
+	require.NoError(t, err)
+	return duration
+}
@@ -0,0 +1,124 @@
+package services
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"gocreator/internal/config"
+
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestVideoService_GenerateSingleVideo_SharedPictureEncodesOncePerSlide(t *testing.T) {
+	fs := afero.NewMemMapFs()
+	slidePath := testPath("test", "data", "slides", "1.png")
+	pictureDir := testPath("test", "data", "cache", "pictures")
+	enAudio := testPath("test", "data", "cache", "en", "audio", "1.mp3")
+	frAudio := testPath("test", "data", "cache", "fr", "audio", "1.mp3")
+	enOutput := testPath("test", "data", "out", ".temp", "output-en", "video_0.mp4")
+	frOutput := testPath("test", "data", "out", ".temp", "output-fr", "video_0.mp4")
+	require.NoError(t, writeTestFile(fs, slidePath, "slide"))
+	require.NoError(t, writeTestFile(fs, enAudio, "english narration"))
+	require.NoError(t, writeTestFile(fs, frAudio, "french narration"))
+
+	writeOutput := func(_ string, args []string) {
+		require.NoError(t, writeTestFile(fs, args[len(args)-1], "video"))
+	}
+	probeImage := []expectedCommand{
+		{Name: "ffprobe", Result: newCommandResult("codec_type=video\nduration=N/A\n", "")},
+		{Name: "ffmpeg", Result: newCommandResult("", "Stream #0:0: Video: png, rgb24, 1280x720\n")},
+	}
+
+	executor := newFakeCommandExecutor(append(probeImage,
+		expectedCommand{Name: "ffprobe", Contains: []string{enAudio}, Result: newCommandResult("12.3\n", "")},
+		expectedCommand{
+			Name:     "ffmpeg",
+			Contains: []string{"-loop 1 -framerate 25 -i " + slidePath, "scale=1920:1080", "-c:v libx264", "-tune stillimage", "-g 25 -an -t 15.00", pictureDir},
+			Run:      writeOutput,
+		},
+		expectedCommand{
+			Name:     "ffmpeg",
+			Contains: []string{"-i " + enAudio, "-c:v copy", "-shortest -t 12.300", enOutput},
+			Run:      writeOutput,
+		},
+	)...)
+	service := NewVideoServiceWithExecutor(fs, &mockLogger{}, executor)
+	service.SetSharedPictureCache(pictureDir)
+
+	require.NoError(t, service.generateSingleVideo(context.Background(), slidePath, enAudio, enOutput, 1920, 1080, nil))
+	executor.AssertDone(t)
+
+	// A narration of similar length in another language reuses the picture
+	executor.expectations = append(probeImage,
+		expectedCommand{Name: "ffprobe", Contains: []string{frAudio}, Result: newCommandResult("13.8\n", "")},
+		expectedCommand{
+			Name:     "ffmpeg",
+			Contains: []string{"-i " + pictureDir, "-i " + frAudio, "-map 0:v:0 -map 1:a:0 -c:v copy", "-t 13.800", frOutput},
+			Run:      writeOutput,
+		},
+	)
+	require.NoError(t, service.generateSingleVideo(context.Background(), slidePath, frAudio, frOutput, 1920, 1080, nil))
+	executor.AssertDone(t)
+
+	pictures, err := afero.Glob(fs, filepath.Join(pictureDir, "*.mp4"))
+	require.NoError(t, err)
+	assert.Len(t, pictures, 1)
+
+	// Each language's segment is still cached on its own narration
+	require.NoError(t, service.generateSingleVideo(context.Background(), slidePath, frAudio, frOutput, 1920, 1080, nil))
+	executor.AssertDone(t)
+}
+
+func TestVideoService_ensureSharedPicture_UsesEncodingAndRebuildsIncompletePictures(t *testing.T) {
+	fs := afero.NewMemMapFs()
+	slidePath := testPath("test", "data", "slides", "1.png")
+	pictureDir := testPath("test", "data", "cache", "pictures")
+	require.NoError(t, writeTestFile(fs, slidePath, "slide"))
+	input := videoRenderInput{slidePath: slidePath, audioDuration: 7, inputWidth: 1920, inputHeight: 1080, targetWidth: 1920, targetHeight: 1080}
+
+	var picturePath string
+	executor := newFakeCommandExecutor(expectedCommand{
+		Name:     "ffmpeg",
+		Contains: []string{"-framerate 30 -i " + slidePath, "-c:v libx264 -preset slow -crf 20 -r 30", "-g 30 -an -t 10.00"},
+		Run: func(_ string, args []string) {
+			picturePath = args[len(args)-1]
+			require.NoError(t, writeTestFile(fs, picturePath, "picture"))
+		},
+	})
+	service := NewVideoServiceWithExecutor(fs, &mockLogger{}, executor)
+	service.SetSharedPictureCache(pictureDir)
+	service.SetEncoding(config.EncodingConfig{Video: config.VideoEncodingConfig{Preset: "slow", CRF: 20, FPS: 30}})
+
+	path, err := service.ensureSharedPicture(context.Background(), input)
+	require.NoError(t, err)
+	executor.AssertDone(t)
+	assert.Equal(t, picturePath, path)
+
+	// A picture left without its hash by a killed run is encoded again
+	require.NoError(t, fs.Remove(picturePath+".hash"))
+	executor.expectations = []expectedCommand{{Name: "ffmpeg", Contains: []string{picturePath}}}
+	_, err = service.ensureSharedPicture(context.Background(), input)
+	require.NoError(t, err)
+	executor.AssertDone(t)
+
+	// Other encoding settings get their own picture
+	service.SetEncoding(config.EncodingConfig{Video: config.VideoEncodingConfig{CRF: 28, FPS: 30}})
+	executor.expectations = []expectedCommand{{Name: "ffmpeg", Contains: []string{"-crf 28"}}}
+	reencoded, err := service.ensureSharedPicture(context.Background(), input)
+	require.NoError(t, err)
+	executor.AssertDone(t)
+	assert.NotEqual(t, picturePath, reencoded)
+}
+
+func TestSharedPictureLength(t *testing.T) {
+	effects := []config.EffectConfig{{Type: "ken-burns"}}
+
+	assert.Equal(t, 5.0, sharedPictureLength(videoRenderInput{audioDuration: 0.4}))
+	assert.Equal(t, 15.0, sharedPictureLength(videoRenderInput{audioDuration: 10.01}))
+	assert.Equal(t, 15.0, sharedPictureLength(videoRenderInput{audioDuration: 15}))
+	assert.Equal(t, 12.35, sharedPictureLength(videoRenderInput{audioDuration: 12.3456, effects: effects}))
+	assert.Equal(t, 12.3, sharedPictureLength(videoRenderInput{audioDuration: 12.3, effects: effects}))
+}
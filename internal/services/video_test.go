@@ -1,6 +1,7 @@
 package services
 
 import (
+	"context"
 	"strings"
 	"testing"
 
@@ -210,3 +211,43 @@ func TestVideoServiceResolveEffectsForSlide_DeterministicRandom(t *testing.T) {
 	assert.NotEqual(t, "random", first[0].Config.Direction)
 	assert.Contains(t, []string{"left", "right", "up", "down", "center"}, third[0].Config.Direction)
 }
+
+func TestVideoService_applyMultiViewLayouts_KeysCacheOnNarratedSegment(t *testing.T) {
+	fs := afero.NewMemMapFs()
+	tempDir := testPath("out", ".temp")
+	segment := testPath("out", ".temp", "video_0.mp4")
+	webcam := testPath("media", "webcam.mp4")
+	multiViewPath := testPath("out", ".temp", "multiview_0.mp4")
+	require.NoError(t, writeTestFile(fs, segment, "english narration"))
+	require.NoError(t, writeTestFile(fs, webcam, "webcam"))
+
+	layout := config.LayoutConfig{Type: "pip", Slides: "0", Main: config.MultiViewSlideSource, Overlay: webcam}
+	service := NewVideoServiceWithExecutor(fs, &mockLogger{}, newFakeCommandExecutor())
+	service.SetMultiView(&config.MultiViewConfig{Enabled: true, Layouts: []config.LayoutConfig{layout}})
+
+	hash, err := service.computeMultiViewHash(segment, layout, 1920, 1080)
+	require.NoError(t, err)
+	require.NoError(t, writeTestFile(fs, multiViewPath, "composed"))
+	require.NoError(t, writeTestFile(fs, multiViewPath+".hash", hash))
+
+	// A cached composition skips ffmpeg entirely
+	videoFiles := []string{segment}
+	require.NoError(t, service.applyMultiViewLayouts(context.Background(), videoFiles, tempDir, 1920, 1080))
+	assert.Equal(t, []string{multiViewPath}, videoFiles)
+
+	// Other narration or audio settings produce a different key
+	require.NoError(t, writeTestFile(fs, segment, "french narration"))
+	translated, err := service.computeMultiViewHash(segment, layout, 1920, 1080)
+	require.NoError(t, err)
+	assert.NotEqual(t, hash, translated)
+
+	layout.Audio.SourceVolumes = []float64{1, 0.3}
+	remixed, err := service.computeMultiViewHash(segment, layout, 1920, 1080)
+	require.NoError(t, err)
+	assert.NotEqual(t, translated, remixed)
+
+	service.SetEncoding(config.EncodingConfig{Video: config.VideoEncodingConfig{CRF: 18}})
+	reencoded, err := service.computeMultiViewHash(segment, layout, 1920, 1080)
+	require.NoError(t, err)
+	assert.NotEqual(t, remixed, reencoded)
+}
@@ -122,9 +122,14 @@ func (m *ProgressModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	switch msg := msg.(type) {
 	case tea.KeyMsg:
 		switch msg.String() {
-		case "q", "ctrl+c":
+		case "q":
 			m.quitting = true
 			return m, tea.Quit
+		case "ctrl+c":
+			// The terminal is in raw mode, so Ctrl-C arrives as a key press rather than SIGINT.
+			// Interrupting makes Run return tea.ErrInterrupted so the caller can cancel the work.
+			m.quitting = true
+			return m, tea.Interrupt
 		}
 
 	case tea.WindowSizeMsg:
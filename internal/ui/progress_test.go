@@ -149,3 +149,15 @@ func TestProgressModel_Update_KeyMsg(t *testing.T) {
 	_, cmd := model.Update(keyMsg)
 	assert.NotNil(t, cmd)
 }
+
+func TestProgressModel_Update_CtrlCInterrupts(t *testing.T) {
+	model := NewProgressModel()
+
+	_, cmd := model.Update(tea.KeyMsg{Type: tea.KeyCtrlC})
+	assert.NotNil(t, cmd)
+	assert.Equal(t, tea.InterruptMsg{}, cmd())
+
+	_, cmd = model.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("q")})
+	assert.NotNil(t, cmd)
+	assert.Equal(t, tea.QuitMsg{}, cmd())
+}